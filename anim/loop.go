@@ -0,0 +1,259 @@
+// Package anim runs fixed-timestep animation loops.
+//
+// A Loop calls an update function at a fixed tick rate, so simulation speed
+// does not depend on how fast frames are drawn, and calls a render function
+// at a capped frame rate, writing each frame to an io.Writer. When rendering
+// falls behind, several updates run before the next frame (frame skipping),
+// up to a limit after which the remaining backlog is dropped.
+package anim
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// Default loop settings.
+const (
+	DefaultFrameRate  = 60 // frames per second
+	DefaultMaxUpdates = 5  // updates per frame before the backlog is dropped
+	DefaultTickRate   = 60 // updates per second
+)
+
+// statsInterval is how often the stats callback is called.
+const statsInterval = time.Second
+
+// Clock is a source of time for a Loop.
+// Tests can inject a fake clock to make a loop deterministic.
+type Clock interface {
+	Now() time.Time
+	Sleep(duration time.Duration)
+}
+
+// systemClock is the Clock backed by the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time               { return time.Now() }
+func (systemClock) Sleep(duration time.Duration) { time.Sleep(duration) }
+
+// Stats describes the recent performance of a Loop.
+type Stats struct {
+	Dropped   uint64        // total updates dropped because the backlog exceeded the update limit
+	FPS       float64       // frames rendered per second over the last interval
+	FrameTime time.Duration // average time spent updating and rendering one frame over the last interval
+	Frames    uint64        // total frames rendered
+	Ticks     uint64        // total updates run
+}
+
+// UpdateFunc advances the animation by one fixed tick of length dt.
+type UpdateFunc func(dt time.Duration)
+
+// RenderFunc returns the frame to draw. Alpha is the fraction of a tick that
+// has elapsed since the last update, in [0, 1), for interpolating positions.
+type RenderFunc func(alpha float64) string
+
+// Option configures a Loop.
+type Option func(*Loop)
+
+// WithClock sets the clock used for timing. The default is the system clock.
+func WithClock(clock Clock) Option {
+	return func(loop *Loop) {
+		loop.clock = clock
+	}
+}
+
+// WithTickRate sets the number of updates per second.
+// Rates of zero or less are ignored.
+func WithTickRate(rate float64) Option {
+	return func(loop *Loop) {
+		if rate > 0 {
+			loop.tickInterval = interval(rate)
+		}
+	}
+}
+
+// WithFrameRate caps the number of frames rendered per second.
+// A rate of 0 removes the cap, rendering once after every tick.
+func WithFrameRate(rate float64) Option {
+	return func(loop *Loop) {
+		loop.frameInterval = interval(rate)
+	}
+}
+
+// WithMaxUpdates sets how many updates may run before a single render.
+// When the loop falls further behind, the extra updates are dropped, so a
+// stall slows the animation down instead of fast-forwarding it.
+func WithMaxUpdates(count int) Option {
+	return func(loop *Loop) {
+		loop.maxUpdates = max(count, 1)
+	}
+}
+
+// WithStats sets a callback that receives loop statistics once per second.
+func WithStats(callback func(Stats)) Option {
+	return func(loop *Loop) {
+		loop.onStats = callback
+	}
+}
+
+// Loop runs update and render functions on a fixed timestep.
+// Pause, Resume, and Stop are safe to call from any goroutine, including
+// from within the update and render functions.
+type Loop struct {
+	clock         Clock         // source of time
+	frameInterval time.Duration // minimum time between renders, 0 when uncapped
+	maxUpdates    int           // updates allowed before a render
+	onStats       func(Stats)   // statistics callback, nil when unset
+	output        io.Writer     // destination for rendered frames
+	paused        atomic.Bool   // whether updates and renders are suspended
+	render        RenderFunc    // produces frames
+	stats         Stats         // running totals
+	stopped       atomic.Bool   // whether Stop has been called
+	tickInterval  time.Duration // time between updates
+	update        UpdateFunc    // advances the animation
+}
+
+// New creates a Loop that calls update at the tick rate and writes the frames
+// returned by render to output. It runs at DefaultTickRate updates and at most
+// DefaultFrameRate frames per second unless configured otherwise.
+func New(output io.Writer, update UpdateFunc, render RenderFunc, options ...Option) *Loop {
+	loop := &Loop{
+		clock:         systemClock{},
+		frameInterval: interval(DefaultFrameRate),
+		maxUpdates:    DefaultMaxUpdates,
+		output:        output,
+		render:        render,
+		tickInterval:  interval(DefaultTickRate),
+		update:        update,
+	}
+
+	for _, option := range options {
+		option(loop)
+	}
+
+	return loop
+}
+
+// Run runs the loop until ctx is done, Stop is called, or writing a frame
+// fails. It returns ctx.Err() when the context ends the loop, the write error
+// when writing fails, and nil after Stop.
+// Time spent paused is not counted, so the animation resumes where it left off.
+func (loop *Loop) Run(ctx context.Context) error {
+	previous := loop.clock.Now()
+	lastRender := previous
+	var lag time.Duration
+	pending := false            // updates have run since the last render
+	var frameBusy time.Duration // time spent on those updates
+
+	windowStart := previous
+	var windowFrames uint64
+	var windowBusy time.Duration
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if loop.stopped.Load() {
+			return nil
+		}
+
+		now := loop.clock.Now()
+		elapsed := now.Sub(previous)
+		previous = now
+
+		if loop.paused.Load() {
+			loop.clock.Sleep(loop.tickInterval)
+			continue
+		}
+
+		// Catch up on the ticks that are due, dropping any beyond the limit
+		lag += elapsed
+		updates := 0
+		for lag >= loop.tickInterval && updates < loop.maxUpdates {
+			loop.update(loop.tickInterval)
+			lag -= loop.tickInterval
+			updates++
+			loop.stats.Ticks++
+		}
+		if updates > 0 {
+			frameBusy += loop.clock.Now().Sub(now)
+		}
+		if lag >= loop.tickInterval {
+			loop.stats.Dropped += uint64(lag / loop.tickInterval)
+			lag %= loop.tickInterval
+		}
+
+		// Render when something changed and the frame cap allows it
+		pending = pending || updates > 0
+		if pending && now.Sub(lastRender) >= loop.frameInterval {
+			renderStart := loop.clock.Now()
+			alpha := float64(lag) / float64(loop.tickInterval)
+			if _, err := io.WriteString(loop.output, loop.render(alpha)); err != nil {
+				return err
+			}
+			lastRender = now
+			pending = false
+			loop.stats.Frames++
+			windowFrames++
+			windowBusy += frameBusy + loop.clock.Now().Sub(renderStart)
+			frameBusy = 0
+		}
+
+		if loop.onStats != nil {
+			if window := now.Sub(windowStart); window >= statsInterval {
+				stats := loop.stats
+				stats.FPS = float64(windowFrames) / window.Seconds()
+				if windowFrames > 0 {
+					stats.FrameTime = windowBusy / time.Duration(windowFrames)
+				}
+				loop.onStats(stats)
+				windowStart, windowFrames, windowBusy = now, 0, 0
+			}
+		}
+
+		loop.clock.Sleep(loop.untilNext(now, lag, lastRender, pending))
+	}
+}
+
+// Pause suspends updates and renders until Resume is called.
+func (loop *Loop) Pause() {
+	loop.paused.Store(true)
+}
+
+// Resume continues a paused loop.
+func (loop *Loop) Resume() {
+	loop.paused.Store(false)
+}
+
+// Paused reports whether the loop is paused.
+func (loop *Loop) Paused() bool {
+	return loop.paused.Load()
+}
+
+// Stop ends Run after the current iteration.
+func (loop *Loop) Stop() {
+	loop.stopped.Store(true)
+}
+
+// untilNext returns how long to sleep until the earliest due event, given the
+// iteration that started at start: the next tick or, when updates are waiting
+// to be drawn, the next allowed render. Waking for whichever comes first keeps
+// fast tick rates from piling up between capped frames.
+func (loop *Loop) untilNext(start time.Time, lag time.Duration, lastRender time.Time, pending bool) time.Duration {
+	current := loop.clock.Now()
+	wait := loop.tickInterval - lag - current.Sub(start)
+	if untilRender := loop.frameInterval - current.Sub(lastRender); pending && untilRender < wait {
+		wait = untilRender
+	}
+	return max(wait, 0)
+}
+
+// interval converts a rate per second to the time between events.
+// Rates of zero or less return 0.
+func interval(rate float64) time.Duration {
+	if rate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(time.Second) / rate)
+}
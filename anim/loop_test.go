@@ -0,0 +1,277 @@
+package anim
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock whose time only moves when Sleep or Advance is called.
+type fakeClock struct {
+	now     time.Time
+	onSleep func() // called after every Sleep, nil when unset
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (clock *fakeClock) Now() time.Time { return clock.now }
+
+func (clock *fakeClock) Sleep(duration time.Duration) {
+	clock.Advance(duration)
+	if clock.onSleep != nil {
+		clock.onSleep()
+	}
+}
+
+func (clock *fakeClock) Advance(duration time.Duration) {
+	clock.now = clock.now.Add(duration)
+}
+
+// runFor runs loop until the fake clock passes duration past its start.
+func runFor(t *testing.T, loop *Loop, clock *fakeClock, duration time.Duration) {
+	t.Helper()
+	end := clock.now.Add(duration)
+	clock.onSleep = func() {
+		if clock.now.After(end) {
+			loop.Stop()
+		}
+	}
+	if err := loop.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+}
+
+func TestLoopRates(t *testing.T) {
+	tests := []struct {
+		name           string
+		tickRate       float64
+		frameRate      float64
+		expectedTicks  int
+		expectedFrames int
+	}{
+		{"matching rates", 60, 60, 60, 60},
+		{"frame cap below tick rate", 60, 30, 60, 30},
+		{"frame cap above tick rate", 20, 60, 20, 20},
+		{"many ticks per frame", 600, 60, 600, 60},
+		{"uncapped", 10, 0, 10, 10},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			clock := newFakeClock()
+			var output bytes.Buffer
+			ticks := 0
+			loop := New(&output,
+				func(dt time.Duration) { ticks++ },
+				func(alpha float64) string { return "#" },
+				WithClock(clock), WithTickRate(testCase.tickRate), WithFrameRate(testCase.frameRate))
+
+			runFor(t, loop, clock, time.Second)
+
+			if ticks != testCase.expectedTicks {
+				t.Errorf("ticks = %d, want %d", ticks, testCase.expectedTicks)
+			}
+			if frames := output.Len(); frames != testCase.expectedFrames {
+				t.Errorf("frames = %d, want %d", frames, testCase.expectedFrames)
+			}
+			if loop.stats.Dropped != 0 {
+				t.Errorf("Stats.Dropped = %d without a stall, want 0", loop.stats.Dropped)
+			}
+		})
+	}
+}
+
+func TestLoopFixedTimestep(t *testing.T) {
+	clock := newFakeClock()
+	var steps []time.Duration
+	loop := New(&bytes.Buffer{},
+		func(dt time.Duration) { steps = append(steps, dt) },
+		func(alpha float64) string {
+			if alpha < 0 || alpha >= 1 {
+				t.Errorf("alpha = %v, want [0, 1)", alpha)
+			}
+			return ""
+		},
+		WithClock(clock), WithTickRate(50))
+
+	runFor(t, loop, clock, 100*time.Millisecond)
+
+	if len(steps) != 5 {
+		t.Fatalf("updates = %d, want 5", len(steps))
+	}
+	for index, step := range steps {
+		if step != 20*time.Millisecond {
+			t.Errorf("update %d dt = %v, want 20ms", index, step)
+		}
+	}
+}
+
+func TestLoopFrameSkipping(t *testing.T) {
+	// A slow render makes several ticks due at once; they run before the next
+	// frame, up to the update limit, and the rest are dropped
+	clock := newFakeClock()
+	var output bytes.Buffer
+	ticks := 0
+	var reported Stats
+	loop := New(&output,
+		func(dt time.Duration) { ticks++ },
+		func(alpha float64) string {
+			clock.Advance(100 * time.Millisecond)
+			return "#"
+		},
+		WithClock(clock), WithTickRate(100), WithFrameRate(0), WithMaxUpdates(4),
+		WithStats(func(stats Stats) { reported = stats }))
+
+	runFor(t, loop, clock, 2*time.Second)
+
+	frames := output.Len()
+	if frames < 18 || frames > 21 {
+		t.Errorf("frames = %d, want about 20", frames)
+	}
+	if ticks > 4*frames {
+		t.Errorf("ticks = %d, want at most %d (4 per frame)", ticks, 4*frames)
+	}
+	if ticks <= frames {
+		t.Errorf("ticks = %d, want more than one per frame (%d frames)", ticks, frames)
+	}
+	if reported.Dropped == 0 {
+		t.Errorf("Stats.Dropped = 0, want dropped updates")
+	}
+	if total := reported.Ticks + reported.Dropped; total < 90 {
+		t.Errorf("Stats.Ticks + Stats.Dropped = %d, want at least 90 after one second", total)
+	}
+}
+
+func TestLoopPauseAndResume(t *testing.T) {
+	clock := newFakeClock()
+	ticks := 0
+	var loop *Loop
+	loop = New(&bytes.Buffer{},
+		func(dt time.Duration) {
+			ticks++
+			if ticks == 10 {
+				loop.Pause()
+			}
+		},
+		func(alpha float64) string { return "" },
+		WithClock(clock), WithTickRate(10))
+
+	pausedAt := time.Time{}
+	clock.onSleep = func() {
+		switch {
+		case loop.Paused() && pausedAt.IsZero():
+			pausedAt = clock.now
+		case loop.Paused() && clock.now.Sub(pausedAt) >= 5*time.Second:
+			if ticks != 10 {
+				t.Errorf("ticks while paused = %d, want 10", ticks)
+			}
+			loop.Resume()
+		case !loop.Paused() && ticks >= 15:
+			loop.Stop()
+		}
+	}
+	if err := loop.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if ticks != 15 {
+		t.Errorf("ticks = %d, want 15", ticks)
+	}
+	// Paused time is not caught up on after resuming
+	if elapsed := clock.now.Sub(pausedAt); elapsed > 5*time.Second+600*time.Millisecond {
+		t.Errorf("time after pause = %v, want about 5.5s", elapsed)
+	}
+}
+
+func TestLoopStats(t *testing.T) {
+	clock := newFakeClock()
+	var reports []Stats
+	loop := New(&bytes.Buffer{},
+		func(dt time.Duration) { clock.Advance(2 * time.Millisecond) },
+		func(alpha float64) string {
+			clock.Advance(3 * time.Millisecond)
+			return ""
+		},
+		WithClock(clock), WithTickRate(60), WithFrameRate(30),
+		WithStats(func(stats Stats) { reports = append(reports, stats) }))
+
+	runFor(t, loop, clock, 3*time.Second)
+
+	if len(reports) < 2 {
+		t.Fatalf("stats reports = %d, want at least 2", len(reports))
+	}
+	last := reports[len(reports)-1]
+	if last.FPS < 29 || last.FPS > 31 {
+		t.Errorf("Stats.FPS = %.2f, want about 30", last.FPS)
+	}
+	// Two updates and one render per frame
+	if last.FrameTime != 7*time.Millisecond {
+		t.Errorf("Stats.FrameTime = %v, want 7ms", last.FrameTime)
+	}
+	if last.Ticks < 2*last.Frames-2 || last.Ticks > 2*last.Frames+2 {
+		t.Errorf("Stats.Ticks = %d, want about twice Stats.Frames (%d)", last.Ticks, last.Frames)
+	}
+	if last.Dropped != 0 {
+		t.Errorf("Stats.Dropped = %d, want 0", last.Dropped)
+	}
+}
+
+func TestLoopContextCancel(t *testing.T) {
+	clock := newFakeClock()
+	ctx, cancel := context.WithCancel(context.Background())
+	ticks := 0
+	loop := New(&bytes.Buffer{},
+		func(dt time.Duration) {
+			ticks++
+			if ticks == 3 {
+				cancel()
+			}
+		},
+		func(alpha float64) string { return "" },
+		WithClock(clock))
+
+	if err := loop.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want %v", err, context.Canceled)
+	}
+	if ticks != 3 {
+		t.Errorf("ticks = %d, want 3", ticks)
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{ err error }
+
+func (writer failingWriter) Write([]byte) (int, error) { return 0, writer.err }
+
+func TestLoopWriteError(t *testing.T) {
+	failure := errors.New("write failed")
+	loop := New(failingWriter{failure},
+		func(dt time.Duration) {},
+		func(alpha float64) string { return "frame" },
+		WithClock(newFakeClock()))
+
+	if err := loop.Run(context.Background()); !errors.Is(err, failure) {
+		t.Errorf("Run() error = %v, want %v", err, failure)
+	}
+}
+
+func TestLoopWritesFrames(t *testing.T) {
+	clock := newFakeClock()
+	var output strings.Builder
+	count := 0
+	loop := New(&output,
+		func(dt time.Duration) { count++ },
+		func(alpha float64) string { return strings.Repeat("⣿", count) + "\n" },
+		WithClock(clock), WithTickRate(10), WithFrameRate(10))
+
+	runFor(t, loop, clock, 300*time.Millisecond)
+
+	if expected := "⣿\n⣿⣿\n⣿⣿⣿\n"; output.String() != expected {
+		t.Errorf("output = %q, want %q", output.String(), expected)
+	}
+}
@@ -1,19 +1,28 @@
 package canvas
 
-import (
-	"math"
-	"strings"
-)
+import "math"
 
 // Canvas represents a braille graphics canvas.
 // Each terminal cell displays a 2x4 braille pattern, providing pixel-level control.
+// With WithHalfBlock, each terminal cell instead displays two vertically stacked
+// pixels with independent colors.
 type Canvas struct {
-	cells        [][]rune  // braille character grid [row][col]
-	colorEnabled bool      // whether color support is enabled
-	colors       [][]Color // color grid [row][col], nil when colors disabled
-	height       int       // pixel height
-	invertY      bool      // Y-axis direction: false = down, true = up
-	width        int       // pixel width
+	cells         [][]rune                  // braille character grid [row][col], one cell per pixel in half-block mode
+	clip          *Rect                     // writes outside this rectangle are ignored, nil when unclipped
+	colorEnabled  bool                      // whether color support is enabled
+	colorPolicy   ColorPolicy               // how a cell's color is resolved from its dots
+	colorSequence *uint64                   // write counter for ordering dot colors, shared with Sub views
+	colors        [][]Color                 // resolved color grid [row][col], nil when colors disabled
+	depth         [][]float64               // per-pixel depth buffer [y][x] in screen rows, nil when depth disabled
+	depthEnabled  bool                      // whether depth buffer support is enabled
+	dotColors     [][][dotsPerCell]dotColor // per-dot color grid [row][col][dot], nil when colors disabled
+	halfBlock     bool                      // whether cells render as two-color half blocks
+	height        int                       // pixel height
+	invertY       bool                      // Y-axis direction: false = down, true = up
+	shared        bool                      // whether storage is shared with a Sub view or its parent, so it is cleared in place
+	text          [][]textCell              // text overlay [row][col] in terminal cells, nil when no text was written
+	view          bool                      // whether the canvas is a Sub view of another canvas's storage
+	width         int                       // pixel width
 }
 
 // New creates a new Canvas with the specified pixel dimensions.
@@ -29,9 +38,12 @@ func New(width, height int, options ...Option) *Canvas {
 		option(canvas)
 	}
 
-	// Allocate cells grid
-	rows := canvas.Rows()
-	columns := canvas.Cols()
+	// Allocate cells grid (half-block mode stores the two pixels of a terminal
+	// cell in separate rows, so each pixel keeps its own color)
+	rows, columns := canvas.Rows(), canvas.Cols()
+	if canvas.halfBlock {
+		rows *= 2
+	}
 	canvas.cells = make([][]rune, rows)
 	for row := range canvas.cells {
 		canvas.cells[row] = make([]rune, columns)
@@ -43,14 +55,37 @@ func New(width, height int, options ...Option) *Canvas {
 	// Allocate colors grid when color support is enabled
 	if canvas.colorEnabled {
 		canvas.colors = make([][]Color, rows)
+		canvas.colorSequence = new(uint64)
+		canvas.dotColors = make([][][dotsPerCell]dotColor, rows)
 		for row := range canvas.colors {
 			canvas.colors[row] = make([]Color, columns)
+			canvas.dotColors[row] = make([][dotsPerCell]dotColor, columns)
 		}
 	}
 
+	// Allocate the depth buffer when depth testing is enabled
+	if canvas.depthEnabled {
+		canvas.depth = make([][]float64, height)
+		for row := range canvas.depth {
+			canvas.depth[row] = make([]float64, width)
+		}
+		canvas.ClearDepth()
+	}
+
 	return canvas
 }
 
+// NewCells creates a new Canvas that fills the given number of terminal columns
+// and rows, using 2x4 pixels per cell (1x2 with WithHalfBlock).
+func NewCells(columns, rows int, options ...Option) *Canvas {
+	probe := &Canvas{}
+	for _, option := range options {
+		option(probe)
+	}
+	cellWidth, cellHeight := probe.terminalCellSize()
+	return New(columns*cellWidth, rows*cellHeight, options...)
+}
+
 // Width returns the pixel width of the canvas.
 func (canvas *Canvas) Width() int {
 	return canvas.width
@@ -61,54 +96,121 @@ func (canvas *Canvas) Height() int {
 	return canvas.height
 }
 
-// Rows returns the number of terminal rows (height / 4).
+// Rows returns the number of terminal rows (height / 4, or height / 2 in half-block mode).
 func (canvas *Canvas) Rows() int {
+	if canvas.halfBlock {
+		return canvas.height / 2
+	}
 	return canvas.height / 4
 }
 
-// Cols returns the number of terminal columns (width / 2).
+// Cols returns the number of terminal columns (width / 2, or width in half-block mode).
 func (canvas *Canvas) Cols() int {
+	if canvas.halfBlock {
+		return canvas.width
+	}
 	return canvas.width / 2
 }
 
+// InvertedY reports whether the canvas was created with WithInvertedY.
+func (canvas *Canvas) InvertedY() bool {
+	return canvas.invertY
+}
+
+// HalfBlock reports whether the canvas was created with WithHalfBlock, where
+// each pixel has its own color rather than each 2x4 cell.
+func (canvas *Canvas) HalfBlock() bool {
+	return canvas.halfBlock
+}
+
+// CellSize returns the pixel dimensions of one terminal cell: 2x4, or 1x2 in
+// half-block mode.
+func (canvas *Canvas) CellSize() (width, height int) {
+	return canvas.terminalCellSize()
+}
+
+// ScreenToCanvasY converts a pixel row counted down from the top of the
+// display to the canvas y coordinate of that row, accounting for WithInvertedY,
+// so code that walks pixels in screen order can pass the result to Set.
+func (canvas *Canvas) ScreenToCanvasY(screenY int) float64 {
+	if canvas.invertY {
+		return float64(canvas.height - 1 - screenY)
+	}
+	return float64(screenY)
+}
+
+// CellToPixel converts a terminal cell position (0-based, from the top-left of
+// the canvas) to the canvas coordinates of the cell's top-left pixel.
+// The result accounts for WithInvertedY, so it can be passed to Get or Set.
+func (canvas *Canvas) CellToPixel(column, row int) (x, y float64) {
+	cellWidth, cellHeight := canvas.terminalCellSize()
+	pixelX := column * cellWidth
+	pixelY := row * cellHeight
+	if canvas.invertY {
+		pixelY = canvas.height - 1 - pixelY
+	}
+	return float64(pixelX), float64(pixelY)
+}
+
 // Set turns on the pixel at the specified coordinates.
 func (canvas *Canvas) Set(x, y float64) {
 	cellRow, cellColumn, dotRow, dotColumn, ok := canvas.pixelToCell(x, y)
-	if !ok {
+	if !ok || canvas.clipped(x, y) {
 		return
 	}
 	canvas.cells[cellRow][cellColumn] |= pixelMap[dotRow][dotColumn]
 }
 
 // SetColor sets the pixel at the specified coordinates and assigns the given color
-// to the containing cell. Without WithColor(), the pixel is set but color is ignored.
+// to it. The containing cell's color is then resolved from its lit dots using the
+// canvas ColorPolicy (by default the last color written wins).
+// Without WithColor(), the pixel is set but color is ignored.
 func (canvas *Canvas) SetColor(x, y float64, color Color) {
+	canvas.SetColorZ(x, y, color, 0)
+}
+
+// SetColorZ is like SetColor but also records a z value for the dot.
+// With ColorPriority, the dot with the highest z decides the cell's color;
+// the other policies ignore z.
+func (canvas *Canvas) SetColorZ(x, y float64, color Color, z float64) {
 	cellRow, cellColumn, dotRow, dotColumn, ok := canvas.pixelToCell(x, y)
-	if !ok {
+	if !ok || canvas.clipped(x, y) {
 		return
 	}
 	canvas.cells[cellRow][cellColumn] |= pixelMap[dotRow][dotColumn]
-	if canvas.colors != nil {
-		canvas.colors[cellRow][cellColumn] = color
+	if canvas.colors == nil {
+		return
 	}
+
+	dot := &canvas.dotColors[cellRow][cellColumn][dotIndex(dotRow, dotColumn)]
+	if !canvas.colorPolicy.replaces(*dot, z) {
+		return
+	}
+	*canvas.colorSequence++
+	*dot = dotColor{color: color, sequence: *canvas.colorSequence, z: z}
+	canvas.resolveColor(cellRow, cellColumn)
 }
 
 // Unset turns off the pixel at the specified coordinates.
 func (canvas *Canvas) Unset(x, y float64) {
 	cellRow, cellColumn, dotRow, dotColumn, ok := canvas.pixelToCell(x, y)
-	if !ok {
+	if !ok || canvas.clipped(x, y) {
 		return
 	}
 	canvas.cells[cellRow][cellColumn] &^= pixelMap[dotRow][dotColumn]
+	canvas.clearDotColor(cellRow, cellColumn, dotRow, dotColumn)
 }
 
 // Toggle inverts the pixel at the specified coordinates.
 func (canvas *Canvas) Toggle(x, y float64) {
 	cellRow, cellColumn, dotRow, dotColumn, ok := canvas.pixelToCell(x, y)
-	if !ok {
+	if !ok || canvas.clipped(x, y) {
 		return
 	}
 	canvas.cells[cellRow][cellColumn] ^= pixelMap[dotRow][dotColumn]
+	if canvas.cells[cellRow][cellColumn]&pixelMap[dotRow][dotColumn] == 0 {
+		canvas.clearDotColor(cellRow, cellColumn, dotRow, dotColumn)
+	}
 }
 
 // Get returns true if the pixel at the specified coordinates is set.
@@ -120,8 +222,44 @@ func (canvas *Canvas) Get(x, y float64) bool {
 	return canvas.cells[cellRow][cellColumn]&pixelMap[dotRow][dotColumn] != 0
 }
 
-// Clear resets all cells to the empty braille pattern.
+// GetColor returns the resolved color of the cell containing the specified pixel
+// (the color of the pixel itself in half-block mode).
+// Returns ColorDefault for out-of-bounds coordinates or when colors are disabled.
+func (canvas *Canvas) GetColor(x, y float64) Color {
+	cellRow, cellColumn, _, _, ok := canvas.pixelToCell(x, y)
+	if !ok || canvas.colors == nil {
+		return ColorDefault
+	}
+	return canvas.colors[cellRow][cellColumn]
+}
+
+// SetClip limits Set, SetColor, SetColorZ, SetDepth, SetColorDepth, Unset, and
+// Toggle to pixels inside rect, so drawing functions can be confined to a region.
+// Get and Clear are not affected.
+func (canvas *Canvas) SetClip(rect Rect) {
+	canvas.clip = &rect
+}
+
+// Clip returns the clipping rectangle set by SetClip, and false when the
+// canvas is unclipped, so a caller can restore it after clipping temporarily.
+func (canvas *Canvas) Clip() (Rect, bool) {
+	if canvas.clip == nil {
+		return Rect{}, false
+	}
+	return *canvas.clip, true
+}
+
+// ClearClip removes the clipping rectangle set by SetClip.
+func (canvas *Canvas) ClearClip() {
+	canvas.clip = nil
+}
+
+// Clear resets all cells to the empty braille pattern and removes any text.
+// With WithDepth, the depth buffer is reset as well. Clearing a Sub view
+// clears only its region of the parent.
 func (canvas *Canvas) Clear() {
+	canvas.ClearText()
+	canvas.ClearDepth()
 	for row := range canvas.cells {
 		for column := range canvas.cells[row] {
 			canvas.cells[row][column] = BrailleOffset
@@ -131,47 +269,54 @@ func (canvas *Canvas) Clear() {
 		for row := range canvas.colors {
 			for column := range canvas.colors[row] {
 				canvas.colors[row][column] = ColorDefault
+				canvas.dotColors[row][column] = [dotsPerCell]dotColor{}
 			}
 		}
+		// Views leave the shared counter alone, since the parent's other
+		// cells still hold dots ordered by it
+		if !canvas.view {
+			*canvas.colorSequence = 0
+		}
 	}
 }
 
 // Frame renders the canvas to a string with rows joined by newlines.
+// Use FrameWith to render with block or ASCII characters instead of braille.
 func (canvas *Canvas) Frame() string {
-	if !canvas.colorEnabled {
-		// Fast path: no colors (existing implementation)
-		rows := make([]string, len(canvas.cells))
-		for index, row := range canvas.cells {
-			rows[index] = string(row)
-		}
-		return strings.Join(rows, "\n")
+	return canvas.FrameWith(RendererBraille)
+}
+
+// pixelToCell converts pixel coordinates to cell and dot positions.
+// Returns ok = false for out-of-bounds coordinates.
+func (canvas *Canvas) pixelToCell(x, y float64) (cellRow, cellColumn, dotRow, dotColumn int, ok bool) {
+	pixelX, pixelY, ok := canvas.screenPixel(x, y)
+	if !ok {
+		return 0, 0, 0, 0, false
 	}
 
-	// Color-enabled path
-	var builder strings.Builder
-	for rowIndex, row := range canvas.cells {
-		if rowIndex > 0 {
-			builder.WriteByte('\n')
-		}
-		for columnIndex, cell := range row {
-			color := canvas.colors[rowIndex][columnIndex]
-			if color != ColorDefault {
-				builder.WriteString(color.ANSI())
-				builder.WriteRune(cell)
-				builder.WriteString(ANSIReset())
-			} else {
-				builder.WriteRune(cell)
-			}
-		}
+	// Calculate cell position (half-block mode stores one pixel per cell)
+	cellWidth, cellHeight := canvas.cellSize()
+	cellColumn = pixelX / cellWidth
+	cellRow = pixelY / cellHeight
+
+	// Check bounds against cell dimensions (handles dimension truncation)
+	if cellRow >= len(canvas.cells) || cellColumn >= canvas.Cols() {
+		return 0, 0, 0, 0, false
 	}
-	return builder.String()
+
+	// Calculate dot position within cell
+	dotColumn = pixelX % cellWidth
+	dotRow = pixelY % cellHeight
+
+	return cellRow, cellColumn, dotRow, dotColumn, true
 }
 
-// pixelToCell converts pixel coordinates to cell and dot positions.
+// screenPixel converts canvas coordinates to a pixel position counted from the
+// top-left corner, accounting for WithInvertedY.
 // Returns ok = false for out-of-bounds coordinates.
-func (canvas *Canvas) pixelToCell(x, y float64) (cellRow, cellColumn, dotRow, dotColumn int, ok bool) {
-	pixelX := int(math.Floor(x))
-	pixelY := int(math.Floor(y))
+func (canvas *Canvas) screenPixel(x, y float64) (pixelX, pixelY int, ok bool) {
+	pixelX = int(math.Floor(x))
+	pixelY = int(math.Floor(y))
 
 	// Handle Y-axis inversion
 	if canvas.invertY {
@@ -180,21 +325,28 @@ func (canvas *Canvas) pixelToCell(x, y float64) (cellRow, cellColumn, dotRow, do
 
 	// Check bounds against pixel dimensions
 	if pixelX < 0 || pixelX >= canvas.width || pixelY < 0 || pixelY >= canvas.height {
-		return 0, 0, 0, 0, false
+		return 0, 0, false
 	}
+	return pixelX, pixelY, true
+}
 
-	// Calculate cell position
-	cellColumn = pixelX / 2
-	cellRow = pixelY / 4
+// clipped reports whether writes to the pixel at (x, y) are blocked by the clip rectangle.
+func (canvas *Canvas) clipped(x, y float64) bool {
+	return canvas.clip != nil && !canvas.clip.Contains(math.Floor(x), math.Floor(y))
+}
 
-	// Check bounds against cell dimensions (handles dimension truncation)
-	if cellRow >= canvas.Rows() || cellColumn >= canvas.Cols() {
-		return 0, 0, 0, 0, false
+// terminalCellSize returns the pixel dimensions of one terminal cell.
+func (canvas *Canvas) terminalCellSize() (width, height int) {
+	if canvas.halfBlock {
+		return 1, 2
 	}
+	return 2, 4
+}
 
-	// Calculate dot position within cell
-	dotColumn = pixelX % 2
-	dotRow = pixelY % 4
-
-	return cellRow, cellColumn, dotRow, dotColumn, true
+// cellSize returns the pixel dimensions of a stored cell.
+func (canvas *Canvas) cellSize() (width, height int) {
+	if canvas.halfBlock {
+		return 1, 1
+	}
+	return 2, 4
 }
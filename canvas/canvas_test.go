@@ -1,20 +1,21 @@
 package canvas
 
 import (
-	"flag"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
 )
 
-var visualFlag = flag.Bool("visual", false, "print visual output")
-
-func TestMain(m *testing.M) {
-	flag.Parse()
-	os.Exit(m.Run())
+// visual reports whether STIPPLE_VISUAL asks for drawings to be logged. The
+// canvas package cannot import stippletest, so it reads the variable itself.
+func visual() bool {
+	enabled, err := strconv.ParseBool(os.Getenv("STIPPLE_VISUAL"))
+	return err == nil && enabled
 }
 
 func printVisual(t *testing.T, name string, canvas *Canvas) {
-	if *visualFlag {
+	if visual() {
 		t.Logf("\n=== %s ===\n%s", name, canvas.Frame())
 	}
 }
@@ -203,6 +204,13 @@ func TestInvertedY(t *testing.T) {
 		t.Errorf("cells[1][0] = %#x, want %#x", canvas.cells[1][0], expectedCell)
 	}
 
+	if !canvas.InvertedY() {
+		t.Error("InvertedY() = false with WithInvertedY(), want true")
+	}
+	if New(4, 8).InvertedY() {
+		t.Error("InvertedY() = true without WithInvertedY(), want false")
+	}
+
 	printVisual(t, "TestInvertedY", canvas)
 }
 
@@ -319,3 +327,159 @@ func TestDimensionTruncationBounds(t *testing.T) {
 
 	printVisual(t, "TestDimensionTruncationBounds", canvas)
 }
+
+func TestNewCells(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       []Option
+		width, height int
+	}{
+		{"braille", nil, 20, 24},
+		{"half block", []Option{WithHalfBlock()}, 10, 12},
+		{"inverted", []Option{WithInvertedY()}, 20, 24},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			canvas := NewCells(10, 6, testCase.options...)
+			if canvas.Width() != testCase.width || canvas.Height() != testCase.height {
+				t.Errorf("NewCells(10, 6) = %dx%d pixels, want %dx%d",
+					canvas.Width(), canvas.Height(), testCase.width, testCase.height)
+			}
+			if canvas.Cols() != 10 || canvas.Rows() != 6 {
+				t.Errorf("NewCells(10, 6) = %d cols x %d rows, want 10x6", canvas.Cols(), canvas.Rows())
+			}
+		})
+	}
+}
+
+func TestCellSize(t *testing.T) {
+	tests := []struct {
+		name           string
+		options        []Option
+		expectedWidth  int
+		expectedHeight int
+	}{
+		{"braille", nil, 2, 4},
+		{"half block", []Option{WithHalfBlock()}, 1, 2},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			width, height := New(10, 16, testCase.options...).CellSize()
+			if width != testCase.expectedWidth || height != testCase.expectedHeight {
+				t.Errorf("CellSize() = (%d, %d), want (%d, %d)", width, height, testCase.expectedWidth, testCase.expectedHeight)
+			}
+		})
+	}
+}
+
+func TestScreenToCanvasY(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []Option
+		screenY  int
+		expected float64
+	}{
+		{"top row", nil, 0, 0},
+		{"bottom row", nil, 15, 15},
+		{"inverted top row", []Option{WithInvertedY()}, 0, 15},
+		{"inverted bottom row", []Option{WithInvertedY()}, 15, 0},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			canvas := New(10, 16, testCase.options...)
+			if actual := canvas.ScreenToCanvasY(testCase.screenY); actual != testCase.expected {
+				t.Errorf("ScreenToCanvasY(%d) = %v, want %v", testCase.screenY, actual, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestCellToPixel(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     []Option
+		column, row int
+		expectedX   float64
+		expectedY   float64
+	}{
+		{"origin", nil, 0, 0, 0, 0},
+		{"braille", nil, 3, 2, 6, 8},
+		{"inverted origin", []Option{WithInvertedY()}, 0, 0, 0, 15},
+		{"inverted", []Option{WithInvertedY()}, 3, 2, 6, 7},
+		{"half block", []Option{WithHalfBlock()}, 3, 2, 3, 4},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			canvas := New(10, 16, testCase.options...)
+			x, y := canvas.CellToPixel(testCase.column, testCase.row)
+			if x != testCase.expectedX || y != testCase.expectedY {
+				t.Errorf("CellToPixel(%d, %d) = (%.0f, %.0f), want (%.0f, %.0f)",
+					testCase.column, testCase.row, x, y, testCase.expectedX, testCase.expectedY)
+			}
+
+			// The pixel lies inside the requested cell
+			canvas.Set(x, y)
+			frameRows := strings.Split(canvas.Frame(), "\n")
+			cell := []rune(frameRows[testCase.row])[testCase.column]
+			if cell == BrailleOffset || cell == ' ' {
+				t.Errorf("pixel (%.0f, %.0f) is not in cell (%d, %d)", x, y, testCase.column, testCase.row)
+			}
+		})
+	}
+}
+
+func TestClip(t *testing.T) {
+	canvas := New(8, 8, WithColor())
+	canvas.SetClip(Rect{X: 2, Y: 2, Width: 3, Height: 3})
+
+	for y := 0.0; y < 8; y++ {
+		for x := 0.0; x < 8; x++ {
+			canvas.SetColor(x, y, ColorRed)
+		}
+	}
+	for y := 0.0; y < 8; y++ {
+		for x := 0.0; x < 8; x++ {
+			inside := x >= 2 && x < 5 && y >= 2 && y < 5
+			if canvas.Get(x, y) != inside {
+				t.Errorf("Get(%.0f, %.0f) = %v, want %v", x, y, canvas.Get(x, y), inside)
+			}
+		}
+	}
+
+	// Unset and Toggle are clipped too, and fractional coordinates are floored
+	canvas.Unset(2.5, 2.5)
+	canvas.Toggle(1, 1)
+	canvas.Set(5.9, 5.9)
+	if canvas.Get(2, 2) || canvas.Get(1, 1) || canvas.Get(5, 5) {
+		t.Errorf("clipped writes = (%v, %v, %v), want (false, false, false)",
+			canvas.Get(2, 2), canvas.Get(1, 1), canvas.Get(5, 5))
+	}
+
+	if rect, ok := canvas.Clip(); !ok || rect != (Rect{X: 2, Y: 2, Width: 3, Height: 3}) {
+		t.Errorf("Clip() = %v, %v, want the clip rectangle and true", rect, ok)
+	}
+
+	canvas.ClearClip()
+	canvas.Set(0, 0)
+	if !canvas.Get(0, 0) {
+		t.Error("Get(0, 0) = false after ClearClip, want true")
+	}
+	if _, ok := canvas.Clip(); ok {
+		t.Error("Clip() ok = true after ClearClip, want false")
+	}
+}
+
+func TestClipInvertedY(t *testing.T) {
+	// The clip rectangle uses the same coordinates as Set
+	canvas := New(4, 8, WithInvertedY())
+	canvas.SetClip(Rect{X: 0, Y: 0, Width: 4, Height: 1})
+	canvas.Set(1, 0)
+	canvas.Set(1, 7)
+	if !canvas.Get(1, 0) || canvas.Get(1, 7) {
+		t.Errorf("Get(1, 0), Get(1, 7) = %v, %v, want true, false", canvas.Get(1, 0), canvas.Get(1, 7))
+	}
+}
@@ -1,7 +1,13 @@
 package canvas
 
-// Color represents an ANSI foreground color.
-type Color uint8
+import "strconv"
+
+// Color represents an ANSI foreground color: one of the standard colors
+// below, or a 24-bit truecolor value created with RGB.
+type Color uint32
+
+// rgbFlag marks a Color that holds a 24-bit RGB value in its low bits.
+const rgbFlag Color = 1 << 24
 
 // Standard ANSI foreground colors (grouped, with ColorDefault at 0).
 const (
@@ -29,14 +35,70 @@ var ansiCodes = [...]string{
 	ColorYellow:  "\x1b[33m",
 }
 
+// backgroundCodes maps Color values to ANSI background escape sequences.
+var backgroundCodes = [...]string{
+	ColorDefault: "",
+	ColorBlack:   "\x1b[40m",
+	ColorBlue:    "\x1b[44m",
+	ColorCyan:    "\x1b[46m",
+	ColorGreen:   "\x1b[42m",
+	ColorMagenta: "\x1b[45m",
+	ColorRed:     "\x1b[41m",
+	ColorWhite:   "\x1b[47m",
+	ColorYellow:  "\x1b[43m",
+}
+
+// RGB returns a truecolor Color with the given red, green, and blue
+// components. Truecolor renders with 24-bit ANSI sequences, which most modern
+// terminals support; use the standard colors for terminals limited to 8 colors.
+func RGB(red, green, blue uint8) Color {
+	return rgbFlag | Color(red)<<16 | Color(green)<<8 | Color(blue)
+}
+
+// IsRGB reports whether the color is a truecolor value created with RGB.
+func (color Color) IsRGB() bool {
+	return color&rgbFlag != 0
+}
+
+// Components returns the red, green, and blue components of a truecolor
+// value, with ok = false for the standard colors.
+func (color Color) Components() (red, green, blue uint8, ok bool) {
+	if !color.IsRGB() {
+		return 0, 0, 0, false
+	}
+	return uint8(color >> 16), uint8(color >> 8), uint8(color), true
+}
+
 // ANSI returns the ANSI escape sequence for this color.
 func (color Color) ANSI() string {
+	if color.IsRGB() {
+		return color.rgbSequence(38)
+	}
 	if int(color) >= len(ansiCodes) {
 		return ""
 	}
 	return ansiCodes[color]
 }
 
+// BackgroundANSI returns the ANSI escape sequence that uses this color as the background.
+func (color Color) BackgroundANSI() string {
+	if color.IsRGB() {
+		return color.rgbSequence(48)
+	}
+	if int(color) >= len(backgroundCodes) {
+		return ""
+	}
+	return backgroundCodes[color]
+}
+
+// rgbSequence returns the 24-bit SGR sequence for a truecolor value, where
+// code is 38 for the foreground or 48 for the background.
+func (color Color) rgbSequence(code int) string {
+	red, green, blue, _ := color.Components()
+	return "\x1b[" + strconv.Itoa(code) + ";2;" + strconv.Itoa(int(red)) + ";" +
+		strconv.Itoa(int(green)) + ";" + strconv.Itoa(int(blue)) + "m"
+}
+
 // ANSIReset returns the ANSI reset escape sequence.
 func ANSIReset() string {
 	return "\x1b[0m"
@@ -29,6 +29,31 @@ func TestColorANSI(t *testing.T) {
 	}
 }
 
+func TestColorBackgroundANSI(t *testing.T) {
+	tests := []struct {
+		color    Color
+		expected string
+	}{
+		{ColorDefault, ""},
+		{ColorBlack, "\x1b[40m"},
+		{ColorBlue, "\x1b[44m"},
+		{ColorCyan, "\x1b[46m"},
+		{ColorGreen, "\x1b[42m"},
+		{ColorMagenta, "\x1b[45m"},
+		{ColorRed, "\x1b[41m"},
+		{ColorWhite, "\x1b[47m"},
+		{ColorYellow, "\x1b[43m"},
+		{Color(255), ""},
+	}
+
+	for _, testCase := range tests {
+		result := testCase.color.BackgroundANSI()
+		if result != testCase.expected {
+			t.Errorf("Color(%d).BackgroundANSI() = %q, want %q", testCase.color, result, testCase.expected)
+		}
+	}
+}
+
 func TestColorANSIOutOfBounds(t *testing.T) {
 	// Invalid color values should return empty string
 	invalidColor := Color(255)
@@ -38,6 +63,57 @@ func TestColorANSIOutOfBounds(t *testing.T) {
 	}
 }
 
+func TestRGB(t *testing.T) {
+	color := RGB(12, 34, 56)
+	if !color.IsRGB() {
+		t.Error("RGB(12, 34, 56).IsRGB() = false, want true")
+	}
+	red, green, blue, ok := color.Components()
+	if !ok || red != 12 || green != 34 || blue != 56 {
+		t.Errorf("Components() = (%d, %d, %d, %v), want (12, 34, 56, true)", red, green, blue, ok)
+	}
+	if ColorRed.IsRGB() {
+		t.Error("ColorRed.IsRGB() = true, want false")
+	}
+	if _, _, _, ok := ColorRed.Components(); ok {
+		t.Error("ColorRed.Components() ok = true, want false")
+	}
+	// Black is a valid truecolor value, distinct from ColorDefault
+	if RGB(0, 0, 0) == ColorDefault {
+		t.Error("RGB(0, 0, 0) == ColorDefault")
+	}
+}
+
+func TestRGBANSI(t *testing.T) {
+	tests := []struct {
+		color              Color
+		expectedForeground string
+		expectedBackground string
+	}{
+		{RGB(255, 128, 0), "\x1b[38;2;255;128;0m", "\x1b[48;2;255;128;0m"},
+		{RGB(0, 0, 0), "\x1b[38;2;0;0;0m", "\x1b[48;2;0;0;0m"},
+	}
+
+	for _, testCase := range tests {
+		if result := testCase.color.ANSI(); result != testCase.expectedForeground {
+			t.Errorf("Color(%#x).ANSI() = %q, want %q", uint32(testCase.color), result, testCase.expectedForeground)
+		}
+		if result := testCase.color.BackgroundANSI(); result != testCase.expectedBackground {
+			t.Errorf("Color(%#x).BackgroundANSI() = %q, want %q", uint32(testCase.color), result, testCase.expectedBackground)
+		}
+	}
+}
+
+func TestRGBFrame(t *testing.T) {
+	canvas := New(2, 4, WithColor())
+	canvas.SetColor(0, 0, RGB(1, 2, 3))
+
+	expected := "\x1b[38;2;1;2;3m\u2801\x1b[0m"
+	if frame := canvas.Frame(); frame != expected {
+		t.Errorf("Frame() = %q, want %q", frame, expected)
+	}
+}
+
 func TestANSIReset(t *testing.T) {
 	expected := "\x1b[0m"
 	result := ANSIReset()
@@ -251,8 +327,8 @@ func TestColorGridAllocation(t *testing.T) {
 }
 
 func TestColorVisualDemo(t *testing.T) {
-	if !*visualFlag {
-		t.Skip("Skipping visual demo (use -visual flag)")
+	if !visual() {
+		t.Skip("Skipping visual demo (set STIPPLE_VISUAL=1)")
 	}
 
 	canvas := New(20, 8, WithColor())
@@ -269,3 +345,26 @@ func TestColorVisualDemo(t *testing.T) {
 
 	t.Logf("\n=== Color Visual Demo ===\n%s", canvas.Frame())
 }
+
+func TestGetColor(t *testing.T) {
+	canvas := New(4, 8, WithColor())
+	canvas.SetColor(1, 1, ColorMagenta)
+
+	// Any pixel in the same cell reports the cell color
+	if color := canvas.GetColor(0, 3); color != ColorMagenta {
+		t.Errorf("GetColor(0, 3) = %d, want %d (ColorMagenta)", color, ColorMagenta)
+	}
+	if color := canvas.GetColor(2, 0); color != ColorDefault {
+		t.Errorf("GetColor(2, 0) = %d, want %d (ColorDefault)", color, ColorDefault)
+	}
+	if color := canvas.GetColor(100, 100); color != ColorDefault {
+		t.Errorf("GetColor(100, 100) = %d, want %d (ColorDefault)", color, ColorDefault)
+	}
+
+	// Without WithColor, colors are never reported
+	canvasNoColor := New(4, 8)
+	canvasNoColor.SetColor(0, 0, ColorRed)
+	if color := canvasNoColor.GetColor(0, 0); color != ColorDefault {
+		t.Errorf("GetColor(0, 0) without WithColor = %d, want %d (ColorDefault)", color, ColorDefault)
+	}
+}
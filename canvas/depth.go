@@ -0,0 +1,72 @@
+package canvas
+
+import "math"
+
+// SetDepth turns on the pixel at the specified coordinates if z is nearer than
+// the depth already stored for it, where smaller values are nearer, and then
+// records z as the pixel's depth. It reports whether the pixel was written.
+// Without WithDepth(), SetDepth behaves like Set and ignores z.
+func (canvas *Canvas) SetDepth(x, y, z float64) bool {
+	if !canvas.depthTest(x, y, z) {
+		return false
+	}
+	canvas.Set(x, y)
+	return true
+}
+
+// SetColorDepth is like SetDepth but also assigns the given color to the pixel,
+// as SetColor does.
+func (canvas *Canvas) SetColorDepth(x, y, z float64, color Color) bool {
+	if !canvas.depthTest(x, y, z) {
+		return false
+	}
+	canvas.SetColor(x, y, color)
+	return true
+}
+
+// WriteDepth records z as the depth of the pixel at the specified coordinates
+// if it is nearer than the depth already stored, without turning the pixel on,
+// so an invisible surface can hide later drawing. It reports whether the depth
+// was written. Without WithDepth(), WriteDepth does nothing.
+func (canvas *Canvas) WriteDepth(x, y, z float64) bool {
+	return canvas.depth != nil && canvas.depthTest(x, y, z)
+}
+
+// Depth returns the depth stored for the pixel at the specified coordinates.
+// Returns +Inf for pixels that have not been written with SetDepth or
+// SetColorDepth, for out-of-bounds coordinates, and when depth is disabled.
+func (canvas *Canvas) Depth(x, y float64) float64 {
+	pixelX, pixelY, ok := canvas.screenPixel(x, y)
+	if !ok || canvas.depth == nil {
+		return math.Inf(1)
+	}
+	return canvas.depth[pixelY][pixelX]
+}
+
+// ClearDepth resets the depth buffer to +Inf without changing any pixels, so
+// a new scene can be depth tested on top of the existing drawing.
+func (canvas *Canvas) ClearDepth() {
+	for row := range canvas.depth {
+		for column := range canvas.depth[row] {
+			canvas.depth[row][column] = math.Inf(1)
+		}
+	}
+}
+
+// depthTest reports whether a write at (x, y) with depth z passes the depth
+// test, and if so records z in the depth buffer. Unset and Toggle do not
+// change stored depths.
+func (canvas *Canvas) depthTest(x, y, z float64) bool {
+	if _, _, _, _, ok := canvas.pixelToCell(x, y); !ok || canvas.clipped(x, y) {
+		return false
+	}
+	if canvas.depth == nil {
+		return true
+	}
+	pixelX, pixelY, _ := canvas.screenPixel(x, y)
+	if z >= canvas.depth[pixelY][pixelX] {
+		return false
+	}
+	canvas.depth[pixelY][pixelX] = z
+	return true
+}
@@ -0,0 +1,171 @@
+package canvas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSetDepth(t *testing.T) {
+	canvas := New(4, 8, WithDepth())
+
+	tests := []struct {
+		name     string
+		z        float64
+		expected bool
+		depth    float64
+	}{
+		{"first write", 5, true, 5},
+		{"farther is hidden", 7, false, 5},
+		{"equal is hidden", 5, false, 5},
+		{"nearer wins", 2, true, 2},
+	}
+
+	for _, testCase := range tests {
+		if written := canvas.SetDepth(1, 2, testCase.z); written != testCase.expected {
+			t.Errorf("%s: SetDepth(1, 2, %v) = %v, want %v", testCase.name, testCase.z, written, testCase.expected)
+		}
+		if depth := canvas.Depth(1, 2); depth != testCase.depth {
+			t.Errorf("%s: Depth(1, 2) = %v, want %v", testCase.name, depth, testCase.depth)
+		}
+	}
+
+	if !canvas.Get(1, 2) {
+		t.Error("pixel (1, 2) not set")
+	}
+}
+
+func TestWriteDepth(t *testing.T) {
+	canvas := New(4, 8, WithDepth())
+	if !canvas.WriteDepth(1, 2, 3) {
+		t.Error("WriteDepth(1, 2, 3) = false, want true")
+	}
+	if canvas.WriteDepth(1, 2, 4) {
+		t.Error("WriteDepth(1, 2, 4) behind depth 3 = true, want false")
+	}
+	if depth := canvas.Depth(1, 2); depth != 3 {
+		t.Errorf("Depth(1, 2) = %v, want 3", depth)
+	}
+	if canvas.Get(1, 2) {
+		t.Error("WriteDepth turned on pixel (1, 2)")
+	}
+
+	// The stored depth hides later drawing behind it
+	if canvas.SetDepth(1, 2, 5) {
+		t.Error("SetDepth(1, 2, 5) behind written depth = true, want false")
+	}
+
+	if New(4, 8).WriteDepth(1, 2, 3) {
+		t.Error("WriteDepth() without WithDepth() = true, want false")
+	}
+}
+
+func TestUnsetKeepsDepth(t *testing.T) {
+	canvas := New(4, 8, WithDepth())
+	canvas.SetDepth(0, 0, 1)
+	canvas.Unset(0, 0)
+
+	// Unset keeps the stored depth, so a farther write stays hidden
+	if canvas.SetDepth(0, 0, 2) {
+		t.Error("SetDepth(0, 0, 2) = true behind a stored depth of 1, want false")
+	}
+	if canvas.Get(0, 0) {
+		t.Error("pixel (0, 0) set by a hidden write")
+	}
+}
+
+func TestSetDepthWithoutDepthEnabled(t *testing.T) {
+	canvas := New(4, 8)
+	canvas.SetDepth(1, 1, 5)
+
+	if !canvas.SetDepth(1, 1, 9) {
+		t.Error("SetDepth(1, 1, 9) = false without WithDepth, want true")
+	}
+	if !canvas.Get(1, 1) {
+		t.Error("pixel (1, 1) not set")
+	}
+	if depth := canvas.Depth(1, 1); !math.IsInf(depth, 1) {
+		t.Errorf("Depth(1, 1) = %v without WithDepth, want +Inf", depth)
+	}
+}
+
+func TestSetDepthBounds(t *testing.T) {
+	canvas := New(4, 8, WithDepth())
+	canvas.SetClip(Rect{X: 0, Y: 0, Width: 2, Height: 8})
+
+	points := []struct {
+		x, y float64
+	}{
+		{-1, 0},
+		{4, 0},
+		{0, 8},
+		{3, 3}, // clipped
+	}
+
+	for _, point := range points {
+		if canvas.SetDepth(point.x, point.y, 1) {
+			t.Errorf("SetDepth(%v, %v, 1) = true, want false", point.x, point.y)
+		}
+		if depth := canvas.Depth(point.x, point.y); !math.IsInf(depth, 1) {
+			t.Errorf("Depth(%v, %v) = %v, want +Inf", point.x, point.y, depth)
+		}
+	}
+}
+
+func TestSetColorDepth(t *testing.T) {
+	canvas := New(4, 8, WithColor(), WithDepth())
+	canvas.SetColorDepth(0, 0, 2, ColorRed)
+	canvas.SetColorDepth(0, 0, 3, ColorBlue)
+
+	if color := canvas.GetColor(0, 0); color != ColorRed {
+		t.Errorf("GetColor(0, 0) = %d, want %d (ColorRed)", color, ColorRed)
+	}
+
+	canvas.SetColorDepth(0, 0, 1, ColorGreen)
+	if color := canvas.GetColor(0, 0); color != ColorGreen {
+		t.Errorf("GetColor(0, 0) = %d, want %d (ColorGreen)", color, ColorGreen)
+	}
+}
+
+func TestDepthInvertedY(t *testing.T) {
+	normal := New(4, 8, WithDepth())
+	inverted := New(4, 8, WithDepth(), WithInvertedY())
+
+	normal.SetDepth(1, 2, 3)
+	inverted.SetDepth(1, 5, 3)
+
+	if frame := inverted.Frame(); frame != normal.Frame() {
+		t.Errorf("inverted frame = %q, want %q", frame, normal.Frame())
+	}
+	if depth := inverted.Depth(1, 5); depth != 3 {
+		t.Errorf("Depth(1, 5) = %v, want 3", depth)
+	}
+	if depth := inverted.Depth(1, 2); !math.IsInf(depth, 1) {
+		t.Errorf("Depth(1, 2) = %v, want +Inf", depth)
+	}
+}
+
+func TestClearResetsDepth(t *testing.T) {
+	canvas := New(4, 8, WithDepth())
+	canvas.SetDepth(0, 0, 1)
+	canvas.Clear()
+
+	if depth := canvas.Depth(0, 0); !math.IsInf(depth, 1) {
+		t.Errorf("Depth(0, 0) after Clear = %v, want +Inf", depth)
+	}
+	if !canvas.SetDepth(0, 0, 5) {
+		t.Error("SetDepth(0, 0, 5) after Clear = false, want true")
+	}
+}
+
+func TestClearDepthKeepsPixels(t *testing.T) {
+	canvas := New(4, 8, WithDepth())
+	canvas.SetDepth(0, 0, 1)
+	canvas.ClearDepth()
+
+	if !canvas.Get(0, 0) {
+		t.Error("pixel (0, 0) cleared by ClearDepth")
+	}
+	if !canvas.SetDepth(0, 0, 5) {
+		t.Error("SetDepth(0, 0, 5) after ClearDepth = false, want true")
+	}
+}
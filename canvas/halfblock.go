@@ -0,0 +1,68 @@
+package canvas
+
+import "strings"
+
+// Half-block characters used by WithHalfBlock canvases.
+const (
+	halfBlockEmpty = ' '
+	halfBlockUpper = '▀'
+	halfBlockLower = '▄'
+	halfBlockFull  = '█'
+)
+
+// halfBlockFrame renders a half-block canvas, pairing stored rows 2n and 2n+1
+// into one terminal row. Each lit pixel's color becomes the foreground or the
+// background of the cell, so both halves keep independent colors.
+func (canvas *Canvas) halfBlockFrame() string {
+	var builder strings.Builder
+	for row := 0; row < canvas.Rows(); row++ {
+		if row > 0 {
+			builder.WriteByte('\n')
+		}
+		for column := 0; column < canvas.Cols(); column++ {
+			if text, ok := canvas.textAt(row, column); ok {
+				writeColored(&builder, text.character, text.color)
+				continue
+			}
+			upperLit := canvas.cells[row*2][column] != BrailleOffset
+			lowerLit := canvas.cells[row*2+1][column] != BrailleOffset
+			upperColor, lowerColor := ColorDefault, ColorDefault
+			if canvas.colors != nil {
+				upperColor = canvas.colors[row*2][column]
+				lowerColor = canvas.colors[row*2+1][column]
+			}
+
+			glyph, foreground, background := halfBlockCell(upperLit, lowerLit, upperColor, lowerColor)
+			if foreground == ColorDefault && background == ColorDefault {
+				builder.WriteRune(glyph)
+				continue
+			}
+			builder.WriteString(foreground.ANSI())
+			builder.WriteString(background.BackgroundANSI())
+			builder.WriteRune(glyph)
+			builder.WriteString(ANSIReset())
+		}
+	}
+	return builder.String()
+}
+
+// halfBlockCell chooses the glyph and colors for a cell from its two pixels.
+// When both pixels are lit with different colors, the upper half block is used with
+// the upper color as foreground and the lower color as background. A default-colored
+// pixel cannot be drawn as a background, so it is kept in the foreground instead.
+func halfBlockCell(upperLit, lowerLit bool, upperColor, lowerColor Color) (glyph rune, foreground, background Color) {
+	switch {
+	case !upperLit && !lowerLit:
+		return halfBlockEmpty, ColorDefault, ColorDefault
+	case !lowerLit:
+		return halfBlockUpper, upperColor, ColorDefault
+	case !upperLit:
+		return halfBlockLower, lowerColor, ColorDefault
+	case upperColor == lowerColor:
+		return halfBlockFull, upperColor, ColorDefault
+	case lowerColor == ColorDefault:
+		return halfBlockLower, lowerColor, upperColor
+	default:
+		return halfBlockUpper, upperColor, lowerColor
+	}
+}
@@ -0,0 +1,143 @@
+package canvas
+
+import "testing"
+
+func TestHalfBlockDimensions(t *testing.T) {
+	canvas := New(10, 8, WithHalfBlock())
+
+	if canvas.Cols() != 10 {
+		t.Errorf("Cols() = %d, want 10", canvas.Cols())
+	}
+	if canvas.Rows() != 4 {
+		t.Errorf("Rows() = %d, want 4", canvas.Rows())
+	}
+
+	// Odd heights truncate to whole terminal rows
+	truncated := New(3, 5, WithHalfBlock())
+	if truncated.Rows() != 2 {
+		t.Errorf("Rows() = %d for height 5, want 2", truncated.Rows())
+	}
+	truncated.Set(0, 4)
+	if truncated.Get(0, 4) {
+		t.Error("Get(0, 4) = true for truncated row, want false")
+	}
+
+	if !canvas.HalfBlock() {
+		t.Error("HalfBlock() = false with WithHalfBlock(), want true")
+	}
+	if New(4, 8).HalfBlock() {
+		t.Error("HalfBlock() = true without WithHalfBlock(), want false")
+	}
+}
+
+func TestHalfBlockSetGet(t *testing.T) {
+	canvas := New(4, 4, WithHalfBlock())
+
+	canvas.Set(1, 2)
+	if !canvas.Get(1, 2) {
+		t.Error("Get(1, 2) = false after Set, want true")
+	}
+	if canvas.Get(1, 3) {
+		t.Error("Get(1, 3) = true, want false (pixels in a cell are independent)")
+	}
+
+	canvas.Toggle(1, 3)
+	canvas.Unset(1, 2)
+	if canvas.Get(1, 2) || !canvas.Get(1, 3) {
+		t.Error("Unset and Toggle should affect only their own pixel")
+	}
+
+	canvas.Clear()
+	if canvas.Get(1, 3) {
+		t.Error("Get(1, 3) = true after Clear, want false")
+	}
+}
+
+func TestHalfBlockFrame(t *testing.T) {
+	canvas := New(4, 2, WithHalfBlock())
+	canvas.Set(1, 0) // upper only
+	canvas.Set(2, 1) // lower only
+	canvas.Set(3, 0) // both
+	canvas.Set(3, 1)
+
+	expected := " ▀▄█"
+	if frame := canvas.Frame(); frame != expected {
+		t.Errorf("Frame() = %q, want %q", frame, expected)
+	}
+
+	printVisual(t, "TestHalfBlockFrame", canvas)
+}
+
+func TestHalfBlockFrameMultipleRows(t *testing.T) {
+	canvas := New(2, 4, WithHalfBlock())
+	canvas.Set(0, 0)
+	canvas.Set(1, 3)
+
+	expected := "▀ \n ▄"
+	if frame := canvas.Frame(); frame != expected {
+		t.Errorf("Frame() = %q, want %q", frame, expected)
+	}
+}
+
+func TestHalfBlockInvertedY(t *testing.T) {
+	canvas := New(1, 4, WithHalfBlock(), WithInvertedY())
+	canvas.Set(0, 0)
+
+	expected := " \n▄"
+	if frame := canvas.Frame(); frame != expected {
+		t.Errorf("Frame() = %q, want %q", frame, expected)
+	}
+}
+
+func TestHalfBlockColors(t *testing.T) {
+	tests := []struct {
+		name     string
+		upper    Color
+		lower    Color
+		setUpper bool
+		setLower bool
+		expected string
+	}{
+		{"upper colored", ColorRed, ColorDefault, true, false, ColorRed.ANSI() + "▀" + ANSIReset()},
+		{"lower colored", ColorDefault, ColorBlue, false, true, ColorBlue.ANSI() + "▄" + ANSIReset()},
+		{"same color", ColorGreen, ColorGreen, true, true, ColorGreen.ANSI() + "█" + ANSIReset()},
+		{"two colors", ColorRed, ColorBlue, true, true,
+			ColorRed.ANSI() + ColorBlue.BackgroundANSI() + "▀" + ANSIReset()},
+		{"default lower", ColorYellow, ColorDefault, true, true,
+			ColorYellow.BackgroundANSI() + "▄" + ANSIReset()},
+		{"default upper", ColorDefault, ColorCyan, true, true,
+			ColorCyan.BackgroundANSI() + "▀" + ANSIReset()},
+		{"both default", ColorDefault, ColorDefault, true, true, "█"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			canvas := New(1, 2, WithHalfBlock(), WithColor())
+			if testCase.setUpper {
+				canvas.SetColor(0, 0, testCase.upper)
+			}
+			if testCase.setLower {
+				canvas.SetColor(0, 1, testCase.lower)
+			}
+
+			if frame := canvas.Frame(); frame != testCase.expected {
+				t.Errorf("Frame() = %q, want %q", frame, testCase.expected)
+			}
+			if color := canvas.GetColor(0, 0); testCase.setUpper && color != testCase.upper {
+				t.Errorf("GetColor(0, 0) = %d, want %d", color, testCase.upper)
+			}
+			if color := canvas.GetColor(0, 1); testCase.setLower && color != testCase.lower {
+				t.Errorf("GetColor(0, 1) = %d, want %d", color, testCase.lower)
+			}
+		})
+	}
+}
+
+func TestHalfBlockFrameWithIgnoresRenderer(t *testing.T) {
+	canvas := New(2, 2, WithHalfBlock())
+	canvas.Set(0, 0)
+
+	if canvas.FrameWith(RendererASCII) != canvas.Frame() {
+		t.Errorf("FrameWith(RendererASCII) = %q, want %q", canvas.FrameWith(RendererASCII), canvas.Frame())
+	}
+}
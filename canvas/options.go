@@ -10,6 +10,33 @@ func WithColor() Option {
 	}
 }
 
+// WithColorPolicy returns an option that selects how a cell's color is resolved
+// when its dots were set with different colors. The default is ColorLastWrite.
+// The policy only has an effect together with WithColor().
+func WithColorPolicy(policy ColorPolicy) Option {
+	return func(canvas *Canvas) {
+		canvas.colorPolicy = policy
+	}
+}
+
+// WithDepth returns an option that enables a per-pixel depth buffer for
+// SetDepth and SetColorDepth. Smaller depth values are nearer to the viewer.
+func WithDepth() Option {
+	return func(canvas *Canvas) {
+		canvas.depthEnabled = true
+	}
+}
+
+// WithHalfBlock returns an option that renders each terminal cell as two vertically
+// stacked pixels using the upper and lower half-block characters.
+// Each pixel keeps its own color, drawn as the foreground or background of the cell,
+// at the cost of lower resolution than braille (1x2 pixels per cell instead of 2x4).
+func WithHalfBlock() Option {
+	return func(canvas *Canvas) {
+		canvas.halfBlock = true
+	}
+}
+
 // WithInvertedY returns an option that inverts the Y-axis direction.
 // By default, Y increases downward (standard screen coordinates).
 // With this option, Y increases upward (mathematical coordinates).
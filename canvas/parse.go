@@ -0,0 +1,203 @@
+package canvas
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseError describes an invalid character or escape sequence found by Parse.
+// Line and Column are 1-based; Column counts runes, not bytes.
+type ParseError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+// Error implements the error interface.
+func (parseError *ParseError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", parseError.Line, parseError.Column, parseError.Message)
+}
+
+// Parse decodes braille text, such as the output of Frame, back into a Canvas.
+// Each line becomes a row of cells and each braille rune (U+2800 to U+28FF) becomes
+// one cell, so the canvas is 2 pixels wide and 4 pixels tall per rune.
+// ANSI SGR color sequences, including 24-bit 38;2;r;g;b sequences, are applied to
+// the cells that follow them; when any are present the returned canvas has color
+// support enabled.
+// A single trailing newline is ignored. All lines must contain the same number of cells.
+func Parse(frame string) (*Canvas, error) {
+	frame = strings.TrimSuffix(frame, "\n")
+
+	var lines []string
+	if frame != "" {
+		lines = strings.Split(frame, "\n")
+	}
+
+	cellRows := make([][]rune, 0, len(lines))
+	colorRows := make([][]Color, 0, len(lines))
+	colorSeen := false
+	current := ColorDefault
+
+	for lineIndex, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		runes := []rune(line)
+
+		var cells []rune
+		var colors []Color
+		for position := 0; position < len(runes); position++ {
+			character := runes[position]
+
+			if character == '\x1b' {
+				color, length, err := parseSGR(runes[position:], current)
+				if err != nil {
+					return nil, &ParseError{Line: lineIndex + 1, Column: position + 1, Message: err.Error()}
+				}
+				current = color
+				colorSeen = true
+				position += length - 1
+				continue
+			}
+
+			if character < BrailleOffset || character > BrailleOffset+0xFF {
+				return nil, &ParseError{
+					Line:    lineIndex + 1,
+					Column:  position + 1,
+					Message: fmt.Sprintf("invalid character %q (U+%04X), want braille pattern", character, character),
+				}
+			}
+
+			cells = append(cells, character)
+			colors = append(colors, current)
+		}
+
+		if lineIndex > 0 && len(cells) != len(cellRows[0]) {
+			return nil, &ParseError{
+				Line:    lineIndex + 1,
+				Column:  len(runes) + 1,
+				Message: fmt.Sprintf("row has %d cells, want %d", len(cells), len(cellRows[0])),
+			}
+		}
+
+		cellRows = append(cellRows, cells)
+		colorRows = append(colorRows, colors)
+	}
+
+	columns := 0
+	if len(cellRows) > 0 {
+		columns = len(cellRows[0])
+	}
+
+	var options []Option
+	if colorSeen {
+		options = append(options, WithColor())
+	}
+	canvas := New(columns*2, len(cellRows)*4, options...)
+
+	for row := range cellRows {
+		copy(canvas.cells[row], cellRows[row])
+		if canvas.colors == nil {
+			continue
+		}
+		copy(canvas.colors[row], colorRows[row])
+		for column, color := range colorRows[row] {
+			canvas.seedDotColors(row, column, color)
+		}
+	}
+
+	return canvas, nil
+}
+
+// seedDotColors gives every lit dot of a parsed cell the cell's color, so
+// later edits to the cell resolve from that color instead of losing it.
+// Dots in cells without a color are left uncolored, as Set leaves them.
+func (canvas *Canvas) seedDotColors(row, column int, color Color) {
+	if color == ColorDefault {
+		return
+	}
+	for dotRow, masks := range pixelMap {
+		for dotColumn, mask := range masks {
+			if canvas.cells[row][column]&mask == 0 {
+				continue
+			}
+			*canvas.colorSequence++
+			canvas.dotColors[row][column][dotIndex(dotRow, dotColumn)] = dotColor{color: color, sequence: *canvas.colorSequence}
+		}
+	}
+}
+
+// parseSGR decodes an ANSI SGR escape sequence at the start of runes.
+// It returns the color in effect after the sequence and the number of runes consumed.
+func parseSGR(runes []rune, current Color) (Color, int, error) {
+	if len(runes) < 2 || runes[1] != '[' {
+		return current, 0, fmt.Errorf("unsupported escape sequence, want CSI")
+	}
+
+	end := -1
+	for index := 2; index < len(runes); index++ {
+		if runes[index] >= 0x40 && runes[index] <= 0x7E {
+			end = index
+			break
+		}
+	}
+	if end == -1 {
+		return current, 0, fmt.Errorf("unterminated escape sequence")
+	}
+	if runes[end] != 'm' {
+		return current, 0, fmt.Errorf("unsupported escape sequence %q, want SGR", string(runes[:end+1]))
+	}
+
+	parameters := string(runes[2:end])
+	if parameters == "" {
+		return ColorDefault, end + 1, nil
+	}
+
+	var codes []int
+	for _, parameter := range strings.Split(parameters, ";") {
+		code, err := strconv.Atoi(parameter)
+		if err != nil {
+			return current, 0, fmt.Errorf("invalid SGR parameter %q", parameter)
+		}
+		codes = append(codes, code)
+	}
+
+	for index := 0; index < len(codes); index++ {
+		if codes[index] == 38 {
+			// 24-bit foreground: 38;2;red;green;blue
+			if index+4 >= len(codes) || codes[index+1] != 2 {
+				return current, 0, fmt.Errorf("unsupported extended color, want 38;2;r;g;b")
+			}
+			components := codes[index+2 : index+5]
+			for _, component := range components {
+				if component < 0 || component > 255 {
+					return current, 0, fmt.Errorf("color component %d out of range 0-255", component)
+				}
+			}
+			current = RGB(uint8(components[0]), uint8(components[1]), uint8(components[2]))
+			index += 4
+			continue
+		}
+		color, ok := colorFromSGR(codes[index])
+		if !ok {
+			return current, 0, fmt.Errorf("unsupported SGR parameter %d", codes[index])
+		}
+		current = color
+	}
+
+	return current, end + 1, nil
+}
+
+// colorFromSGR maps an SGR parameter to the Color it selects.
+// Reset (0) and default foreground (39) both select ColorDefault.
+func colorFromSGR(code int) (Color, bool) {
+	if code == 0 || code == 39 {
+		return ColorDefault, true
+	}
+	sequence := "\x1b[" + strconv.Itoa(code) + "m"
+	for color, ansi := range ansiCodes {
+		if ansi == sequence {
+			return Color(color), true
+		}
+	}
+	return ColorDefault, false
+}
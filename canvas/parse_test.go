@@ -0,0 +1,185 @@
+package canvas
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRoundTrip(t *testing.T) {
+	original := New(8, 12)
+	original.Set(0, 0)
+	original.Set(3, 5)
+	original.Set(7, 11)
+	original.Set(4, 8)
+
+	parsed, err := Parse(original.Frame())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if parsed.Width() != 8 || parsed.Height() != 12 {
+		t.Errorf("Parse() dimensions = %dx%d, want 8x12", parsed.Width(), parsed.Height())
+	}
+	for y := 0; y < 12; y++ {
+		for x := 0; x < 8; x++ {
+			if parsed.Get(float64(x), float64(y)) != original.Get(float64(x), float64(y)) {
+				t.Errorf("pixel (%d, %d) = %v, want %v",
+					x, y, parsed.Get(float64(x), float64(y)), original.Get(float64(x), float64(y)))
+			}
+		}
+	}
+	if parsed.colors != nil {
+		t.Error("colors should be nil when the frame has no escape sequences")
+	}
+
+	printVisual(t, "TestParseRoundTrip", parsed)
+}
+
+func TestParseColorRoundTrip(t *testing.T) {
+	original := New(6, 8, WithColor())
+	original.SetColor(0, 0, ColorRed)
+	original.SetColor(2, 1, ColorGreen)
+	original.Set(5, 6)
+
+	frame := original.Frame()
+	parsed, err := Parse(frame)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if parsed.colors == nil {
+		t.Fatal("colors should be allocated when the frame has escape sequences")
+	}
+	if parsed.colors[0][0] != ColorRed {
+		t.Errorf("colors[0][0] = %d, want %d (ColorRed)", parsed.colors[0][0], ColorRed)
+	}
+	if parsed.colors[0][1] != ColorGreen {
+		t.Errorf("colors[0][1] = %d, want %d (ColorGreen)", parsed.colors[0][1], ColorGreen)
+	}
+	if parsed.colors[1][2] != ColorDefault {
+		t.Errorf("colors[1][2] = %d, want %d (ColorDefault)", parsed.colors[1][2], ColorDefault)
+	}
+	if parsed.Frame() != frame {
+		t.Errorf("Frame() after Parse =\n%q\nwant:\n%q", parsed.Frame(), frame)
+	}
+
+	printVisual(t, "TestParseColorRoundTrip", parsed)
+}
+
+func TestParseColorPersistsAcrossCells(t *testing.T) {
+	frame := "\x1b[34m" + string([]rune{BrailleOffset | 0x01, BrailleOffset | 0x02}) + "\x1b[39m" + string(BrailleOffset)
+
+	parsed, err := Parse(frame)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	expected := []Color{ColorBlue, ColorBlue, ColorDefault}
+	for column, color := range expected {
+		if parsed.colors[0][column] != color {
+			t.Errorf("colors[0][%d] = %d, want %d", column, parsed.colors[0][column], color)
+		}
+	}
+}
+
+func TestParseColorSurvivesEdits(t *testing.T) {
+	original := New(2, 4, WithColor())
+	original.SetColor(0, 0, ColorRed)
+	original.SetColor(1, 1, ColorRed)
+	original.SetColor(0, 2, ColorRed)
+
+	parsed, err := Parse(original.Frame())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	// Turning off one parsed dot keeps the color of the others
+	parsed.Unset(0, 2)
+	if color := parsed.GetColor(0, 0); color != ColorRed {
+		t.Errorf("GetColor() after Unset = %v, want red", color)
+	}
+
+	// A new color wins while its dot is lit, and the parsed color returns after
+	parsed.SetColor(1, 3, ColorBlue)
+	if color := parsed.GetColor(0, 0); color != ColorBlue {
+		t.Errorf("GetColor() after SetColor = %v, want blue", color)
+	}
+	parsed.Toggle(1, 3)
+	if color := parsed.GetColor(0, 0); color != ColorRed {
+		t.Errorf("GetColor() after Toggle = %v, want red", color)
+	}
+}
+
+func TestParseRGB(t *testing.T) {
+	original := New(4, 4, WithColor())
+	original.SetColor(0, 0, RGB(10, 200, 30))
+	original.SetColor(2, 0, ColorBlue)
+
+	parsed, err := Parse(original.Frame())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if color := parsed.GetColor(0, 0); color != RGB(10, 200, 30) {
+		t.Errorf("GetColor(0, 0) = %#x, want %#x", uint32(color), uint32(RGB(10, 200, 30)))
+	}
+	if color := parsed.GetColor(2, 0); color != ColorBlue {
+		t.Errorf("GetColor(2, 0) = %d, want %d (ColorBlue)", color, ColorBlue)
+	}
+}
+
+func TestParseTrailingNewline(t *testing.T) {
+	parsed, err := Parse(string(BrailleOffset|0xFF) + "\n")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if parsed.Rows() != 1 || parsed.Cols() != 1 {
+		t.Errorf("Parse() = %d rows x %d cols, want 1x1", parsed.Rows(), parsed.Cols())
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	parsed, err := Parse("")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if parsed.Width() != 0 || parsed.Height() != 0 {
+		t.Errorf("Parse(\"\") dimensions = %dx%d, want 0x0", parsed.Width(), parsed.Height())
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	braille := string(BrailleOffset)
+
+	tests := []struct {
+		name   string
+		frame  string
+		line   int
+		column int
+	}{
+		{"invalid character", braille + "x" + braille, 1, 2},
+		{"invalid character on second line", braille + braille + "\n" + braille + " ", 2, 2},
+		{"ragged rows", braille + braille + "\n" + braille, 2, 2},
+		{"unterminated escape", braille + "\x1b[31", 1, 2},
+		{"non-CSI escape", "\x1b]0;title\x07", 1, 1},
+		{"non-SGR sequence", "\x1b[2J" + braille, 1, 1},
+		{"unsupported SGR parameter", "\x1b[1m" + braille, 1, 1},
+		{"invalid SGR parameter", "\x1b[3xm" + braille, 1, 1},
+		{"256-color foreground", "\x1b[38;5;196m" + braille, 1, 1},
+		{"short truecolor", "\x1b[38;2;1;2m" + braille, 1, 1},
+		{"truecolor out of range", "\x1b[38;2;1;2;256m" + braille, 1, 1},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := Parse(testCase.frame)
+			var parseError *ParseError
+			if !errors.As(err, &parseError) {
+				t.Fatalf("Parse() error = %v, want *ParseError", err)
+			}
+			if parseError.Line != testCase.line || parseError.Column != testCase.column {
+				t.Errorf("Parse() error at line %d, column %d, want line %d, column %d (%v)",
+					parseError.Line, parseError.Column, testCase.line, testCase.column, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package canvas
+
+// ColorPolicy selects how a cell's single display color is chosen from the
+// colors of its lit dots.
+type ColorPolicy uint8
+
+// Color resolution policies (grouped, with ColorLastWrite as the default).
+const (
+	ColorLastWrite  ColorPolicy = iota // the most recently written color wins
+	ColorFirstWrite                    // the earliest written color wins and is never overwritten
+	ColorMajority                      // the color of most lit dots wins, ties go to the latest write
+	ColorPriority                      // the color with the highest z wins, ties go to the latest write
+)
+
+// dotsPerCell is the number of dots in a braille cell.
+const dotsPerCell = 8
+
+// dotColor records the color written to a single dot.
+// A zero sequence means the dot has no color assigned.
+type dotColor struct {
+	color    Color
+	sequence uint64
+	z        float64
+}
+
+// dotIndex returns the position of a dot within a cell's dot color array.
+func dotIndex(dotRow, dotColumn int) int {
+	return dotRow*2 + dotColumn
+}
+
+// replaces reports whether a new write with the given z overwrites an existing dot color.
+func (policy ColorPolicy) replaces(existing dotColor, z float64) bool {
+	if existing.sequence == 0 {
+		return true
+	}
+	switch policy {
+	case ColorFirstWrite:
+		return false
+	case ColorPriority:
+		return z >= existing.z
+	default:
+		return true
+	}
+}
+
+// clearDotColor forgets the color of a dot that was turned off and re-resolves its cell.
+func (canvas *Canvas) clearDotColor(cellRow, cellColumn, dotRow, dotColumn int) {
+	if canvas.colors == nil {
+		return
+	}
+	canvas.dotColors[cellRow][cellColumn][dotIndex(dotRow, dotColumn)] = dotColor{}
+	canvas.resolveColor(cellRow, cellColumn)
+}
+
+// resolveColor recomputes a cell's color from the colors of its lit dots.
+// Dots that were lit without a color (with Set) do not take part.
+func (canvas *Canvas) resolveColor(cellRow, cellColumn int) {
+	cell := canvas.cells[cellRow][cellColumn]
+	cellWidth, cellHeight := canvas.cellSize()
+
+	var lit litDots
+	for dotRow := 0; dotRow < cellHeight; dotRow++ {
+		for dotColumn := 0; dotColumn < cellWidth; dotColumn++ {
+			dot := canvas.dotColors[cellRow][cellColumn][dotIndex(dotRow, dotColumn)]
+			if dot.sequence == 0 || cell&pixelMap[dotRow][dotColumn] == 0 {
+				continue
+			}
+			lit.dots[lit.count] = dot
+			lit.count++
+		}
+	}
+
+	winner := dotColor{color: ColorDefault}
+	for index, dot := range lit.dots[:lit.count] {
+		if index == 0 || canvas.colorPolicy.beats(dot, winner, &lit) {
+			winner = dot
+		}
+	}
+	canvas.colors[cellRow][cellColumn] = winner.color
+}
+
+// litDots holds the colored, lit dots of a single cell.
+type litDots struct {
+	dots  [dotsPerCell]dotColor
+	count int
+}
+
+// countColor returns how many lit dots have the given color.
+func (lit *litDots) countColor(color Color) int {
+	count := 0
+	for _, dot := range lit.dots[:lit.count] {
+		if dot.color == color {
+			count++
+		}
+	}
+	return count
+}
+
+// beats reports whether candidate should replace the current winner for a cell.
+func (policy ColorPolicy) beats(candidate, winner dotColor, lit *litDots) bool {
+	switch policy {
+	case ColorFirstWrite:
+		return candidate.sequence < winner.sequence
+	case ColorMajority:
+		candidateCount, winnerCount := lit.countColor(candidate.color), lit.countColor(winner.color)
+		if candidateCount != winnerCount {
+			return candidateCount > winnerCount
+		}
+		return candidate.sequence > winner.sequence
+	case ColorPriority:
+		if candidate.z != winner.z {
+			return candidate.z > winner.z
+		}
+		return candidate.sequence > winner.sequence
+	default:
+		return candidate.sequence > winner.sequence
+	}
+}
@@ -0,0 +1,144 @@
+package canvas
+
+import "testing"
+
+func TestColorPolicies(t *testing.T) {
+	// Three dots in cell (0, 0): red, then two blue, then green with a high z
+	writes := []struct {
+		x, y  float64
+		color Color
+		z     float64
+	}{
+		{0, 0, ColorRed, 1},
+		{1, 0, ColorBlue, 0},
+		{0, 1, ColorBlue, 0},
+		{1, 1, ColorGreen, 0.5},
+	}
+
+	tests := []struct {
+		name     string
+		policy   ColorPolicy
+		expected Color
+	}{
+		{"last write", ColorLastWrite, ColorGreen},
+		{"first write", ColorFirstWrite, ColorRed},
+		{"majority", ColorMajority, ColorBlue},
+		{"priority", ColorPriority, ColorRed},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			canvas := New(4, 8, WithColor(), WithColorPolicy(testCase.policy))
+			for _, write := range writes {
+				canvas.SetColorZ(write.x, write.y, write.color, write.z)
+			}
+			if color := canvas.GetColor(0, 0); color != testCase.expected {
+				t.Errorf("GetColor(0, 0) = %d, want %d", color, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestColorPolicyDefaultIsLastWrite(t *testing.T) {
+	canvas := New(4, 8, WithColor())
+	if canvas.colorPolicy != ColorLastWrite {
+		t.Errorf("default colorPolicy = %d, want %d (ColorLastWrite)", canvas.colorPolicy, ColorLastWrite)
+	}
+}
+
+func TestColorFirstWriteKeepsDotColor(t *testing.T) {
+	canvas := New(4, 8, WithColor(), WithColorPolicy(ColorFirstWrite))
+	canvas.SetColor(0, 0, ColorRed)
+	canvas.SetColor(0, 0, ColorBlue)
+
+	if color := canvas.GetColor(0, 0); color != ColorRed {
+		t.Errorf("GetColor(0, 0) = %d, want %d (ColorRed)", color, ColorRed)
+	}
+
+	// Turning the dot off forgets its color, so the next write wins again
+	canvas.Unset(0, 0)
+	canvas.SetColor(0, 0, ColorBlue)
+	if color := canvas.GetColor(0, 0); color != ColorBlue {
+		t.Errorf("GetColor(0, 0) after Unset = %d, want %d (ColorBlue)", color, ColorBlue)
+	}
+}
+
+func TestColorPriorityKeepsHigherZ(t *testing.T) {
+	canvas := New(4, 8, WithColor(), WithColorPolicy(ColorPriority))
+	canvas.SetColorZ(0, 0, ColorRed, 5)
+	canvas.SetColorZ(0, 0, ColorBlue, 2)
+
+	if color := canvas.GetColor(0, 0); color != ColorRed {
+		t.Errorf("GetColor(0, 0) = %d, want %d (ColorRed)", color, ColorRed)
+	}
+
+	// Equal z goes to the latest write
+	canvas.SetColorZ(1, 0, ColorGreen, 5)
+	if color := canvas.GetColor(0, 0); color != ColorGreen {
+		t.Errorf("GetColor(0, 0) = %d, want %d (ColorGreen)", color, ColorGreen)
+	}
+}
+
+func TestColorMajorityTieGoesToLatest(t *testing.T) {
+	canvas := New(4, 8, WithColor(), WithColorPolicy(ColorMajority))
+	canvas.SetColor(0, 0, ColorRed)
+	canvas.SetColor(1, 0, ColorBlue)
+
+	if color := canvas.GetColor(0, 0); color != ColorBlue {
+		t.Errorf("GetColor(0, 0) = %d, want %d (ColorBlue)", color, ColorBlue)
+	}
+}
+
+func TestColorUnsetRemovesDotColor(t *testing.T) {
+	canvas := New(4, 8, WithColor())
+	canvas.SetColor(0, 0, ColorRed)
+	canvas.SetColor(1, 0, ColorBlue)
+
+	// Removing the blue dot leaves red as the only colored dot
+	canvas.Unset(1, 0)
+	if color := canvas.GetColor(0, 0); color != ColorRed {
+		t.Errorf("GetColor(0, 0) after Unset = %d, want %d (ColorRed)", color, ColorRed)
+	}
+
+	// Toggling the red dot off leaves no colored dots
+	canvas.Toggle(0, 0)
+	if color := canvas.GetColor(0, 0); color != ColorDefault {
+		t.Errorf("GetColor(0, 0) after Toggle = %d, want %d (ColorDefault)", color, ColorDefault)
+	}
+}
+
+func TestColorUncoloredDotsKeepCellColor(t *testing.T) {
+	canvas := New(4, 8, WithColor())
+	canvas.SetColor(0, 0, ColorRed)
+	canvas.Set(1, 1)
+
+	if color := canvas.GetColor(0, 0); color != ColorRed {
+		t.Errorf("GetColor(0, 0) = %d, want %d (ColorRed)", color, ColorRed)
+	}
+}
+
+func TestColorPolicyClear(t *testing.T) {
+	canvas := New(4, 8, WithColor(), WithColorPolicy(ColorFirstWrite))
+	canvas.SetColor(0, 0, ColorRed)
+	canvas.Clear()
+	canvas.SetColor(0, 0, ColorBlue)
+
+	if color := canvas.GetColor(0, 0); color != ColorBlue {
+		t.Errorf("GetColor(0, 0) after Clear = %d, want %d (ColorBlue)", color, ColorBlue)
+	}
+}
+
+func TestColorPolicyHalfBlock(t *testing.T) {
+	// Each half-block pixel is its own cell, so overlapping writes stay per pixel
+	canvas := New(1, 2, WithHalfBlock(), WithColor(), WithColorPolicy(ColorFirstWrite))
+	canvas.SetColor(0, 0, ColorRed)
+	canvas.SetColor(0, 0, ColorBlue)
+	canvas.SetColor(0, 1, ColorBlue)
+
+	if color := canvas.GetColor(0, 0); color != ColorRed {
+		t.Errorf("GetColor(0, 0) = %d, want %d (ColorRed)", color, ColorRed)
+	}
+	if color := canvas.GetColor(0, 1); color != ColorBlue {
+		t.Errorf("GetColor(0, 1) = %d, want %d (ColorBlue)", color, ColorBlue)
+	}
+}
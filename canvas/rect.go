@@ -0,0 +1,73 @@
+package canvas
+
+import "math"
+
+// Rect is an axis-aligned rectangle in canvas coordinates.
+// It covers the pixels from (X, Y) up to but not including (X+Width, Y+Height),
+// so a single pixel at (x, y) is Rect{X: x, Y: y, Width: 1, Height: 1}.
+type Rect struct {
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+}
+
+// Empty reports whether the rectangle covers no area.
+func (rect Rect) Empty() bool {
+	return rect.Width <= 0 || rect.Height <= 0
+}
+
+// Contains reports whether the pixel at (x, y) lies inside the rectangle.
+func (rect Rect) Contains(x, y float64) bool {
+	return x >= rect.X && x < rect.X+rect.Width && y >= rect.Y && y < rect.Y+rect.Height
+}
+
+// Intersects reports whether the two rectangles overlap.
+// Empty rectangles never intersect anything.
+func (rect Rect) Intersects(other Rect) bool {
+	if rect.Empty() || other.Empty() {
+		return false
+	}
+	return rect.X < other.X+other.Width && other.X < rect.X+rect.Width &&
+		rect.Y < other.Y+other.Height && other.Y < rect.Y+rect.Height
+}
+
+// Union returns the smallest rectangle containing both rectangles.
+// An empty rectangle does not contribute to the union.
+func (rect Rect) Union(other Rect) Rect {
+	if rect.Empty() {
+		return other
+	}
+	if other.Empty() {
+		return rect
+	}
+	left := math.Min(rect.X, other.X)
+	top := math.Min(rect.Y, other.Y)
+	right := math.Max(rect.X+rect.Width, other.X+other.Width)
+	bottom := math.Max(rect.Y+rect.Height, other.Y+other.Height)
+	return Rect{X: left, Y: top, Width: right - left, Height: bottom - top}
+}
+
+// Intersect returns the overlap of the two rectangles, or an empty rectangle
+// when they do not overlap.
+func (rect Rect) Intersect(other Rect) Rect {
+	if !rect.Intersects(other) {
+		return Rect{}
+	}
+	left := math.Max(rect.X, other.X)
+	top := math.Max(rect.Y, other.Y)
+	right := math.Min(rect.X+rect.Width, other.X+other.Width)
+	bottom := math.Min(rect.Y+rect.Height, other.Y+other.Height)
+	return Rect{X: left, Y: top, Width: right - left, Height: bottom - top}
+}
+
+// PixelBounds returns the rectangle covering the pixels from (x0, y0) to
+// (x1, y1) inclusive, in either order, after flooring the coordinates the same
+// way Set does.
+func PixelBounds(x0, y0, x1, y1 float64) Rect {
+	left := math.Floor(math.Min(x0, x1))
+	top := math.Floor(math.Min(y0, y1))
+	right := math.Floor(math.Max(x0, x1))
+	bottom := math.Floor(math.Max(y0, y1))
+	return Rect{X: left, Y: top, Width: right - left + 1, Height: bottom - top + 1}
+}
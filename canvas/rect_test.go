@@ -0,0 +1,133 @@
+package canvas
+
+import "testing"
+
+func TestRectEmpty(t *testing.T) {
+	tests := []struct {
+		rect     Rect
+		expected bool
+	}{
+		{Rect{Width: 1, Height: 1}, false},
+		{Rect{X: 5, Y: 5, Width: 0, Height: 3}, true},
+		{Rect{Width: 3, Height: -1}, true},
+		{Rect{}, true},
+	}
+
+	for _, testCase := range tests {
+		if result := testCase.rect.Empty(); result != testCase.expected {
+			t.Errorf("%+v.Empty() = %v, want %v", testCase.rect, result, testCase.expected)
+		}
+	}
+}
+
+func TestRectContains(t *testing.T) {
+	rect := Rect{X: 2, Y: 3, Width: 4, Height: 2}
+	tests := []struct {
+		x, y     float64
+		expected bool
+	}{
+		{2, 3, true},
+		{5, 4, true},
+		{5.9, 4.9, true},
+		{6, 4, false},
+		{5, 5, false},
+		{1.9, 3, false},
+	}
+
+	for _, testCase := range tests {
+		if result := rect.Contains(testCase.x, testCase.y); result != testCase.expected {
+			t.Errorf("Contains(%v, %v) = %v, want %v", testCase.x, testCase.y, result, testCase.expected)
+		}
+	}
+}
+
+func TestRectIntersects(t *testing.T) {
+	rect := Rect{X: 0, Y: 0, Width: 4, Height: 4}
+	tests := []struct {
+		name     string
+		other    Rect
+		expected bool
+	}{
+		{"overlapping", Rect{X: 3, Y: 3, Width: 4, Height: 4}, true},
+		{"inside", Rect{X: 1, Y: 1, Width: 1, Height: 1}, true},
+		{"touching edge", Rect{X: 4, Y: 0, Width: 2, Height: 2}, false},
+		{"separate", Rect{X: 10, Y: 10, Width: 2, Height: 2}, false},
+		{"empty inside", Rect{X: 1, Y: 1}, false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			if result := rect.Intersects(testCase.other); result != testCase.expected {
+				t.Errorf("Intersects(%+v) = %v, want %v", testCase.other, result, testCase.expected)
+			}
+			if result := testCase.other.Intersects(rect); result != testCase.expected {
+				t.Errorf("reversed Intersects() = %v, want %v", result, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestRectUnion(t *testing.T) {
+	tests := []struct {
+		name     string
+		rect     Rect
+		other    Rect
+		expected Rect
+	}{
+		{"disjoint", Rect{X: 0, Y: 0, Width: 2, Height: 2}, Rect{X: 5, Y: 1, Width: 1, Height: 4}, Rect{X: 0, Y: 0, Width: 6, Height: 5}},
+		{"nested", Rect{X: 0, Y: 0, Width: 8, Height: 8}, Rect{X: 2, Y: 2, Width: 1, Height: 1}, Rect{X: 0, Y: 0, Width: 8, Height: 8}},
+		{"empty first", Rect{}, Rect{X: 3, Y: 3, Width: 1, Height: 1}, Rect{X: 3, Y: 3, Width: 1, Height: 1}},
+		{"empty second", Rect{X: 3, Y: 3, Width: 1, Height: 1}, Rect{X: 9, Y: 9}, Rect{X: 3, Y: 3, Width: 1, Height: 1}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			if result := testCase.rect.Union(testCase.other); result != testCase.expected {
+				t.Errorf("Union() = %+v, want %+v", result, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestRectIntersect(t *testing.T) {
+	rect := Rect{X: 0, Y: 0, Width: 4, Height: 4}
+	tests := []struct {
+		name     string
+		other    Rect
+		expected Rect
+	}{
+		{"overlapping", Rect{X: 2, Y: 3, Width: 4, Height: 4}, Rect{X: 2, Y: 3, Width: 2, Height: 1}},
+		{"inside", Rect{X: 1, Y: 1, Width: 1, Height: 2}, Rect{X: 1, Y: 1, Width: 1, Height: 2}},
+		{"separate", Rect{X: 10, Y: 10, Width: 2, Height: 2}, Rect{}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			if result := rect.Intersect(testCase.other); result != testCase.expected {
+				t.Errorf("Intersect(%+v) = %+v, want %+v", testCase.other, result, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestPixelBounds(t *testing.T) {
+	tests := []struct {
+		name           string
+		x0, y0, x1, y1 float64
+		expected       Rect
+	}{
+		{"single pixel", 3, 4, 3, 4, Rect{X: 3, Y: 4, Width: 1, Height: 1}},
+		{"forward", 0, 0, 9, 4, Rect{X: 0, Y: 0, Width: 10, Height: 5}},
+		{"reversed", 9, 4, 0, 0, Rect{X: 0, Y: 0, Width: 10, Height: 5}},
+		{"fractional", 0.5, 0.5, 2.9, 1.2, Rect{X: 0, Y: 0, Width: 3, Height: 2}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			result := PixelBounds(testCase.x0, testCase.y0, testCase.x1, testCase.y1)
+			if result != testCase.expected {
+				t.Errorf("PixelBounds() = %+v, want %+v", result, testCase.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package canvas
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// Renderer selects the characters used to display each cell's 2x4 dots.
+// All renderers read the same dot data, so drawing code does not change.
+type Renderer uint8
+
+// Available renderers (RendererBraille is the default used by Frame).
+const (
+	RendererBraille   Renderer = iota // braille patterns, full 2x4 resolution
+	RendererHalfBlock                 // half blocks (▀▄█), 1x2 per cell
+	RendererQuadrant                  // quadrant blocks (▘▝▖▗ ...), 2x2 per cell
+	RendererSextant                   // sextant blocks (U+1FB00), 2x3 per cell
+	RendererASCII                     // ASCII density ramp based on the number of lit dots
+)
+
+// asciiRamp maps the number of lit dots in a cell (0 to 8) to a character.
+const asciiRamp = " .:-=+*#@"
+
+// quadrantGlyphs maps a quadrant bitmask (top-left 1, top-right 2,
+// bottom-left 4, bottom-right 8) to its block character.
+var quadrantGlyphs = [16]rune{
+	' ', '▘', '▝', '▀', '▖', '▌', '▞', '▛',
+	'▗', '▚', '▐', '▜', '▄', '▙', '▟', '█',
+}
+
+// sextantRows groups the 4 dot rows of a cell into the 3 rows of a sextant.
+var sextantRows = [3][]int{{0}, {1, 2}, {3}}
+
+// FrameWith renders the canvas to a string using the given renderer,
+// with rows joined by newlines. Colors are applied as in Frame.
+// Canvases created with WithHalfBlock always render as two-color half blocks.
+func (canvas *Canvas) FrameWith(renderer Renderer) string {
+	if canvas.halfBlock {
+		return canvas.halfBlockFrame()
+	}
+
+	glyph := renderer.glyph()
+
+	if !canvas.colorEnabled {
+		rows := make([]string, len(canvas.cells))
+		for index, row := range canvas.cells {
+			if renderer == RendererBraille && canvas.text == nil {
+				rows[index] = string(row)
+				continue
+			}
+			glyphs := make([]rune, len(row))
+			for column, cell := range row {
+				if text, ok := canvas.textAt(index, column); ok {
+					glyphs[column] = text.character
+					continue
+				}
+				glyphs[column] = glyph(cell)
+			}
+			rows[index] = string(glyphs)
+		}
+		return strings.Join(rows, "\n")
+	}
+
+	var builder strings.Builder
+	for rowIndex, row := range canvas.cells {
+		if rowIndex > 0 {
+			builder.WriteByte('\n')
+		}
+		for columnIndex, cell := range row {
+			character, color := glyph(cell), canvas.colors[rowIndex][columnIndex]
+			if text, ok := canvas.textAt(rowIndex, columnIndex); ok {
+				character, color = text.character, text.color
+			}
+			writeColored(&builder, character, color)
+		}
+	}
+	return builder.String()
+}
+
+// writeColored writes a character wrapped in the color's escape sequences,
+// or on its own for ColorDefault.
+func writeColored(builder *strings.Builder, character rune, color Color) {
+	if color == ColorDefault {
+		builder.WriteRune(character)
+		return
+	}
+	builder.WriteString(color.ANSI())
+	builder.WriteRune(character)
+	builder.WriteString(ANSIReset())
+}
+
+// glyph returns the function that converts a braille cell to this renderer's character.
+// Unknown renderers fall back to braille.
+func (renderer Renderer) glyph() func(cell rune) rune {
+	switch renderer {
+	case RendererHalfBlock:
+		return halfBlockGlyph
+	case RendererQuadrant:
+		return quadrantGlyph
+	case RendererSextant:
+		return sextantGlyph
+	case RendererASCII:
+		return asciiGlyph
+	default:
+		return func(cell rune) rune { return cell }
+	}
+}
+
+// halfBlockGlyph lights the upper half for dot rows 0-1 and the lower half for rows 2-3.
+func halfBlockGlyph(cell rune) rune {
+	mask := quadrantMask(cell)
+	upper := mask&0b0011 != 0
+	lower := mask&0b1100 != 0
+	switch {
+	case upper && lower:
+		return '█'
+	case upper:
+		return '▀'
+	case lower:
+		return '▄'
+	default:
+		return ' '
+	}
+}
+
+// quadrantGlyph lights each quadrant that contains at least one dot.
+func quadrantGlyph(cell rune) rune {
+	return quadrantGlyphs[quadrantMask(cell)]
+}
+
+// quadrantMask returns the quadrant bitmask for a braille cell.
+func quadrantMask(cell rune) int {
+	mask := 0
+	for dotRow := 0; dotRow < 4; dotRow++ {
+		for dotColumn := 0; dotColumn < 2; dotColumn++ {
+			if cell&pixelMap[dotRow][dotColumn] != 0 {
+				mask |= 1 << (dotRow / 2 * 2) << dotColumn
+			}
+		}
+	}
+	return mask
+}
+
+// sextantGlyph lights each sextant that contains at least one dot.
+func sextantGlyph(cell rune) rune {
+	mask := 0
+	for sextantRow, dotRows := range sextantRows {
+		for _, dotRow := range dotRows {
+			for dotColumn := 0; dotColumn < 2; dotColumn++ {
+				if cell&pixelMap[dotRow][dotColumn] != 0 {
+					mask |= 1 << (sextantRow * 2) << dotColumn
+				}
+			}
+		}
+	}
+
+	// The sextant block omits the patterns that already exist as
+	// space, left half, right half, and full block.
+	switch mask {
+	case 0:
+		return ' '
+	case 0b010101:
+		return '▌'
+	case 0b101010:
+		return '▐'
+	case 0b111111:
+		return '█'
+	}
+	offset := mask - 1
+	if mask > 0b010101 {
+		offset--
+	}
+	if mask > 0b101010 {
+		offset--
+	}
+	return 0x1FB00 + rune(offset)
+}
+
+// asciiGlyph picks a density ramp character from the number of lit dots.
+func asciiGlyph(cell rune) rune {
+	return rune(asciiRamp[bits.OnesCount8(uint8(cell-BrailleOffset))])
+}
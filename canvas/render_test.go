@@ -0,0 +1,156 @@
+package canvas
+
+import (
+	"strings"
+	"testing"
+)
+
+// cellWithDots returns a 2x4 canvas with the given (x, y) dots set.
+func cellWithDots(dots ...[2]float64) *Canvas {
+	canvas := New(2, 4)
+	for _, dot := range dots {
+		canvas.Set(dot[0], dot[1])
+	}
+	return canvas
+}
+
+func TestFrameWithBrailleMatchesFrame(t *testing.T) {
+	canvas := New(8, 8)
+	canvas.Set(0, 0)
+	canvas.Set(5, 6)
+
+	if canvas.FrameWith(RendererBraille) != canvas.Frame() {
+		t.Errorf("FrameWith(RendererBraille) =\n%s\nwant:\n%s", canvas.FrameWith(RendererBraille), canvas.Frame())
+	}
+}
+
+func TestFrameWithHalfBlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		canvas   *Canvas
+		expected string
+	}{
+		{"empty", cellWithDots(), " "},
+		{"upper", cellWithDots([2]float64{0, 1}), "▀"},
+		{"lower", cellWithDots([2]float64{1, 2}), "▄"},
+		{"both", cellWithDots([2]float64{0, 0}, [2]float64{1, 3}), "█"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			frame := testCase.canvas.FrameWith(RendererHalfBlock)
+			if frame != testCase.expected {
+				t.Errorf("FrameWith(RendererHalfBlock) = %q, want %q", frame, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestFrameWithQuadrant(t *testing.T) {
+	tests := []struct {
+		name     string
+		canvas   *Canvas
+		expected string
+	}{
+		{"empty", cellWithDots(), " "},
+		{"top-left", cellWithDots([2]float64{0, 1}), "▘"},
+		{"top-right", cellWithDots([2]float64{1, 0}), "▝"},
+		{"bottom-left", cellWithDots([2]float64{0, 3}), "▖"},
+		{"bottom-right", cellWithDots([2]float64{1, 2}), "▗"},
+		{"diagonal", cellWithDots([2]float64{0, 0}, [2]float64{1, 3}), "▚"},
+		{"left column", cellWithDots([2]float64{0, 0}, [2]float64{0, 3}), "▌"},
+		{"three quadrants", cellWithDots([2]float64{0, 0}, [2]float64{1, 0}, [2]float64{0, 2}), "▛"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			frame := testCase.canvas.FrameWith(RendererQuadrant)
+			if frame != testCase.expected {
+				t.Errorf("FrameWith(RendererQuadrant) = %q, want %q", frame, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestFrameWithSextant(t *testing.T) {
+	tests := []struct {
+		name     string
+		canvas   *Canvas
+		expected rune
+	}{
+		{"empty", cellWithDots(), ' '},
+		{"top-left", cellWithDots([2]float64{0, 0}), 0x1FB00},
+		{"top-right", cellWithDots([2]float64{1, 0}), 0x1FB01},
+		{"middle-left from dot row 1", cellWithDots([2]float64{0, 1}), 0x1FB03},
+		{"middle-left from dot row 2", cellWithDots([2]float64{0, 2}), 0x1FB03},
+		{"left column", cellWithDots([2]float64{0, 0}, [2]float64{0, 1}, [2]float64{0, 3}), '▌'},
+		{"right column", cellWithDots([2]float64{1, 0}, [2]float64{1, 2}, [2]float64{1, 3}), '▐'},
+		{"all but top-left", cellWithDots(
+			[2]float64{1, 0}, [2]float64{0, 1}, [2]float64{1, 1}, [2]float64{0, 3}, [2]float64{1, 3}), 0x1FB3B},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			frame := testCase.canvas.FrameWith(RendererSextant)
+			if frame != string(testCase.expected) {
+				t.Errorf("FrameWith(RendererSextant) = %q (%U), want %q (%U)",
+					frame, []rune(frame)[0], string(testCase.expected), testCase.expected)
+			}
+		})
+	}
+
+	full := New(2, 4)
+	for y := 0; y < 4; y++ {
+		full.Set(0, float64(y))
+		full.Set(1, float64(y))
+	}
+	if frame := full.FrameWith(RendererSextant); frame != "█" {
+		t.Errorf("FrameWith(RendererSextant) for full cell = %q, want %q", frame, "█")
+	}
+}
+
+func TestFrameWithASCII(t *testing.T) {
+	canvas := New(18, 4)
+	// Cell n has n dots lit, for n from 0 to 8
+	for cell := 0; cell <= 8; cell++ {
+		for dot := 0; dot < cell; dot++ {
+			canvas.Set(float64(cell*2+dot%2), float64(dot/2))
+		}
+	}
+
+	frame := canvas.FrameWith(RendererASCII)
+	if frame != asciiRamp {
+		t.Errorf("FrameWith(RendererASCII) = %q, want %q", frame, asciiRamp)
+	}
+
+	printVisual(t, "TestFrameWithASCII", canvas)
+}
+
+func TestFrameWithColor(t *testing.T) {
+	canvas := New(4, 4, WithColor())
+	canvas.SetColor(0, 0, ColorRed)
+	canvas.Set(3, 3)
+
+	frame := canvas.FrameWith(RendererQuadrant)
+	expected := ColorRed.ANSI() + "▘" + ANSIReset() + "▗"
+	if frame != expected {
+		t.Errorf("FrameWith(RendererQuadrant) = %q, want %q", frame, expected)
+	}
+}
+
+func TestFrameWithMultipleRows(t *testing.T) {
+	canvas := New(4, 8)
+	canvas.Set(0, 0)
+	canvas.Set(3, 7)
+
+	lines := strings.Split(canvas.FrameWith(RendererHalfBlock), "\n")
+	expected := []string{"▀ ", " ▄"}
+	if len(lines) != len(expected) {
+		t.Fatalf("FrameWith(RendererHalfBlock) has %d rows, want %d", len(lines), len(expected))
+	}
+	for index := range expected {
+		if lines[index] != expected[index] {
+			t.Errorf("row %d = %q, want %q", index, lines[index], expected[index])
+		}
+	}
+}
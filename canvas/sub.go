@@ -0,0 +1,78 @@
+package canvas
+
+import "math"
+
+// Sub returns a view of the terminal cells lying entirely inside rect, given
+// in canvas coordinates. The view is a Canvas the size of those cells, with
+// the parent's options and its origin at the region's top-left corner
+// (bottom-left with WithInvertedY), but it shares the parent's storage:
+// drawing into the view changes the parent, and the parent's drawing shows
+// through the view. Views can be nested.
+//
+// The view starts unclipped and is not limited by the parent's clip
+// rectangle. A rect that covers no whole cell returns an empty view.
+func (canvas *Canvas) Sub(rect Rect) *Canvas {
+	column, row, columns, rows := canvas.cellsInside(rect)
+	cellWidth, cellHeight := canvas.terminalCellSize()
+	storedWidth, storedHeight := canvas.cellSize()
+	view := &Canvas{
+		colorEnabled:  canvas.colorEnabled,
+		colorPolicy:   canvas.colorPolicy,
+		colorSequence: canvas.colorSequence,
+		depthEnabled:  canvas.depthEnabled,
+		halfBlock:     canvas.halfBlock,
+		height:        rows * cellHeight,
+		invertY:       canvas.invertY,
+		shared:        true,
+		view:          true,
+		width:         columns * cellWidth,
+	}
+	canvas.shared = true
+
+	// Stored cells are smaller than terminal cells in half-block mode
+	top, bottom := row*cellHeight/storedHeight, (row+rows)*cellHeight/storedHeight
+	left, right := column*cellWidth/storedWidth, (column+columns)*cellWidth/storedWidth
+	view.cells = subGrid(canvas.cells, top, bottom, left, right)
+	if canvas.colors != nil {
+		view.colors = subGrid(canvas.colors, top, bottom, left, right)
+		view.dotColors = subGrid(canvas.dotColors, top, bottom, left, right)
+	}
+	if canvas.depth != nil {
+		view.depth = subGrid(canvas.depth, row*cellHeight, (row+rows)*cellHeight, column*cellWidth, (column+columns)*cellWidth)
+	}
+	canvas.allocateText()
+	view.text = subGrid(canvas.text, row, row+rows, column, column+columns)
+	return view
+}
+
+// cellsInside returns the terminal cells lying entirely inside rect, limited
+// to the canvas, counted from the top-left cell.
+func (canvas *Canvas) cellsInside(rect Rect) (column, row, columns, rows int) {
+	rect = rect.Intersect(Rect{Width: float64(canvas.width), Height: float64(canvas.height)})
+	if rect.Empty() {
+		return 0, 0, 0, 0
+	}
+	top := rect.Y
+	if canvas.invertY {
+		top = float64(canvas.height) - rect.Y - rect.Height
+	}
+	cellWidth, cellHeight := canvas.terminalCellSize()
+	column = int(math.Ceil(rect.X / float64(cellWidth)))
+	row = int(math.Ceil(top / float64(cellHeight)))
+	right := min(int(math.Floor((rect.X+rect.Width)/float64(cellWidth))), canvas.Cols())
+	bottom := min(int(math.Floor((top+rect.Height)/float64(cellHeight))), canvas.Rows())
+	if right <= column || bottom <= row {
+		return 0, 0, 0, 0
+	}
+	return column, row, right - column, bottom - row
+}
+
+// subGrid returns the rows top to bottom and columns left to right of grid,
+// sharing its storage.
+func subGrid[T any](grid [][]T, top, bottom, left, right int) [][]T {
+	rows := make([][]T, bottom-top)
+	for index := range rows {
+		rows[index] = grid[top+index][left:right:right]
+	}
+	return rows
+}
@@ -0,0 +1,173 @@
+package canvas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSubDimensions(t *testing.T) {
+	tests := []struct {
+		name           string
+		options        []Option
+		rect           Rect
+		expectedWidth  int
+		expectedHeight int
+	}{
+		{"aligned", nil, Rect{X: 2, Y: 4, Width: 6, Height: 8}, 6, 8},
+		{"partial cells dropped", nil, Rect{X: 1, Y: 2, Width: 8, Height: 10}, 6, 8},
+		{"limited to canvas", nil, Rect{X: 12, Y: 8, Width: 20, Height: 20}, 8, 8},
+		{"outside", nil, Rect{X: 40, Y: 0, Width: 4, Height: 4}, 0, 0},
+		{"no whole cell", nil, Rect{X: 1, Y: 1, Width: 2, Height: 4}, 0, 0},
+		{"half block", []Option{WithHalfBlock()}, Rect{X: 3, Y: 1, Width: 4, Height: 5}, 4, 4},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			view := New(20, 16, testCase.options...).Sub(testCase.rect)
+			if view.Width() != testCase.expectedWidth || view.Height() != testCase.expectedHeight {
+				t.Errorf("Sub(%v) size = %dx%d, want %dx%d", testCase.rect,
+					view.Width(), view.Height(), testCase.expectedWidth, testCase.expectedHeight)
+			}
+			if frame := view.Frame(); view.Width() == 0 && frame != "" {
+				t.Errorf("empty view Frame() = %q, want empty", frame)
+			}
+		})
+	}
+}
+
+func TestSubSharesPixels(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		rect    Rect
+		parentX float64 // parent pixel under the view's origin
+		parentY float64
+	}{
+		{"braille", nil, Rect{X: 4, Y: 4, Width: 8, Height: 8}, 4, 4},
+		{"inverted", []Option{WithInvertedY()}, Rect{X: 4, Y: 4, Width: 8, Height: 8}, 4, 4},
+		{"half block", []Option{WithHalfBlock()}, Rect{X: 3, Y: 2, Width: 5, Height: 6}, 3, 2},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			parent := New(20, 16, testCase.options...)
+			view := parent.Sub(testCase.rect)
+
+			view.Set(0, 0)
+			if !parent.Get(testCase.parentX, testCase.parentY) {
+				t.Errorf("view Set(0, 0) did not light parent (%v, %v)", testCase.parentX, testCase.parentY)
+			}
+			parent.Set(testCase.parentX+1, testCase.parentY+1)
+			if !view.Get(1, 1) {
+				t.Error("parent drawing does not show through the view")
+			}
+			view.Unset(0, 0)
+			if parent.Get(testCase.parentX, testCase.parentY) {
+				t.Error("view Unset(0, 0) left the parent pixel lit")
+			}
+
+			// Writes outside the view are dropped rather than reaching the parent
+			view.Set(-1, 0)
+			view.Set(float64(view.Width()), 0)
+			if parent.Get(testCase.parentX-1, testCase.parentY) {
+				t.Error("view wrote outside its region")
+			}
+		})
+	}
+}
+
+func TestSubFrame(t *testing.T) {
+	parent := New(12, 8)
+	view := parent.Sub(Rect{X: 4, Y: 4, Width: 4, Height: 4})
+	view.Set(0, 0)
+	view.SetText(1, 0, "x")
+	parent.Set(11, 7)
+
+	if frame := view.Frame(); frame != "⠁x" {
+		t.Errorf("view Frame() = %q, want %q", frame, "⠁x")
+	}
+	if frame := parent.Frame(); frame != "⠀⠀⠀⠀⠀⠀\n⠀⠀⠁x⠀⢀" {
+		t.Errorf("parent Frame() = %q, want %q", frame, "⠀⠀⠀⠀⠀⠀\n⠀⠀⠁x⠀⢀")
+	}
+}
+
+func TestSubNested(t *testing.T) {
+	parent := New(20, 16)
+	inner := parent.Sub(Rect{X: 2, Y: 4, Width: 16, Height: 12}).Sub(Rect{X: 4, Y: 4, Width: 4, Height: 4})
+	inner.Set(1, 2)
+	if !parent.Get(7, 10) {
+		t.Error("nested view Set(1, 2) did not light parent (7, 10)")
+	}
+}
+
+func TestSubColorOrdering(t *testing.T) {
+	parent := New(8, 4, WithColor())
+	view := parent.Sub(Rect{X: 0, Y: 0, Width: 2, Height: 4})
+	parent.SetColor(0, 0, ColorRed)
+	view.SetColor(1, 1, ColorBlue)
+	if color := parent.GetColor(0, 0); color != ColorBlue {
+		t.Errorf("GetColor() after view write = %v, want %v", color, ColorBlue)
+	}
+
+	// Clearing the view must not reorder writes in the rest of the parent
+	parent.SetColor(4, 0, ColorGreen)
+	view.Clear()
+	parent.SetColor(5, 0, ColorYellow)
+	if color := parent.GetColor(4, 0); color != ColorYellow {
+		t.Errorf("GetColor() after view Clear = %v, want %v", color, ColorYellow)
+	}
+}
+
+func TestSubClear(t *testing.T) {
+	parent := New(12, 4)
+	parent.Set(0, 0)
+	parent.SetText(5, 0, "b")
+	view := parent.Sub(Rect{X: 4, Y: 0, Width: 4, Height: 4})
+	view.Set(0, 0)
+	view.SetText(0, 0, "a")
+
+	view.Clear()
+	if view.Get(0, 0) || view.Text(0, 0) != 0 {
+		t.Error("view Clear() left its pixels or text")
+	}
+	if !parent.Get(0, 0) || parent.Text(5, 0) != 'b' {
+		t.Error("view Clear() changed the parent outside the view")
+	}
+
+	// The parent clearing its text keeps the view attached
+	parent.ClearText()
+	view.SetText(0, 0, "c")
+	if parent.Text(2, 0) != 'c' {
+		t.Errorf("parent Text(2, 0) = %q after ClearText, want 'c'", parent.Text(2, 0))
+	}
+}
+
+func TestSubDepth(t *testing.T) {
+	parent := New(8, 8, WithDepth())
+	view := parent.Sub(Rect{X: 2, Y: 4, Width: 4, Height: 4})
+	view.SetDepth(1, 1, 5)
+	if depth := parent.Depth(3, 5); depth != 5 {
+		t.Errorf("parent Depth(3, 5) = %v, want 5", depth)
+	}
+	if parent.SetDepth(3, 5, 6) {
+		t.Error("parent SetDepth behind the view's write passed")
+	}
+
+	view.ClearDepth()
+	if depth := parent.Depth(3, 5); !math.IsInf(depth, 1) {
+		t.Errorf("parent Depth(3, 5) after view ClearDepth = %v, want +Inf", depth)
+	}
+}
+
+func TestSubIgnoresParentClip(t *testing.T) {
+	parent := New(8, 4)
+	parent.SetClip(Rect{X: 0, Y: 0, Width: 2, Height: 4})
+	view := parent.Sub(Rect{X: 4, Y: 0, Width: 4, Height: 4})
+	view.Set(0, 0)
+	if !parent.Get(4, 0) {
+		t.Error("view write was blocked by the parent's clip")
+	}
+	if _, clipped := view.Clip(); clipped {
+		t.Error("view Clip() reports a clip, want unclipped")
+	}
+}
@@ -0,0 +1,76 @@
+package canvas
+
+// textCell is a character drawn over a terminal cell by SetText.
+type textCell struct {
+	character rune  // the character, 0 when the cell has no text
+	color     Color // the character's color
+}
+
+// SetText writes text over the canvas starting at the given terminal cell,
+// one character per cell, left to right. Text replaces the cell's pixels in
+// the rendered frame but does not change them, so Get still reports the dots
+// underneath. Characters outside the canvas are dropped. Text should not
+// contain newlines or characters wider than one cell.
+func (canvas *Canvas) SetText(column, row int, text string) {
+	canvas.SetTextColor(column, row, text, ColorDefault)
+}
+
+// SetTextColor writes text like SetText in the given color.
+// Without WithColor(), the text is written and the color is ignored.
+func (canvas *Canvas) SetTextColor(column, row int, text string, color Color) {
+	if row < 0 || row >= canvas.Rows() {
+		return
+	}
+	canvas.allocateText()
+	if !canvas.colorEnabled {
+		color = ColorDefault
+	}
+
+	for _, character := range text {
+		if column >= 0 && column < canvas.Cols() {
+			canvas.text[row][column] = textCell{character: character, color: color}
+		}
+		column++
+	}
+}
+
+// Text returns the text character drawn over a terminal cell, or 0 when the
+// cell shows pixels.
+func (canvas *Canvas) Text(column, row int) rune {
+	cell, ok := canvas.textAt(row, column)
+	if !ok {
+		return 0
+	}
+	return cell.character
+}
+
+// ClearText removes all text written with SetText, leaving the pixels.
+func (canvas *Canvas) ClearText() {
+	if !canvas.shared {
+		canvas.text = nil
+		return
+	}
+	for _, row := range canvas.text {
+		clear(row)
+	}
+}
+
+// allocateText creates the text overlay if no text has been written yet.
+func (canvas *Canvas) allocateText() {
+	if canvas.text != nil {
+		return
+	}
+	canvas.text = make([][]textCell, canvas.Rows())
+	for index := range canvas.text {
+		canvas.text[index] = make([]textCell, canvas.Cols())
+	}
+}
+
+// textAt returns the text over a terminal cell, with ok = false when there is none.
+func (canvas *Canvas) textAt(row, column int) (cell textCell, ok bool) {
+	if canvas.text == nil || row < 0 || row >= len(canvas.text) || column < 0 || column >= len(canvas.text[row]) {
+		return textCell{}, false
+	}
+	cell = canvas.text[row][column]
+	return cell, cell.character != 0
+}
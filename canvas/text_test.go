@@ -0,0 +1,91 @@
+package canvas
+
+import "testing"
+
+func TestSetText(t *testing.T) {
+	canvas := New(10, 8)
+	canvas.Set(0, 0)
+	canvas.Set(9, 7)
+	canvas.SetText(1, 0, "Hi")
+	canvas.SetText(3, 1, "abc")
+
+	expected := "⠁Hi⠀⠀\n⠀⠀⠀ab"
+	if frame := canvas.Frame(); frame != expected {
+		t.Errorf("Frame() = %q, want %q", frame, expected)
+	}
+	if !canvas.Get(9, 7) {
+		t.Error("Get(9, 7) = false under text, want true")
+	}
+	if canvas.Text(1, 0) != 'H' || canvas.Text(0, 0) != 0 || canvas.Text(99, 0) != 0 {
+		t.Errorf("Text() = %q, %q, %q, want 'H', 0, 0", canvas.Text(1, 0), canvas.Text(0, 0), canvas.Text(99, 0))
+	}
+}
+
+func TestSetTextOutOfBounds(t *testing.T) {
+	canvas := New(6, 4)
+	canvas.SetText(-2, 0, "abcd")
+	canvas.SetText(0, 5, "x")
+	canvas.SetText(0, -1, "x")
+
+	if frame := canvas.Frame(); frame != "cd⠀" {
+		t.Errorf("Frame() = %q, want %q", frame, "cd⠀")
+	}
+}
+
+func TestSetTextColor(t *testing.T) {
+	canvas := New(6, 4, WithColor())
+	canvas.SetColor(0, 0, ColorBlue)
+	canvas.SetTextColor(1, 0, "A", ColorRed)
+	canvas.SetText(2, 0, "B")
+
+	expected := ColorBlue.ANSI() + "⠁" + ANSIReset() + ColorRed.ANSI() + "A" + ANSIReset() + "B"
+	if frame := canvas.Frame(); frame != expected {
+		t.Errorf("Frame() = %q, want %q", frame, expected)
+	}
+}
+
+func TestSetTextColorWithoutColorSupport(t *testing.T) {
+	canvas := New(4, 4)
+	canvas.SetTextColor(0, 0, "A", ColorRed)
+	if frame := canvas.Frame(); frame != "A⠀" {
+		t.Errorf("Frame() = %q, want %q", frame, "A⠀")
+	}
+}
+
+func TestTextRenderers(t *testing.T) {
+	tests := []struct {
+		name     string
+		canvas   *Canvas
+		renderer Renderer
+		expected string
+	}{
+		{"ascii", New(6, 4), RendererASCII, " x "},
+		{"quadrant", New(6, 4), RendererQuadrant, " x "},
+		{"half-block canvas", New(3, 2, WithHalfBlock()), RendererBraille, " x "},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.canvas.SetText(1, 0, "x")
+			if frame := testCase.canvas.FrameWith(testCase.renderer); frame != testCase.expected {
+				t.Errorf("FrameWith() = %q, want %q", frame, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestClearRemovesText(t *testing.T) {
+	canvas := New(4, 4)
+	canvas.SetText(0, 0, "ab")
+	canvas.Clear()
+	if frame := canvas.Frame(); frame != "⠀⠀" {
+		t.Errorf("Frame() after Clear() = %q, want %q", frame, "⠀⠀")
+	}
+
+	canvas.Set(0, 0)
+	canvas.SetText(1, 0, "b")
+	canvas.ClearText()
+	if frame := canvas.Frame(); frame != "⠁⠀" {
+		t.Errorf("Frame() after ClearText() = %q, want %q", frame, "⠁⠀")
+	}
+}
@@ -10,6 +10,27 @@ import (
 // Radius of 0 draws a single pixel at the center.
 // Negative radius draws nothing.
 func Circle(c *canvas.Canvas, centerX, centerY, radius float64) {
+	circle(c.Set, centerX, centerY, radius)
+}
+
+// CircleColor draws a circle outline like Circle in the given color.
+func CircleColor(c *canvas.Canvas, centerX, centerY, radius float64, color canvas.Color) {
+	circle(colorPlotter(c, color), centerX, centerY, radius)
+}
+
+// CircleDepth draws a circle outline like Circle, depth testing every pixel at
+// depth z with SetDepth.
+func CircleDepth(c *canvas.Canvas, centerX, centerY, radius, z float64) {
+	circle(depthPlotter(c, z), centerX, centerY, radius)
+}
+
+// CircleDepthColor draws a circle outline like CircleDepth in the given color.
+func CircleDepthColor(c *canvas.Canvas, centerX, centerY, radius, z float64, color canvas.Color) {
+	circle(depthColorPlotter(c, z, color), centerX, centerY, radius)
+}
+
+// circle plots the outline of a circle.
+func circle(plot func(x, y float64), centerX, centerY, radius float64) {
 	if radius < 0 {
 		return
 	}
@@ -19,7 +40,7 @@ func Circle(c *canvas.Canvas, centerX, centerY, radius float64) {
 	intRadius := int(math.Floor(radius))
 
 	if intRadius == 0 {
-		c.Set(float64(intCenterX), float64(intCenterY))
+		plot(float64(intCenterX), float64(intCenterY))
 		return
 	}
 
@@ -27,7 +48,7 @@ func Circle(c *canvas.Canvas, centerX, centerY, radius float64) {
 	y := intRadius
 	d := 1 - intRadius
 
-	plotCirclePoints(c, intCenterX, intCenterY, x, y)
+	plotCirclePoints(plot, intCenterX, intCenterY, x, y)
 
 	for x <= y {
 		x++
@@ -37,7 +58,7 @@ func Circle(c *canvas.Canvas, centerX, centerY, radius float64) {
 			y--
 			d = d + 2*(x-y) + 1 // move southeast
 		}
-		plotCirclePoints(c, intCenterX, intCenterY, x, y)
+		plotCirclePoints(plot, intCenterX, intCenterY, x, y)
 	}
 }
 
@@ -45,6 +66,27 @@ func Circle(c *canvas.Canvas, centerX, centerY, radius float64) {
 // Radius of 0 draws a single pixel at the center.
 // Negative radius draws nothing.
 func CircleFilled(c *canvas.Canvas, centerX, centerY, radius float64) {
+	circleFilled(c.Set, centerX, centerY, radius)
+}
+
+// CircleFilledColor draws a filled circle like CircleFilled in the given color.
+func CircleFilledColor(c *canvas.Canvas, centerX, centerY, radius float64, color canvas.Color) {
+	circleFilled(colorPlotter(c, color), centerX, centerY, radius)
+}
+
+// CircleFilledDepth draws a filled circle like CircleFilled, depth testing every
+// pixel at depth z with SetDepth.
+func CircleFilledDepth(c *canvas.Canvas, centerX, centerY, radius, z float64) {
+	circleFilled(depthPlotter(c, z), centerX, centerY, radius)
+}
+
+// CircleFilledDepthColor draws a filled circle like CircleFilledDepth in the given color.
+func CircleFilledDepthColor(c *canvas.Canvas, centerX, centerY, radius, z float64, color canvas.Color) {
+	circleFilled(depthColorPlotter(c, z, color), centerX, centerY, radius)
+}
+
+// circleFilled plots every pixel of a circle.
+func circleFilled(plot func(x, y float64), centerX, centerY, radius float64) {
 	if radius < 0 {
 		return
 	}
@@ -54,7 +96,7 @@ func CircleFilled(c *canvas.Canvas, centerX, centerY, radius float64) {
 	intRadius := int(math.Floor(radius))
 
 	if intRadius == 0 {
-		c.Set(float64(intCenterX), float64(intCenterY))
+		plot(float64(intCenterX), float64(intCenterY))
 		return
 	}
 
@@ -62,7 +104,7 @@ func CircleFilled(c *canvas.Canvas, centerX, centerY, radius float64) {
 	y := intRadius
 	d := 1 - intRadius
 
-	drawCircleSpans(c, intCenterX, intCenterY, x, y)
+	drawCircleSpans(plot, intCenterX, intCenterY, x, y)
 
 	for x <= y {
 		x++
@@ -72,33 +114,33 @@ func CircleFilled(c *canvas.Canvas, centerX, centerY, radius float64) {
 			y--
 			d = d + 2*(x-y) + 1 // move southeast
 		}
-		drawCircleSpans(c, intCenterX, intCenterY, x, y)
+		drawCircleSpans(plot, intCenterX, intCenterY, x, y)
 	}
 }
 
 // plotCirclePoints plots all 8 symmetric points for the circle outline.
-func plotCirclePoints(c *canvas.Canvas, centerX, centerY, x, y int) {
-	c.Set(float64(centerX+x), float64(centerY+y))
-	c.Set(float64(centerX-x), float64(centerY+y))
-	c.Set(float64(centerX+x), float64(centerY-y))
-	c.Set(float64(centerX-x), float64(centerY-y))
-	c.Set(float64(centerX+y), float64(centerY+x))
-	c.Set(float64(centerX-y), float64(centerY+x))
-	c.Set(float64(centerX+y), float64(centerY-x))
-	c.Set(float64(centerX-y), float64(centerY-x))
+func plotCirclePoints(plot func(x, y float64), centerX, centerY, x, y int) {
+	plot(float64(centerX+x), float64(centerY+y))
+	plot(float64(centerX-x), float64(centerY+y))
+	plot(float64(centerX+x), float64(centerY-y))
+	plot(float64(centerX-x), float64(centerY-y))
+	plot(float64(centerX+y), float64(centerY+x))
+	plot(float64(centerX-y), float64(centerY+x))
+	plot(float64(centerX+y), float64(centerY-x))
+	plot(float64(centerX-y), float64(centerY-x))
 }
 
 // drawCircleSpans draws 4 horizontal spans covering all octants for filled circles.
-func drawCircleSpans(c *canvas.Canvas, centerX, centerY, x, y int) {
-	drawHorizontalSpan(c, centerX-x, centerX+x, centerY+y)
-	drawHorizontalSpan(c, centerX-x, centerX+x, centerY-y)
-	drawHorizontalSpan(c, centerX-y, centerX+y, centerY+x)
-	drawHorizontalSpan(c, centerX-y, centerX+y, centerY-x)
+func drawCircleSpans(plot func(x, y float64), centerX, centerY, x, y int) {
+	drawHorizontalSpan(plot, centerX-x, centerX+x, centerY+y)
+	drawHorizontalSpan(plot, centerX-x, centerX+x, centerY-y)
+	drawHorizontalSpan(plot, centerX-y, centerX+y, centerY+x)
+	drawHorizontalSpan(plot, centerX-y, centerX+y, centerY-x)
 }
 
 // drawHorizontalSpan draws a horizontal line from startX to endX at the given y.
-func drawHorizontalSpan(c *canvas.Canvas, startX, endX, y int) {
+func drawHorizontalSpan(plot func(x, y float64), startX, endX, y int) {
 	for pixelX := startX; pixelX <= endX; pixelX++ {
-		c.Set(float64(pixelX), float64(y))
+		plot(float64(pixelX), float64(y))
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/stippletest"
 )
 
 func countSetPixels(c *canvas.Canvas, width, height int) int {
@@ -54,7 +55,7 @@ func TestCircleSymmetry(t *testing.T) {
 	}
 
 	assertGolden(t, "circle_outline", c)
-	printVisual(t, "TestCircleSymmetry", c)
+	stippletest.PrintVisual(t, "TestCircleSymmetry", c)
 }
 
 func TestCircleRadius0(t *testing.T) {
@@ -72,7 +73,7 @@ func TestCircleRadius0(t *testing.T) {
 	}
 
 	assertGolden(t, "circle_radius_0", c)
-	printVisual(t, "TestCircleRadius0", c)
+	stippletest.PrintVisual(t, "TestCircleRadius0", c)
 }
 
 func TestCircleRadius1(t *testing.T) {
@@ -100,7 +101,7 @@ func TestCircleRadius1(t *testing.T) {
 	}
 
 	assertGolden(t, "circle_radius_1", c)
-	printVisual(t, "TestCircleRadius1", c)
+	stippletest.PrintVisual(t, "TestCircleRadius1", c)
 }
 
 func TestCircleDiagonalHandling(t *testing.T) {
@@ -137,7 +138,7 @@ func TestCircleDiagonalHandling(t *testing.T) {
 		}
 	}
 
-	printVisual(t, "TestCircleDiagonalHandling", c)
+	stippletest.PrintVisual(t, "TestCircleDiagonalHandling", c)
 }
 
 func TestCircleNegativeRadius(t *testing.T) {
@@ -165,7 +166,7 @@ func TestCircleFloatCoordinates(t *testing.T) {
 		t.Error("west point (2, 6) not set with floored coordinates")
 	}
 
-	printVisual(t, "TestCircleFloatCoordinates", c)
+	stippletest.PrintVisual(t, "TestCircleFloatCoordinates", c)
 }
 
 func TestCirclePartiallyOffCanvas(t *testing.T) {
@@ -187,7 +188,7 @@ func TestCirclePartiallyOffCanvas(t *testing.T) {
 	// Points off canvas should be silently clipped (no panic)
 	// The circle should still be partially drawn
 
-	printVisual(t, "TestCirclePartiallyOffCanvas", c)
+	stippletest.PrintVisual(t, "TestCirclePartiallyOffCanvas", c)
 }
 
 func TestCircleFilled(t *testing.T) {
@@ -222,7 +223,7 @@ func TestCircleFilled(t *testing.T) {
 	}
 
 	assertGolden(t, "circle_filled", c)
-	printVisual(t, "TestCircleFilled", c)
+	stippletest.PrintVisual(t, "TestCircleFilled", c)
 }
 
 func TestCircleFilledNoGaps(t *testing.T) {
@@ -253,7 +254,7 @@ func TestCircleFilledNoGaps(t *testing.T) {
 		}
 	}
 
-	printVisual(t, "TestCircleFilledNoGaps", c)
+	stippletest.PrintVisual(t, "TestCircleFilledNoGaps", c)
 }
 
 func TestCircleOutlineOnly(t *testing.T) {
@@ -273,7 +274,7 @@ func TestCircleOutlineOnly(t *testing.T) {
 		t.Error("interior point (10, 14) should not be set in outline")
 	}
 
-	printVisual(t, "TestCircleOutlineOnly", c)
+	stippletest.PrintVisual(t, "TestCircleOutlineOnly", c)
 }
 
 func TestCircleFilledNegativeRadius(t *testing.T) {
@@ -300,7 +301,7 @@ func TestCircleFilledRadius0(t *testing.T) {
 		t.Errorf("expected 1 pixel for radius 0, got %d", count)
 	}
 
-	printVisual(t, "TestCircleFilledRadius0", c)
+	stippletest.PrintVisual(t, "TestCircleFilledRadius0", c)
 }
 
 func TestCircleFilledFloatCoordinates(t *testing.T) {
@@ -318,5 +319,70 @@ func TestCircleFilledFloatCoordinates(t *testing.T) {
 		t.Error("east edge (8, 6) not set with floored coordinates")
 	}
 
-	printVisual(t, "TestCircleFilledFloatCoordinates", c)
+	stippletest.PrintVisual(t, "TestCircleFilledFloatCoordinates", c)
+}
+
+func TestCircleColor(t *testing.T) {
+	expected := canvas.New(30, 30)
+	Circle(expected, 14, 14, 10)
+	actual := canvas.New(30, 30, canvas.WithColor())
+	CircleColor(actual, 14, 14, 10, canvas.ColorRed)
+	assertColored(t, expected, actual, canvas.ColorRed)
+}
+
+func TestCircleFilledColor(t *testing.T) {
+	expected := canvas.New(30, 30)
+	CircleFilled(expected, 14, 14, 10)
+	actual := canvas.New(30, 30, canvas.WithColor())
+	CircleFilledColor(actual, 14, 14, 10, canvas.ColorMagenta)
+	assertColored(t, expected, actual, canvas.ColorMagenta)
+}
+
+func TestCircleDepth(t *testing.T) {
+	// A near red outline stays visible over a farther green disc
+	c := canvas.New(30, 30, canvas.WithHalfBlock(), canvas.WithColor(), canvas.WithDepth())
+	CircleDepthColor(c, 14, 14, 10, 1, canvas.ColorRed)
+	CircleFilledDepthColor(c, 14, 14, 10, 2, canvas.ColorGreen)
+
+	expected := canvas.New(30, 30, canvas.WithHalfBlock())
+	CircleFilled(expected, 14, 14, 10)
+	if points := stippletest.Diff(expected, c); len(points) > 0 {
+		t.Errorf("pixels differ at %v\n%s", points, stippletest.Overlay(expected, c))
+	}
+
+	tests := []struct {
+		x, y     float64
+		color    canvas.Color
+		expected float64
+	}{
+		{14, 4, canvas.ColorRed, 1},
+		{24, 14, canvas.ColorRed, 1},
+		{14, 14, canvas.ColorGreen, 2},
+	}
+	for _, testCase := range tests {
+		if color := c.GetColor(testCase.x, testCase.y); color != testCase.color {
+			t.Errorf("GetColor(%v, %v) = %v, want %v", testCase.x, testCase.y, color, testCase.color)
+		}
+		if depth := c.Depth(testCase.x, testCase.y); depth != testCase.expected {
+			t.Errorf("Depth(%v, %v) = %v, want %v", testCase.x, testCase.y, depth, testCase.expected)
+		}
+	}
+
+	stippletest.PrintVisual(t, "TestCircleDepth", c)
+}
+
+func TestCircleDepthColor(t *testing.T) {
+	expected := canvas.New(30, 30)
+	Circle(expected, 14, 14, 10)
+	actual := canvas.New(30, 30, canvas.WithColor(), canvas.WithDepth())
+	CircleDepthColor(actual, 14, 14, 10, 1, canvas.ColorRed)
+	assertColored(t, expected, actual, canvas.ColorRed)
+}
+
+func TestCircleFilledDepthColor(t *testing.T) {
+	expected := canvas.New(30, 30)
+	CircleFilled(expected, 14, 14, 10)
+	actual := canvas.New(30, 30, canvas.WithColor(), canvas.WithDepth())
+	CircleFilledDepthColor(actual, 14, 14, 10, 1, canvas.ColorMagenta)
+	assertColored(t, expected, actual, canvas.ColorMagenta)
 }
@@ -0,0 +1,23 @@
+package draw
+
+// bayer4 is a 4x4 ordered-dither matrix with thresholds 0 to 15.
+var bayer4 = [4][4]float64{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+// DitherThreshold returns the ordered-dither threshold of pixel (x, y), between
+// 0 and 1. The thresholds repeat every 4 pixels in each direction, and pixels
+// with lower thresholds are lit first as intensity rises.
+func DitherThreshold(x, y int) float64 {
+	return (bayer4[y&3][x&3] + 0.5) / 16
+}
+
+// Dithered reports whether a pixel with the given intensity (0 to 1) is lit
+// by ordered dithering, so an area of constant intensity lights about that
+// fraction of its pixels in an even pattern.
+func Dithered(x, y int, intensity float64) bool {
+	return intensity > DitherThreshold(x, y)
+}
@@ -0,0 +1,42 @@
+package draw
+
+import "testing"
+
+func TestDithered(t *testing.T) {
+	tests := []struct {
+		name      string
+		intensity float64
+		expected  int
+	}{
+		{"zero", 0, 0},
+		{"quarter", 0.25, 4},
+		{"half", 0.5, 8},
+		{"full", 1, 16},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			lit := 0
+			for y := range 4 {
+				for x := range 4 {
+					if Dithered(x, y, testCase.intensity) {
+						lit++
+					}
+				}
+			}
+			if lit != testCase.expected {
+				t.Errorf("Dithered() lit %d of 16 pixels at %v, want %d", lit, testCase.intensity, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestDitheredTiles(t *testing.T) {
+	for y := range 4 {
+		for x := range 4 {
+			if Dithered(x, y, 0.4) != Dithered(x+4, y+8, 0.4) {
+				t.Errorf("Dithered(%d, %d) differs from the next tile", x, y)
+			}
+		}
+	}
+}
@@ -9,6 +9,71 @@ import (
 
 // Line draws a line from (startX, startY) to (endX, endY) using Bresenham's algorithm.
 func Line(c *canvas.Canvas, startX, startY, endX, endY float64) {
+	line(c.Set, startX, startY, endX, endY)
+}
+
+// LineColor draws a line like Line, setting every pixel with the given color.
+func LineColor(c *canvas.Canvas, startX, startY, endX, endY float64, color canvas.Color) {
+	line(colorPlotter(c, color), startX, startY, endX, endY)
+}
+
+// LineDepth draws a line like Line, depth testing each pixel with SetDepth.
+// The depth is interpolated linearly from startZ at the first pixel to endZ at
+// the last, so the canvas needs WithDepth for the line to be occluded.
+func LineDepth(c *canvas.Canvas, startX, startY, startZ, endX, endY, endZ float64) {
+	lineDepth(c.SetDepth, startX, startY, startZ, endX, endY, endZ)
+}
+
+// LineDepthColor draws a line like LineDepth, setting every visible pixel with the given color.
+func LineDepthColor(c *canvas.Canvas, startX, startY, startZ, endX, endY, endZ float64, color canvas.Color) {
+	lineDepth(func(x, y, z float64) bool {
+		return c.SetColorDepth(x, y, z, color)
+	}, startX, startY, startZ, endX, endY, endZ)
+}
+
+// colorPlotter returns a plot function that sets pixels on c with color.
+func colorPlotter(c *canvas.Canvas, color canvas.Color) func(x, y float64) {
+	return func(x, y float64) {
+		c.SetColor(x, y, color)
+	}
+}
+
+// depthPlotter returns a plot function that depth tests pixels on c at depth z.
+func depthPlotter(c *canvas.Canvas, z float64) func(x, y float64) {
+	return func(x, y float64) {
+		c.SetDepth(x, y, z)
+	}
+}
+
+// depthColorPlotter returns a plot function that depth tests pixels on c at
+// depth z and sets the visible ones with color.
+func depthColorPlotter(c *canvas.Canvas, z float64, color canvas.Color) func(x, y float64) {
+	return func(x, y float64) {
+		c.SetColorDepth(x, y, z, color)
+	}
+}
+
+// lineDepth plots the pixels of a line like line, passing each one a depth
+// interpolated between startZ and endZ by its position along the line.
+func lineDepth(plot func(x, y, z float64) bool, startX, startY, startZ, endX, endY, endZ float64) {
+	// The line visits one pixel per step along its major axis
+	steps := max(
+		math.Abs(math.Floor(endX)-math.Floor(startX)),
+		math.Abs(math.Floor(endY)-math.Floor(startY)),
+	)
+	step := 0.0
+	line(func(x, y float64) {
+		z := startZ
+		if steps > 0 {
+			z += (endZ - startZ) * step / steps
+		}
+		plot(x, y, z)
+		step++
+	}, startX, startY, endX, endY)
+}
+
+// line plots the pixels of a line from (startX, startY) to (endX, endY).
+func line(plot func(x, y float64), startX, startY, endX, endY float64) {
 	// Convert float coordinates to int using floor
 	x0 := int(math.Floor(startX))
 	y0 := int(math.Floor(startY))
@@ -52,7 +117,7 @@ func Line(c *canvas.Canvas, startX, startY, endX, endY float64) {
 	// Draw the line
 	x, y := x0, y0
 	for {
-		c.Set(float64(x), float64(y))
+		plot(float64(x), float64(y))
 
 		// Check if we've reached the end
 		if x == x1 && y == y1 {
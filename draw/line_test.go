@@ -1,9 +1,11 @@
 package draw
 
 import (
+	"math"
 	"testing"
 
 	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/stippletest"
 )
 
 func TestLineHorizontal(t *testing.T) {
@@ -25,7 +27,7 @@ func TestLineHorizontal(t *testing.T) {
 		t.Error("pixel (9, 1) should not be set")
 	}
 
-	printVisual(t, "TestLineHorizontal", c)
+	stippletest.PrintVisual(t, "TestLineHorizontal", c)
 }
 
 func TestLineVertical(t *testing.T) {
@@ -47,7 +49,7 @@ func TestLineVertical(t *testing.T) {
 		t.Error("pixel (1, 11) should not be set")
 	}
 
-	printVisual(t, "TestLineVertical", c)
+	stippletest.PrintVisual(t, "TestLineVertical", c)
 }
 
 func TestLineDiagonalPositive(t *testing.T) {
@@ -61,7 +63,7 @@ func TestLineDiagonalPositive(t *testing.T) {
 		}
 	}
 
-	printVisual(t, "TestLineDiagonalPositive", c)
+	stippletest.PrintVisual(t, "TestLineDiagonalPositive", c)
 }
 
 func TestLineDiagonalNegative(t *testing.T) {
@@ -75,7 +77,7 @@ func TestLineDiagonalNegative(t *testing.T) {
 		}
 	}
 
-	printVisual(t, "TestLineDiagonalNegative", c)
+	stippletest.PrintVisual(t, "TestLineDiagonalNegative", c)
 }
 
 func TestLineSymmetry(t *testing.T) {
@@ -97,8 +99,8 @@ func TestLineSymmetry(t *testing.T) {
 		}
 	}
 
-	printVisual(t, "TestLineSymmetry (forward)", c1)
-	printVisual(t, "TestLineSymmetry (reverse)", c2)
+	stippletest.PrintVisual(t, "TestLineSymmetry (forward)", c1)
+	stippletest.PrintVisual(t, "TestLineSymmetry (reverse)", c2)
 }
 
 func TestLineSinglePoint(t *testing.T) {
@@ -123,7 +125,7 @@ func TestLineSinglePoint(t *testing.T) {
 		t.Errorf("expected 1 pixel set, got %d", count)
 	}
 
-	printVisual(t, "TestLineSinglePoint", c)
+	stippletest.PrintVisual(t, "TestLineSinglePoint", c)
 }
 
 func TestLineShallowSlope(t *testing.T) {
@@ -154,7 +156,7 @@ func TestLineShallowSlope(t *testing.T) {
 		t.Errorf("expected %d pixels, got %d", expectedCount, count)
 	}
 
-	printVisual(t, "TestLineShallowSlope", c)
+	stippletest.PrintVisual(t, "TestLineShallowSlope", c)
 }
 
 func TestLineSteepSlope(t *testing.T) {
@@ -185,7 +187,7 @@ func TestLineSteepSlope(t *testing.T) {
 		t.Errorf("expected %d pixels, got %d", expectedCount, count)
 	}
 
-	printVisual(t, "TestLineSteepSlope", c)
+	stippletest.PrintVisual(t, "TestLineSteepSlope", c)
 }
 
 func TestLineReversedCoordinates(t *testing.T) {
@@ -209,7 +211,7 @@ func TestLineReversedCoordinates(t *testing.T) {
 			t.Errorf("%s: midpoint pixel (%d, %d) not set", testCase.name, testCase.checkX, testCase.checkY)
 		}
 
-		printVisual(t, "TestLineReversedCoordinates_"+testCase.name, c)
+		stippletest.PrintVisual(t, "TestLineReversedCoordinates_"+testCase.name, c)
 	}
 }
 
@@ -226,5 +228,53 @@ func TestLineFloatCoordinates(t *testing.T) {
 		t.Error("floored end point (5, 3) not set")
 	}
 
-	printVisual(t, "TestLineFloatCoordinates", c)
+	stippletest.PrintVisual(t, "TestLineFloatCoordinates", c)
+}
+
+func TestLineColor(t *testing.T) {
+	expected := canvas.New(20, 16)
+	Line(expected, 1, 14, 18, 2)
+	actual := canvas.New(20, 16, canvas.WithColor())
+	LineColor(actual, 1, 14, 18, 2, canvas.ColorGreen)
+	assertColored(t, expected, actual, canvas.ColorGreen)
+}
+
+func TestLineDepthInterpolates(t *testing.T) {
+	c := canvas.New(20, 4, canvas.WithDepth())
+	LineDepth(c, 0, 1, 0, 10, 1, 5)
+
+	for x := 0; x <= 10; x++ {
+		expected := float64(x) / 2
+		if depth := c.Depth(float64(x), 1); math.Abs(depth-expected) > 1e-9 {
+			t.Errorf("Depth(%d, 1) = %v, want %v", x, depth, expected)
+		}
+	}
+}
+
+func TestLineDepthOcclusion(t *testing.T) {
+	// A red wall at depth 2 covers x = 4..11; the green line recedes from
+	// depth 3 to 1, passing in front of the wall at x = 7.5
+	c := canvas.New(16, 8, canvas.WithHalfBlock(), canvas.WithColor(), canvas.WithDepth())
+	RectangleFilledDepthColor(c, 4, 0, 8, 8, 2, canvas.ColorRed)
+	LineDepthColor(c, 0, 3, 3, 15, 3, 1, canvas.ColorGreen)
+
+	for x := 0; x < 16; x++ {
+		expected := canvas.ColorGreen
+		if x >= 4 && x <= 7 {
+			expected = canvas.ColorRed
+		}
+		if color := c.GetColor(float64(x), 3); color != expected {
+			t.Errorf("GetColor(%d, 3) = %v, want %v", x, color, expected)
+		}
+	}
+
+	stippletest.PrintVisual(t, "TestLineDepthOcclusion", c)
+}
+
+func TestLineDepthColor(t *testing.T) {
+	expected := canvas.New(20, 16)
+	Line(expected, 1, 14, 18, 2)
+	actual := canvas.New(20, 16, canvas.WithColor(), canvas.WithDepth())
+	LineDepthColor(actual, 1, 14, 0, 18, 2, 1, canvas.ColorGreen)
+	assertColored(t, expected, actual, canvas.ColorGreen)
 }
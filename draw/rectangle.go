@@ -10,6 +10,27 @@ import (
 // The rectangle's top-left corner is at (x, y), extending to (x+width-1, y+height-1).
 // Width or height of 0 or negative draws nothing.
 func Rectangle(c *canvas.Canvas, x, y, width, height float64) {
+	rectangle(c.Set, x, y, width, height)
+}
+
+// RectangleColor draws a rectangle outline like Rectangle in the given color.
+func RectangleColor(c *canvas.Canvas, x, y, width, height float64, color canvas.Color) {
+	rectangle(colorPlotter(c, color), x, y, width, height)
+}
+
+// RectangleDepth draws a rectangle outline like Rectangle, depth testing every
+// pixel at depth z with SetDepth.
+func RectangleDepth(c *canvas.Canvas, x, y, width, height, z float64) {
+	rectangle(depthPlotter(c, z), x, y, width, height)
+}
+
+// RectangleDepthColor draws a rectangle outline like RectangleDepth in the given color.
+func RectangleDepthColor(c *canvas.Canvas, x, y, width, height, z float64, color canvas.Color) {
+	rectangle(depthColorPlotter(c, z, color), x, y, width, height)
+}
+
+// rectangle plots the outline of a rectangle.
+func rectangle(plot func(x, y float64), x, y, width, height float64) {
 	if width <= 0 || height <= 0 {
 		return
 	}
@@ -23,16 +44,37 @@ func Rectangle(c *canvas.Canvas, x, y, width, height float64) {
 	bottom := y + height - 1
 
 	// Draw four edges using Line
-	Line(c, x, y, right, y)           // Top edge
-	Line(c, right, y, right, bottom)  // Right edge
-	Line(c, right, bottom, x, bottom) // Bottom edge
-	Line(c, x, bottom, x, y)          // Left edge
+	line(plot, x, y, right, y)           // Top edge
+	line(plot, right, y, right, bottom)  // Right edge
+	line(plot, right, bottom, x, bottom) // Bottom edge
+	line(plot, x, bottom, x, y)          // Left edge
 }
 
 // RectangleFilled draws a filled rectangle from (x, y) with the given width and height.
 // The rectangle's top-left corner is at (x, y), extending to (x+width-1, y+height-1).
 // Width or height of 0 or negative draws nothing.
 func RectangleFilled(c *canvas.Canvas, x, y, width, height float64) {
+	rectangleFilled(c.Set, x, y, width, height)
+}
+
+// RectangleFilledColor draws a filled rectangle like RectangleFilled in the given color.
+func RectangleFilledColor(c *canvas.Canvas, x, y, width, height float64, color canvas.Color) {
+	rectangleFilled(colorPlotter(c, color), x, y, width, height)
+}
+
+// RectangleFilledDepth draws a filled rectangle like RectangleFilled, depth
+// testing every pixel at depth z with SetDepth.
+func RectangleFilledDepth(c *canvas.Canvas, x, y, width, height, z float64) {
+	rectangleFilled(depthPlotter(c, z), x, y, width, height)
+}
+
+// RectangleFilledDepthColor draws a filled rectangle like RectangleFilledDepth in the given color.
+func RectangleFilledDepthColor(c *canvas.Canvas, x, y, width, height, z float64, color canvas.Color) {
+	rectangleFilled(depthColorPlotter(c, z, color), x, y, width, height)
+}
+
+// rectangleFilled plots every pixel of a rectangle.
+func rectangleFilled(plot func(x, y float64), x, y, width, height float64) {
 	if width <= 0 || height <= 0 {
 		return
 	}
@@ -46,7 +88,7 @@ func RectangleFilled(c *canvas.Canvas, x, y, width, height float64) {
 	// Set all pixels in the rectangle
 	for pixelY := startY; pixelY <= endY; pixelY++ {
 		for pixelX := startX; pixelX <= endX; pixelX++ {
-			c.Set(float64(pixelX), float64(pixelY))
+			plot(float64(pixelX), float64(pixelY))
 		}
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/stippletest"
 )
 
 func TestRectangle(t *testing.T) {
@@ -45,7 +46,7 @@ func TestRectangle(t *testing.T) {
 		}
 	}
 
-	printVisual(t, "TestRectangle", c)
+	stippletest.PrintVisual(t, "TestRectangle", c)
 }
 
 func TestRectangleFilled(t *testing.T) {
@@ -87,7 +88,7 @@ func TestRectangleFilled(t *testing.T) {
 		}
 	}
 
-	printVisual(t, "TestRectangleFilled", c)
+	stippletest.PrintVisual(t, "TestRectangleFilled", c)
 }
 
 func TestRectangleZeroSize(t *testing.T) {
@@ -176,7 +177,7 @@ func TestRectanglePartiallyOffCanvas(t *testing.T) {
 		}
 	}
 
-	printVisual(t, "TestRectanglePartiallyOffCanvas", c)
+	stippletest.PrintVisual(t, "TestRectanglePartiallyOffCanvas", c)
 }
 
 func TestRectangleFilledPartiallyOffCanvas(t *testing.T) {
@@ -207,7 +208,7 @@ func TestRectangleFilledPartiallyOffCanvas(t *testing.T) {
 		}
 	}
 
-	printVisual(t, "TestRectangleFilledPartiallyOffCanvas", c)
+	stippletest.PrintVisual(t, "TestRectangleFilledPartiallyOffCanvas", c)
 }
 
 func TestRectangleSmall(t *testing.T) {
@@ -228,7 +229,7 @@ func TestRectangleSmall(t *testing.T) {
 	if count != 1 {
 		t.Errorf("1x1 rectangle: expected 1 pixel, got %d", count)
 	}
-	printVisual(t, "TestRectangleSmall 1x1", c1)
+	stippletest.PrintVisual(t, "TestRectangleSmall 1x1", c1)
 
 	// 2x2 outline: 4 corner pixels
 	c2 := canvas.New(10, 8)
@@ -250,7 +251,7 @@ func TestRectangleSmall(t *testing.T) {
 	if count != 4 {
 		t.Errorf("2x2 rectangle: expected 4 pixels, got %d", count)
 	}
-	printVisual(t, "TestRectangleSmall 2x2", c2)
+	stippletest.PrintVisual(t, "TestRectangleSmall 2x2", c2)
 }
 
 func TestRectangleFilledSmall(t *testing.T) {
@@ -271,7 +272,7 @@ func TestRectangleFilledSmall(t *testing.T) {
 	if count != 1 {
 		t.Errorf("1x1 filled: expected 1 pixel, got %d", count)
 	}
-	printVisual(t, "TestRectangleFilledSmall 1x1", c1)
+	stippletest.PrintVisual(t, "TestRectangleFilledSmall 1x1", c1)
 
 	// 2x2 filled: all 4 pixels
 	c2 := canvas.New(10, 8)
@@ -293,7 +294,7 @@ func TestRectangleFilledSmall(t *testing.T) {
 	if count != 4 {
 		t.Errorf("2x2 filled: expected 4 pixels, got %d", count)
 	}
-	printVisual(t, "TestRectangleFilledSmall 2x2", c2)
+	stippletest.PrintVisual(t, "TestRectangleFilledSmall 2x2", c2)
 }
 
 func TestRectangleFilledFloatCoordinates(t *testing.T) {
@@ -325,5 +326,86 @@ func TestRectangleFilledFloatCoordinates(t *testing.T) {
 		t.Errorf("expected 20 pixels, got %d", count)
 	}
 
-	printVisual(t, "TestRectangleFilledFloatCoordinates", c)
+	stippletest.PrintVisual(t, "TestRectangleFilledFloatCoordinates", c)
+}
+
+func TestRectangleHalfBlock(t *testing.T) {
+	// Drawing primitives work unchanged on half-block canvases
+	c := canvas.New(8, 6, canvas.WithHalfBlock())
+	Rectangle(c, 1, 1, 6, 4)
+
+	for x := 1; x <= 6; x++ {
+		if !c.Get(float64(x), 1) || !c.Get(float64(x), 4) {
+			t.Errorf("edge pixels in column %d not set", x)
+		}
+	}
+	if c.Get(3, 3) {
+		t.Error("interior pixel (3, 3) should not be set")
+	}
+
+	expected := " ▄▄▄▄▄▄ \n █    █ \n ▀▀▀▀▀▀ "
+	if frame := c.Frame(); frame != expected {
+		t.Errorf("Frame() =\n%s\nwant:\n%s", frame, expected)
+	}
+
+	stippletest.PrintVisual(t, "TestRectangleHalfBlock", c)
+}
+
+func TestRectangleColor(t *testing.T) {
+	expected := canvas.New(20, 16)
+	Rectangle(expected, 2, 2, 15, 10)
+	actual := canvas.New(20, 16, canvas.WithColor())
+	RectangleColor(actual, 2, 2, 15, 10, canvas.ColorCyan)
+	assertColored(t, expected, actual, canvas.ColorCyan)
+}
+
+func TestRectangleFilledColor(t *testing.T) {
+	expected := canvas.New(20, 16)
+	RectangleFilled(expected, 3, 1, 9, 12)
+	actual := canvas.New(20, 16, canvas.WithColor())
+	RectangleFilledColor(actual, 3, 1, 9, 12, canvas.ColorYellow)
+	assertColored(t, expected, actual, canvas.ColorYellow)
+}
+
+func TestRectangleDepth(t *testing.T) {
+	expected := canvas.New(20, 16)
+	RectangleFilled(expected, 6, 4, 8, 8)
+	Rectangle(expected, 2, 2, 15, 10)
+
+	// The outline is drawn behind the square, so only the square's depth is kept
+	// where they overlap
+	actual := canvas.New(20, 16, canvas.WithDepth())
+	RectangleFilledDepth(actual, 6, 4, 8, 8, 1)
+	RectangleDepth(actual, 2, 2, 15, 10, 2)
+	stippletest.AssertEqual(t, expected, actual)
+
+	tests := []struct {
+		x, y     float64
+		expected float64
+	}{
+		{6, 4, 1},
+		{2, 2, 2},
+		{13, 11, 1}, // outline pixel covered by the square
+	}
+	for _, testCase := range tests {
+		if depth := actual.Depth(testCase.x, testCase.y); depth != testCase.expected {
+			t.Errorf("Depth(%v, %v) = %v, want %v", testCase.x, testCase.y, depth, testCase.expected)
+		}
+	}
+}
+
+func TestRectangleDepthColor(t *testing.T) {
+	expected := canvas.New(20, 16)
+	Rectangle(expected, 2, 2, 15, 10)
+	actual := canvas.New(20, 16, canvas.WithColor(), canvas.WithDepth())
+	RectangleDepthColor(actual, 2, 2, 15, 10, 1, canvas.ColorCyan)
+	assertColored(t, expected, actual, canvas.ColorCyan)
+}
+
+func TestRectangleFilledDepthColor(t *testing.T) {
+	expected := canvas.New(20, 16)
+	RectangleFilled(expected, 3, 1, 9, 12)
+	actual := canvas.New(20, 16, canvas.WithColor(), canvas.WithDepth())
+	RectangleFilledDepthColor(actual, 3, 1, 9, 12, 1, canvas.ColorYellow)
+	assertColored(t, expected, actual, canvas.ColorYellow)
 }
@@ -2,55 +2,33 @@ package draw
 
 import (
 	"flag"
-	"os"
-	"path/filepath"
 	"testing"
 
 	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/stippletest"
 )
 
-var visualFlag = flag.Bool("visual", false, "print visual output")
-var updateFlag = flag.Bool("update", false, "update golden files")
-
-func TestMain(m *testing.M) {
-	flag.Parse()
-	os.Exit(m.Run())
-}
-
-func printVisual(t *testing.T, name string, c *canvas.Canvas) {
-	if *visualFlag {
-		t.Logf("\n=== %s ===\n%s", name, c.Frame())
-	}
-}
-
-func goldenPath(name string) string {
-	return filepath.Join("testdata", name+".golden")
-}
+// The -update flag rewrites golden files through stippletest.Update.
+var _ = flag.Bool("update", false, "update golden files")
 
 func assertGolden(t *testing.T, name string, c *canvas.Canvas) {
 	t.Helper()
+	stippletest.AssertGolden(t, name, c)
+}
 
-	actual := c.Frame()
-	path := goldenPath(name)
-
-	if *updateFlag {
-		if err := os.MkdirAll("testdata", 0755); err != nil {
-			t.Fatalf("failed to create testdata directory: %v", err)
-		}
-		if err := os.WriteFile(path, []byte(actual), 0644); err != nil {
-			t.Fatalf("failed to write golden file %s: %v", path, err)
-		}
-		t.Logf("updated golden file: %s", path)
-		return
-	}
-
-	expected, err := os.ReadFile(path)
-	if err != nil {
-		t.Fatalf("failed to read golden file %s (run with -update to create): %v", path, err)
+// assertColored checks that actual has the same pixels as expected and that
+// every cell with a lit pixel has the given color.
+func assertColored(t *testing.T, expected, actual *canvas.Canvas, color canvas.Color) {
+	t.Helper()
+	if points := stippletest.Diff(expected, actual); len(points) > 0 {
+		t.Errorf("pixels differ at %v\n%s", points, stippletest.Overlay(expected, actual))
 	}
-
-	if actual != string(expected) {
-		t.Errorf("output does not match golden file %s\n--- expected ---\n%s\n--- actual ---\n%s",
-			path, string(expected), actual)
+	for y := 0; y < actual.Height(); y++ {
+		for x := 0; x < actual.Width(); x++ {
+			if actual.Get(float64(x), float64(y)) && actual.GetColor(float64(x), float64(y)) != color {
+				t.Errorf("GetColor(%d, %d) = %v, want %v", x, y, actual.GetColor(float64(x), float64(y)), color)
+				return
+			}
+		}
 	}
 }
@@ -4,9 +4,21 @@ package main
 import (
 	"fmt"
 	"math"
+	"strings"
+	"time"
 
 	"github.com/cboone/stipple/canvas"
 	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/layout"
+	"github.com/cboone/stipple/lsystem"
+	"github.com/cboone/stipple/plot"
+	"github.com/cboone/stipple/raycast"
+	"github.com/cboone/stipple/scene"
+	"github.com/cboone/stipple/sprite"
+	"github.com/cboone/stipple/three"
+	"github.com/cboone/stipple/tilemap"
+	"github.com/cboone/stipple/turtle"
+	"github.com/cboone/stipple/widget"
 )
 
 func main() {
@@ -44,6 +56,25 @@ func main() {
 	demoColoredLines()
 	demoColoredRectangles()
 	demoColoredEyeball()
+	demoFallbackRenderers()
+	demoHalfBlockSprite()
+	demoScene()
+	demoLineChart()
+	demoScatter()
+	demoBarChart()
+	demoSparkline()
+	demoFunctionPlot()
+	demoHeatmap()
+	demoTurtle()
+	demoLSystem()
+	demoRaycast()
+	demoWireframe()
+	demoDepth()
+	demoSprites()
+	demoBillboards()
+	demoMinimap()
+	demoWidgets()
+	demoLayout()
 }
 
 func demoIndividualPixels() {
@@ -343,11 +374,10 @@ func demoColoredEyeball() {
 	}
 	// Blue iris (circle outline)
 	irisRadius := 5.0
-	for angle := 0.0; angle < 2*math.Pi; angle += 0.05 {
-		x := centerX + 2 + irisRadius*math.Cos(angle)
-		y := centerY - 1 + irisRadius*math.Sin(angle)
-		canvasDemo.SetColor(x, y, canvas.ColorBlue)
-	}
+	plot.ParametricColor(canvasDemo,
+		func(angle float64) float64 { return centerX + 2 + irisRadius*math.Cos(angle) },
+		func(angle float64) float64 { return centerY - 1 + irisRadius*math.Sin(angle) },
+		0, 2*math.Pi, canvas.ColorBlue)
 	// Black pupil (filled circle)
 	pupilCenterX, pupilCenterY := centerX+3, centerY-2
 	for y := 0; y < 28; y++ {
@@ -362,3 +392,562 @@ func demoColoredEyeball() {
 	}
 	fmt.Println(canvasDemo.Frame())
 }
+
+func demoFallbackRenderers() {
+	fmt.Println()
+	fmt.Println("25. Fallback renderers (same canvas, different glyphs):")
+	canvasDemo := canvas.New(30, 28)
+	draw.Circle(canvasDemo, 14, 13, 10)
+	draw.CircleFilled(canvasDemo, 16, 12, 4)
+	renderers := []struct {
+		name     string
+		renderer canvas.Renderer
+	}{
+		{"Half block", canvas.RendererHalfBlock},
+		{"Quadrant", canvas.RendererQuadrant},
+		{"Sextant", canvas.RendererSextant},
+		{"ASCII", canvas.RendererASCII},
+	}
+	for _, entry := range renderers {
+		fmt.Printf("   %s:\n", entry.name)
+		fmt.Println(canvasDemo.FrameWith(entry.renderer))
+	}
+}
+
+func demoHalfBlockSprite() {
+	fmt.Println()
+	fmt.Println("26. Half-block two-color mode:")
+	canvasDemo := canvas.New(16, 16, canvas.WithHalfBlock(), canvas.WithColor())
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			distanceX := float64(x) - 7.5
+			distanceY := float64(y) - 7.5
+			distance := math.Sqrt(distanceX*distanceX + distanceY*distanceY)
+			switch {
+			case distance <= 2:
+				canvasDemo.SetColor(float64(x), float64(y), canvas.ColorBlack)
+			case distance <= 4:
+				canvasDemo.SetColor(float64(x), float64(y), canvas.ColorBlue)
+			case distance <= 7.5:
+				canvasDemo.SetColor(float64(x), float64(y), canvas.ColorWhite)
+			}
+		}
+	}
+	fmt.Println(canvasDemo.Frame())
+}
+
+func demoScene() {
+	fmt.Println()
+	fmt.Println("27. Scene with dirty-region redraw (before and after moving the box):")
+	canvasDemo := canvas.New(40, 20)
+	sceneDemo := scene.New(canvasDemo)
+	sceneDemo.Add(scene.Line{StartX: 0, StartY: 19, EndX: 39, EndY: 0})
+	sceneDemo.Add(scene.Circle{CenterX: 30, CenterY: 10, Radius: 7})
+	box := sceneDemo.Add(scene.Rectangle{X: 2, Y: 2, Width: 12, Height: 8, Filled: true})
+	sceneDemo.Render()
+	fmt.Println(canvasDemo.Frame())
+	fmt.Println()
+
+	box.SetDrawable(scene.Rectangle{X: 14, Y: 6, Width: 12, Height: 8})
+	sceneDemo.Render()
+	fmt.Println(canvasDemo.Frame())
+}
+
+func demoLineChart() {
+	fmt.Println()
+	fmt.Println("28. Line chart with axes, tick labels, and legend:")
+	canvasDemo := canvas.NewCells(60, 14, canvas.WithColor())
+	chart := plot.New()
+	var xs, sines, cosines []float64
+	for index := 0; index <= 100; index++ {
+		x := float64(index) / 10
+		xs = append(xs, x)
+		sines = append(sines, 3*math.Sin(x))
+		cosines = append(cosines, 2*math.Cos(x))
+	}
+	chart.AddSeries(plot.Series{Name: "3 sin x", X: xs, Y: sines, Color: canvas.ColorCyan})
+	chart.AddSeries(plot.Series{Name: "2 cos x", X: xs, Y: cosines, Color: canvas.ColorMagenta})
+	chart.Draw(canvasDemo)
+	fmt.Println(canvasDemo.Frame())
+}
+
+func demoScatter() {
+	fmt.Println()
+	fmt.Println("29. Scatter plot with markers and density mode:")
+	canvasDemo := canvas.NewCells(60, 14, canvas.WithColor())
+	chart := plot.New()
+	var clusterX, clusterY []float64
+	for index := 0; index < 400; index++ {
+		angle := float64(index) * 2.399963
+		radius := math.Sqrt(float64(index)) / 8
+		clusterX = append(clusterX, 3+radius*math.Cos(angle))
+		clusterY = append(clusterY, 4+radius*math.Sin(angle))
+	}
+	chart.AddScatter(plot.Scatter{Name: "density", X: clusterX, Y: clusterY, Size: 2, Density: true, Color: canvas.ColorCyan})
+	chart.AddScatter(plot.Scatter{
+		Name:   "samples",
+		X:      []float64{6, 7, 8, 9},
+		Y:      []float64{2, 6, 3, 7},
+		Marker: plot.MarkerCircle,
+		Color:  canvas.ColorYellow,
+		Sizes:  []float64{2, 3, 4, 5},
+	})
+	chart.AddScatter(plot.Scatter{Name: "outliers", X: []float64{0.5, 9.5}, Y: []float64{7.5, 0.5}, Marker: plot.MarkerCross, Color: canvas.ColorRed})
+	chart.Draw(canvasDemo)
+	fmt.Println(canvasDemo.Frame())
+}
+
+func demoBarChart() {
+	fmt.Println()
+	fmt.Println("30. Stacked bar chart and histogram:")
+	canvasDemo := canvas.NewCells(60, 12, canvas.WithColor())
+	chart := plot.New()
+	chart.AddBars(plot.Bars{
+		Series: []plot.BarSeries{
+			{Name: "reads", Values: []float64{3, 5, 2, 6, 4}, Color: canvas.ColorGreen},
+			{Name: "writes", Values: []float64{2, 1, 3, 2, 3}, Color: canvas.ColorYellow},
+			{Name: "errors", Values: []float64{-1, -0.5, -2, 0, -1.5}, Color: canvas.ColorRed},
+		},
+		Stacked: true,
+	})
+	chart.Draw(canvasDemo)
+	fmt.Println(canvasDemo.Frame())
+
+	fmt.Println()
+	canvasDemo = canvas.NewCells(60, 12, canvas.WithColor())
+	chart = plot.New()
+	var samples []float64
+	for index := 0; index < 500; index++ {
+		// A sum of uniform-ish values approximates a normal distribution
+		sum := 0.0
+		for term := 1; term <= 4; term++ {
+			sum += math.Mod(float64(index*term)*0.618034, 1)
+		}
+		samples = append(samples, sum)
+	}
+	chart.AddHistogram(plot.Histogram{Name: "samples", Values: samples, Bins: 16, Color: canvas.ColorCyan})
+	chart.Draw(canvasDemo)
+	fmt.Println(canvasDemo.Frame())
+}
+
+func demoSparkline() {
+	fmt.Println()
+	fmt.Println("31. Sparklines (line and filled):")
+	cpu := plot.NewSparkline(80, plot.WithSparklineColor(canvas.ColorGreen))
+	memory := plot.NewSparkline(80, plot.WithFilled(), plot.WithSparklineColor(canvas.ColorMagenta))
+	for index := 0; index < 120; index++ {
+		step := float64(index)
+		cpu.Push(50 + 30*math.Sin(step/5) + 10*math.Sin(step*1.7))
+		memory.Push(40 + step/4 + 5*math.Sin(step/3))
+	}
+
+	canvasDemo := canvas.NewCells(40, 1, canvas.WithColor())
+	cpu.Draw(canvasDemo)
+	fmt.Println("   cpu    " + canvasDemo.Frame())
+	canvasDemo = canvas.NewCells(40, 2, canvas.WithColor())
+	memory.Draw(canvasDemo)
+	for index, line := range strings.Split(canvasDemo.Frame(), "\n") {
+		label := "          "
+		if index == 0 {
+			label = "   memory "
+		}
+		fmt.Println(label + line)
+	}
+}
+
+func demoFunctionPlot() {
+	fmt.Println()
+	fmt.Println("32. Function and parametric curves (inverted Y, tan x breaks at its asymptotes):")
+	canvasDemo := canvas.New(120, 48, canvas.WithColor(), canvas.WithInvertedY())
+	// Map x from 0..119 onto -pi..pi and y = tan x onto the canvas height
+	plot.FunctionColor(canvasDemo, func(x float64) float64 {
+		angle := (x/119)*2*math.Pi - math.Pi
+		return 24 + 6*math.Tan(angle)
+	}, 0, 119, canvas.ColorYellow)
+	plot.ParametricColor(canvasDemo,
+		func(t float64) float64 { return 60 + 50*math.Sin(3*t) },
+		func(t float64) float64 { return 24 + 20*math.Sin(2*t) },
+		0, 2*math.Pi, canvas.ColorCyan)
+	fmt.Println(canvasDemo.Frame())
+}
+
+func demoHeatmap() {
+	fmt.Println()
+	fmt.Println("33. Heatmap (truecolor viridis, 8-color diverging, and dot density):")
+	var values [][]float64
+	for row := 0; row < 12; row++ {
+		var line []float64
+		for column := 0; column < 24; column++ {
+			x, y := float64(column)/4-3, float64(row)/2-3
+			line = append(line, math.Sin(x)*math.Cos(y)+0.3*x)
+		}
+		values = append(values, line)
+	}
+
+	for _, heatmap := range []plot.Heatmap{
+		{Values: values, Interpolate: true, Colorbar: true},
+		{Values: values, Ramp: plot.RampBasicDiverging, Colorbar: true},
+		{Values: values, Interpolate: true, Density: true, Colorbar: true},
+	} {
+		canvasDemo := canvas.NewCells(48, 6, canvas.WithColor())
+		heatmap.Draw(canvasDemo)
+		fmt.Println(canvasDemo.Frame())
+		fmt.Println()
+	}
+}
+
+func demoTurtle() {
+	fmt.Println()
+	fmt.Println("34. Turtle graphics (a square spiral and a five-pointed star):")
+	canvasDemo := canvas.New(120, 48, canvas.WithColor())
+	pen := turtle.New(canvasDemo)
+	pen.PenUp()
+	pen.Goto(30, 24)
+	pen.PenDown()
+	pen.SetPenColor(canvas.ColorCyan)
+	for step := 1; step <= 20; step++ {
+		pen.Forward(float64(step) * 2)
+		pen.Left(90)
+	}
+
+	pen.PenUp()
+	pen.Goto(72, 30)
+	pen.SetHeading(0)
+	pen.PenDown()
+	pen.SetPenColor(canvas.ColorYellow)
+	for range 5 {
+		pen.Forward(36)
+		pen.Right(144)
+	}
+	fmt.Println(canvasDemo.Frame())
+}
+
+func demoLSystem() {
+	fmt.Println()
+	fmt.Println("35. L-systems (a stochastic fractal plant and a dragon curve, scaled to fit):")
+	plant := lsystem.System{
+		Axiom: "X",
+		Rules: []lsystem.Rule{
+			{Symbol: 'X', Successor: "F+[[X]-X]-F[-FX]+X", Weight: 2},
+			{Symbol: 'X', Successor: "F-[[X]+X]+F[+FX]-X", Weight: 1},
+			{Symbol: 'F', Successor: "FF"},
+		},
+		Angle:   25,
+		Heading: 80,
+		Seed:    3,
+	}
+	plantCanvas := canvas.New(60, 64, canvas.WithColor())
+	if err := plant.DrawColor(plantCanvas, 5, canvas.ColorGreen); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	dragon := lsystem.System{
+		Axiom: "F",
+		Rules: []lsystem.Rule{
+			{Symbol: 'F', Successor: "F+G"},
+			{Symbol: 'G', Successor: "F-G"},
+		},
+		Angle: 90,
+	}
+	dragonCanvas := canvas.New(60, 64, canvas.WithColor())
+	if err := dragon.DrawColor(dragonCanvas, 8, canvas.ColorRed); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	plantLines := strings.Split(plantCanvas.Frame(), "\n")
+	dragonLines := strings.Split(dragonCanvas.Frame(), "\n")
+	for index := range plantLines {
+		fmt.Println(plantLines[index] + "  " + dragonLines[index])
+	}
+}
+
+func demoRaycast() {
+	fmt.Println()
+	fmt.Println("36. Raycast maze (distance shading, colors by side, and an occluded sprite):")
+	view := mazeView()
+	canvasDemo := canvas.New(120, 48, canvas.WithColor())
+	depth := view.Render(canvasDemo)
+
+	// A glowing orb floating in the corridor, drawn only where no wall is nearer
+	if orb, ok := view.Project(canvasDemo, 3.6, 6.8); ok {
+		radius := orb.Scale / 6
+		centerY := float64(canvasDemo.Height()) / 2
+		for x := int(orb.Column - radius); x <= int(orb.Column+radius); x++ {
+			if !depth.Visible(x, orb.Distance) {
+				continue
+			}
+			for y := int(centerY - radius); y <= int(centerY+radius); y++ {
+				if math.Hypot(float64(x)-orb.Column, float64(y)-centerY) <= radius {
+					canvasDemo.SetColor(float64(x), float64(y), canvas.ColorYellow)
+				}
+			}
+		}
+	}
+	fmt.Println(canvasDemo.Frame())
+}
+
+func demoWireframe() {
+	fmt.Println()
+	fmt.Println("37. 3D wireframes (all edges, backface culling, and hidden-line removal):")
+	camera := three.Camera{
+		Position:    three.Vec3{X: 2.5, Y: 2, Z: 4},
+		Target:      three.Vec3{Y: -0.3, Z: 0.6},
+		FieldOfView: math.Pi / 3,
+	}
+	// A cube with a smaller cube resting in front of it
+	big := three.Cube(2).Transform(three.RotationY(0.4))
+	small := three.Cube(0.8).Transform(three.Translation(0.6, -0.6, 2))
+
+	var frames []string
+	for _, options := range [][]three.Option{
+		nil,
+		{three.WithBackfaceCulling()},
+		{three.WithBackfaceCulling(), three.WithHiddenLineRemoval()},
+	} {
+		canvasDemo := canvas.New(56, 56, canvas.WithColor(), canvas.WithDepth())
+		renderer := three.New(canvasDemo, camera, options...)
+		// The nearer mesh goes first so it hides what is behind it
+		renderer.DrawColor(small, canvas.ColorYellow)
+		renderer.DrawColor(big, canvas.ColorCyan)
+		frames = append(frames, canvasDemo.Frame())
+	}
+
+	lines := make([][]string, len(frames))
+	for index, frame := range frames {
+		lines[index] = strings.Split(frame, "\n")
+	}
+	for row := range lines[0] {
+		fmt.Println(lines[0][row] + "  " + lines[1][row] + "  " + lines[2][row])
+	}
+}
+
+func demoDepth() {
+	fmt.Println()
+	fmt.Println("38. Depth buffer (a line piercing a disc and a panel, drawn in any order):")
+	canvasDemo := canvas.New(60, 24, canvas.WithHalfBlock(), canvas.WithColor(), canvas.WithDepth())
+	draw.CircleFilledDepthColor(canvasDemo, 20, 12, 9, 2, canvas.ColorBlue)
+	draw.LineDepthColor(canvasDemo, 2, 20, 1, 57, 3, 4, canvas.ColorYellow)
+	draw.RectangleFilledDepthColor(canvasDemo, 24, 4, 28, 16, 3, canvas.ColorRed)
+	fmt.Println(canvasDemo.Frame())
+}
+
+// mazeView returns the camera in the maze used by the raycasting demos.
+func mazeView() raycast.View {
+	maze := raycast.NewMap(
+		"##########",
+		"#........#",
+		"#.##.###.#",
+		"#.#....#.#",
+		"#.#.##.#.#",
+		"#...#....#",
+		"###.#.##.#",
+		"#........#",
+		"##########",
+	)
+	return raycast.View{
+		Map:   maze,
+		X:     1.5,
+		Y:     7.5,
+		Angle: 0,
+		Shade: true,
+		Colors: func(wall int, side raycast.Side) canvas.Color {
+			if side == raycast.EastWest {
+				return canvas.ColorCyan
+			}
+			return canvas.ColorBlue
+		},
+	}
+}
+
+// eyeballSheet is a blinking eyeball in sprite sheet text format.
+const eyeballSheet = `
+sprite eyeball
+frame 600ms
+....########....
+..##wwwwwwww##..
+.#wwwwbbbbwwwww#
+#wwwwbbkkbbwwww#
+#wwwwbbkkbbwwww#
+.#wwwwbbbbwwwww#
+..##wwwwwwww##..
+....########....
+frame 120ms
+................
+................
+..##########....
+.#wwwwbbbbwwww#.
+#wwwwbbkkbbwwww#
+.##wwwwwwwwww##.
+...##########...
+................
+frame 120ms
+................
+................
+................
+................
+################
+..#..#..#..#..#.
+................
+................
+`
+
+func demoSprites() {
+	fmt.Println()
+	fmt.Println("39. Sprites (a blinking eyeball at 0ms, 650ms, and 750ms, then flipped):")
+	eyeball, err := sprite.Parse(eyeballSheet)
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	canvasDemo := canvas.New(80, 8, canvas.WithHalfBlock(), canvas.WithColor())
+	for index, elapsed := range []time.Duration{0, 650 * time.Millisecond, 750 * time.Millisecond} {
+		eyeball.Draw(canvasDemo, float64(index*20), 0, elapsed)
+	}
+	eyeball.FlipHorizontal().Draw(canvasDemo, 62, 0, 0)
+	fmt.Println(canvasDemo.Frame())
+}
+
+func demoBillboards() {
+	fmt.Println()
+	fmt.Println("40. Billboard sprites (scaled eyeballs clipped by walls, and a rotated one):")
+	eyeball, err := sprite.Parse(eyeballSheet)
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	open := eyeball.Frames[0]
+
+	view := mazeView()
+	canvasDemo := canvas.New(120, 48, canvas.WithColor())
+	depth := view.Render(canvasDemo)
+
+	// Farther eyeballs first; each is clipped to the columns where it is in front of the wall
+	for _, position := range [][2]float64{{7.5, 7.5}, {3.6, 6.8}} {
+		projection, ok := view.Project(canvasDemo, position[0], position[1])
+		if !ok {
+			continue
+		}
+		height := projection.Scale / 3
+		width := height * float64(open.Width()) / float64(open.Height())
+		top := float64(canvasDemo.Height())/2 + projection.Scale/2 - height
+		sprite.DrawScaled(canvasDemo, open, projection.Column-width/2, top, width, height,
+			sprite.WithOcclusion(projection.Distance, depth.Visible))
+	}
+	fmt.Println(canvasDemo.Frame())
+	fmt.Println()
+
+	spinning := canvas.New(96, 24, canvas.WithHalfBlock(), canvas.WithColor())
+	for index := range 4 {
+		sprite.DrawRotated(spinning, open, float64(12+index*24), 12, float64(index)*math.Pi/4)
+	}
+	fmt.Println(spinning.Frame())
+}
+
+func demoMinimap() {
+	fmt.Println()
+	fmt.Println("41. Tile map minimap (zoom 2 over the maze view, then whole cells per tile):")
+	view := mazeView()
+	canvasDemo := canvas.New(120, 48, canvas.WithColor())
+	view.Render(canvasDemo)
+
+	minimap := tilemap.View{
+		Map:      view.Map,
+		Tiles:    map[int]tilemap.Tile{1: tilemap.Solid(canvas.ColorWhite)},
+		Viewport: canvas.Rect{X: 96, Y: 0, Width: 24, Height: 20},
+		Zoom:     2,
+		X:        view.X,
+		Y:        view.Y,
+		Clamp:    true,
+		Markers:  []tilemap.Marker{{X: view.X, Y: view.Y, Angle: view.Angle, Color: canvas.ColorYellow}},
+	}
+	minimap.Render(canvasDemo)
+	draw.RectangleColor(canvasDemo, 94, 0, 26, 21, canvas.ColorWhite)
+	fmt.Println(canvasDemo.Frame())
+	fmt.Println()
+
+	// One half-block cell per tile, scrolled to follow the player
+	cells := canvas.NewCells(20, 6, canvas.WithHalfBlock(), canvas.WithColor())
+	minimap.Viewport = canvas.Rect{}
+	minimap.Zoom = tilemap.ZoomCell
+	minimap.Tiles[1] = tilemap.Solid(canvas.ColorBlue)
+	minimap.Render(cells)
+	fmt.Println(cells.Frame())
+}
+
+func demoWidgets() {
+	fmt.Println()
+	fmt.Println("42. HUD widgets (panels holding gauges, a meter, and progress bars):")
+	canvasDemo := canvas.NewCells(40, 10, canvas.WithColor())
+
+	health := widget.Panel{
+		Title:   "Health",
+		Border:  widget.BorderRounded,
+		Color:   canvas.ColorRed,
+		Content: widget.Gauge{Value: 73, Max: 100, Color: canvas.ColorRed, Label: "73 / 100"},
+	}
+	health.Draw(canvasDemo, canvas.Rect{X: 0, Y: 0, Width: 40, Height: 12})
+
+	signal := widget.Panel{
+		Title:  "Signal",
+		Border: widget.BorderBraille,
+		Color:  canvas.ColorCyan,
+		Content: widget.Meter{
+			Value:      0.8,
+			Color:      canvas.ColorGreen,
+			Thresholds: []widget.Threshold{{At: 0.6, Color: canvas.ColorYellow}, {At: 0.85, Color: canvas.ColorRed}},
+		},
+	}
+	signal.Draw(canvasDemo, canvas.Rect{X: 40, Y: 0, Width: 40, Height: 12})
+
+	fuel := widget.Panel{Border: widget.BorderDouble, Title: "Fuel", Content: widget.Gauge{Value: 0.35, Orientation: widget.Vertical, Color: canvas.ColorYellow}}
+	fuel.Draw(canvasDemo, canvas.Rect{X: 0, Y: 12, Width: 16, Height: 28})
+
+	tasks := widget.Panel{Title: "Loading", Border: widget.BorderLine}
+	tasks.Draw(canvasDemo, canvas.Rect{X: 16, Y: 12, Width: 64, Height: 28})
+	inner := tasks.Inner(canvasDemo, canvas.Rect{X: 16, Y: 12, Width: 64, Height: 28})
+	for index, progress := range []float64{1, 0.62, 0.18} {
+		bar := widget.ProgressBar{Progress: progress, Label: fmt.Sprintf("Level %d", index+1), Color: canvas.ColorGreen}
+		bar.Draw(canvasDemo, canvas.Rect{X: inner.X, Y: inner.Y + float64(index)*8, Width: inner.Width, Height: 4})
+	}
+	fmt.Println(canvasDemo.Frame())
+}
+
+func demoLayout() {
+	fmt.Println()
+	fmt.Println("43. Layout (3D view and minimap side by side over a status bar, each drawn in a sub-canvas):")
+	root := layout.Layout{
+		Direction: layout.Vertical,
+		Children: []layout.Layout{
+			{Children: []layout.Layout{
+				{Name: "view"},
+				{Name: "minimap", Size: layout.Percent(30)},
+			}},
+			{Name: "status", Size: layout.Fixed(1)},
+		},
+	}
+	regions := root.Compute(60, 12)
+	canvasDemo := canvas.NewCells(60, 12, canvas.WithColor())
+
+	view := mazeView()
+	view.Render(regions["view"].Sub(canvasDemo))
+
+	minimap := widget.Panel{Title: "Map", Border: widget.BorderRounded, Color: canvas.ColorCyan}
+	bounds := regions["minimap"].Rect(canvasDemo)
+	minimap.Draw(canvasDemo, bounds)
+	tiles := tilemap.View{
+		Map:     view.Map,
+		Tiles:   map[int]tilemap.Tile{1: tilemap.Solid(canvas.ColorWhite)},
+		Zoom:    2,
+		X:       view.X,
+		Y:       view.Y,
+		Clamp:   true,
+		Markers: []tilemap.Marker{{X: view.X, Y: view.Y, Angle: view.Angle, Color: canvas.ColorYellow}},
+	}
+	tiles.Render(canvasDemo.Sub(minimap.Inner(canvasDemo, bounds)))
+
+	status := regions["status"]
+	widget.ProgressBar{Progress: 0.4, Label: "Explored", Color: canvas.ColorGreen}.Draw(canvasDemo, status.Rect(canvasDemo))
+	fmt.Println(canvasDemo.Frame())
+}
@@ -0,0 +1,220 @@
+package input
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// escape is the byte that starts every escape sequence.
+const escape = 0x1b
+
+// tildeKeys maps the first parameter of "ESC [ n ~" sequences to keys.
+var tildeKeys = map[int]Key{
+	1:  KeyHome,
+	2:  KeyInsert,
+	3:  KeyDelete,
+	4:  KeyEnd,
+	5:  KeyPageUp,
+	6:  KeyPageDown,
+	7:  KeyHome,
+	8:  KeyEnd,
+	11: KeyF1,
+	12: KeyF2,
+	13: KeyF3,
+	14: KeyF4,
+	15: KeyF5,
+	17: KeyF6,
+	18: KeyF7,
+	19: KeyF8,
+	20: KeyF9,
+	21: KeyF10,
+	23: KeyF11,
+	24: KeyF12,
+}
+
+// letterKeys maps the final byte of "ESC [ X" and "ESC O X" sequences to keys.
+var letterKeys = map[byte]Key{
+	'A': KeyUp,
+	'B': KeyDown,
+	'C': KeyRight,
+	'D': KeyLeft,
+	'F': KeyEnd,
+	'H': KeyHome,
+	'P': KeyF1,
+	'Q': KeyF2,
+	'R': KeyF3,
+	'S': KeyF4,
+}
+
+// decode reads one event from the start of data.
+// It returns the event (nil for recognized but ignored or unknown sequences),
+// the number of bytes consumed, and complete = false when data ends in the
+// middle of an event and more bytes are needed.
+// A lone escape byte at the end of data is decoded as KeyEscape, and an
+// escape followed only by '[' or 'O' as that rune with ModAlt.
+func decode(data []byte) (event Event, length int, complete bool) {
+	if len(data) == 0 {
+		return nil, 0, false
+	}
+
+	switch first := data[0]; {
+	case first == escape:
+		return decodeEscape(data)
+	case first == '\r' || first == '\n':
+		return KeyEvent{Key: KeyEnter}, 1, true
+	case first == '\t':
+		return KeyEvent{Key: KeyTab}, 1, true
+	case first == 0x7f || first == 0x08:
+		return KeyEvent{Key: KeyBackspace}, 1, true
+	case first == 0x00:
+		return KeyEvent{Key: KeyRune, Rune: ' ', Modifiers: ModCtrl}, 1, true
+	case first < 0x1b:
+		return KeyEvent{Key: KeyRune, Rune: rune('a' + first - 1), Modifiers: ModCtrl}, 1, true
+	case first < 0x20:
+		return KeyEvent{Key: KeyRune, Rune: rune('\\' + first - 0x1c), Modifiers: ModCtrl}, 1, true
+	}
+
+	if !utf8.FullRune(data) {
+		return nil, 0, false
+	}
+	character, size := utf8.DecodeRune(data)
+	return KeyEvent{Key: KeyRune, Rune: character}, size, true
+}
+
+// decodeEscape decodes data that starts with an escape byte.
+func decodeEscape(data []byte) (Event, int, bool) {
+	if len(data) == 1 {
+		return KeyEvent{Key: KeyEscape}, 1, true
+	}
+
+	// Terminals send sequences in one write, so "ESC [" or "ESC O" at the end
+	// of data is Alt with that key rather than the start of a sequence
+	switch introducer := data[1]; {
+	case len(data) == 2 && (introducer == '[' || introducer == 'O'):
+	case introducer == '[':
+		return decodeCSI(data)
+	case introducer == 'O':
+		if key, ok := letterKeys[data[2]]; ok {
+			return KeyEvent{Key: key}, 3, true
+		}
+		return nil, 3, true
+	case introducer == escape:
+		return KeyEvent{Key: KeyEscape}, 1, true
+	}
+
+	// Escape followed by a key is that key with Alt held
+	event, length, complete := decode(data[1:])
+	if !complete {
+		return nil, 0, false
+	}
+	if key, ok := event.(KeyEvent); ok {
+		key.Modifiers |= ModAlt
+		return key, length + 1, true
+	}
+	return event, length + 1, true
+}
+
+// decodeCSI decodes a control sequence: "ESC [" parameters and a final byte.
+func decodeCSI(data []byte) (Event, int, bool) {
+	end := -1
+	for index := 2; index < len(data); index++ {
+		if data[index] >= 0x40 && data[index] <= 0x7e {
+			end = index
+			break
+		}
+	}
+	if end == -1 {
+		return nil, 0, false
+	}
+	length := end + 1
+	final := data[end]
+	parameters := string(data[2:end])
+
+	if strings.HasPrefix(parameters, "<") && (final == 'M' || final == 'm') {
+		event, ok := decodeSGRMouse(parameters[1:], final)
+		if !ok {
+			return nil, length, true
+		}
+		return event, length, true
+	}
+
+	values := parseParameters(parameters)
+	modifiers := Modifier(0)
+	if len(values) >= 2 && values[1] > 1 {
+		modifiers = Modifier(values[1] - 1)
+	}
+
+	switch final {
+	case '~':
+		if len(values) > 0 {
+			if key, ok := tildeKeys[values[0]]; ok {
+				return KeyEvent{Key: key, Modifiers: modifiers}, length, true
+			}
+		}
+	case 'Z':
+		return KeyEvent{Key: KeyTab, Modifiers: ModShift}, length, true
+	default:
+		if key, ok := letterKeys[final]; ok {
+			return KeyEvent{Key: key, Modifiers: modifiers}, length, true
+		}
+	}
+	return nil, length, true
+}
+
+// decodeSGRMouse decodes the "b;x;y" parameters of an SGR 1006 mouse report.
+// The final byte is 'M' for presses and motion and 'm' for releases.
+func decodeSGRMouse(parameters string, final byte) (MouseEvent, bool) {
+	values := parseParameters(parameters)
+	if len(values) != 3 || values[0] < 0 || values[1] < 1 || values[2] < 1 {
+		return MouseEvent{}, false
+	}
+	code := values[0]
+
+	event := MouseEvent{
+		Action: MousePress,
+		Column: values[1] - 1,
+		Row:    values[2] - 1,
+	}
+	if code&4 != 0 {
+		event.Modifiers |= ModShift
+	}
+	if code&8 != 0 {
+		event.Modifiers |= ModAlt
+	}
+	if code&16 != 0 {
+		event.Modifiers |= ModCtrl
+	}
+
+	button := code & 3
+	switch {
+	case code&64 != 0:
+		event.Button = MouseWheelUp + MouseButton(button)
+	case button == 3:
+		event.Button = MouseNone
+	default:
+		event.Button = MouseLeft + MouseButton(button)
+	}
+
+	switch {
+	case final == 'm':
+		event.Action = MouseRelease
+	case code&32 != 0:
+		event.Action = MouseMotion
+	}
+	return event, true
+}
+
+// parseParameters splits semicolon-separated numeric parameters.
+// Empty or invalid parameters are returned as 0.
+func parseParameters(parameters string) []int {
+	if parameters == "" {
+		return nil
+	}
+	fields := strings.Split(parameters, ";")
+	values := make([]int, len(fields))
+	for index, field := range fields {
+		values[index], _ = strconv.Atoi(field)
+	}
+	return values
+}
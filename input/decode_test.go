@@ -0,0 +1,126 @@
+package input
+
+import "testing"
+
+func TestDecodeKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected KeyEvent
+	}{
+		{"letter", "a", KeyEvent{Key: KeyRune, Rune: 'a'}},
+		{"uppercase", "Q", KeyEvent{Key: KeyRune, Rune: 'Q'}},
+		{"multi-byte rune", "é", KeyEvent{Key: KeyRune, Rune: 'é'}},
+		{"braille rune", "⣿", KeyEvent{Key: KeyRune, Rune: '⣿'}},
+		{"enter", "\r", KeyEvent{Key: KeyEnter}},
+		{"newline", "\n", KeyEvent{Key: KeyEnter}},
+		{"tab", "\t", KeyEvent{Key: KeyTab}},
+		{"backspace", "\x7f", KeyEvent{Key: KeyBackspace}},
+		{"ctrl-h backspace", "\x08", KeyEvent{Key: KeyBackspace}},
+		{"escape", "\x1b", KeyEvent{Key: KeyEscape}},
+		{"ctrl-c", "\x03", KeyEvent{Key: KeyRune, Rune: 'c', Modifiers: ModCtrl}},
+		{"ctrl-space", "\x00", KeyEvent{Key: KeyRune, Rune: ' ', Modifiers: ModCtrl}},
+		{"ctrl-backslash", "\x1c", KeyEvent{Key: KeyRune, Rune: '\\', Modifiers: ModCtrl}},
+		{"alt-x", "\x1bx", KeyEvent{Key: KeyRune, Rune: 'x', Modifiers: ModAlt}},
+		{"alt-ctrl-a", "\x1b\x01", KeyEvent{Key: KeyRune, Rune: 'a', Modifiers: ModAlt | ModCtrl}},
+		{"alt-bracket", "\x1b[", KeyEvent{Key: KeyRune, Rune: '[', Modifiers: ModAlt}},
+		{"alt-o", "\x1bO", KeyEvent{Key: KeyRune, Rune: 'O', Modifiers: ModAlt}},
+		{"up", "\x1b[A", KeyEvent{Key: KeyUp}},
+		{"down", "\x1b[B", KeyEvent{Key: KeyDown}},
+		{"right", "\x1b[C", KeyEvent{Key: KeyRight}},
+		{"left", "\x1b[D", KeyEvent{Key: KeyLeft}},
+		{"application up", "\x1bOA", KeyEvent{Key: KeyUp}},
+		{"home", "\x1b[H", KeyEvent{Key: KeyHome}},
+		{"end", "\x1b[F", KeyEvent{Key: KeyEnd}},
+		{"home tilde", "\x1b[1~", KeyEvent{Key: KeyHome}},
+		{"insert", "\x1b[2~", KeyEvent{Key: KeyInsert}},
+		{"delete", "\x1b[3~", KeyEvent{Key: KeyDelete}},
+		{"page up", "\x1b[5~", KeyEvent{Key: KeyPageUp}},
+		{"page down", "\x1b[6~", KeyEvent{Key: KeyPageDown}},
+		{"f1", "\x1bOP", KeyEvent{Key: KeyF1}},
+		{"f4", "\x1bOS", KeyEvent{Key: KeyF4}},
+		{"f5", "\x1b[15~", KeyEvent{Key: KeyF5}},
+		{"f12", "\x1b[24~", KeyEvent{Key: KeyF12}},
+		{"shift-tab", "\x1b[Z", KeyEvent{Key: KeyTab, Modifiers: ModShift}},
+		{"ctrl-up", "\x1b[1;5A", KeyEvent{Key: KeyUp, Modifiers: ModCtrl}},
+		{"shift-right", "\x1b[1;2C", KeyEvent{Key: KeyRight, Modifiers: ModShift}},
+		{"ctrl-alt-shift-left", "\x1b[1;8D", KeyEvent{Key: KeyLeft, Modifiers: ModCtrl | ModAlt | ModShift}},
+		{"alt-delete", "\x1b[3;3~", KeyEvent{Key: KeyDelete, Modifiers: ModAlt}},
+		{"ctrl-f1", "\x1b[1;5P", KeyEvent{Key: KeyF1, Modifiers: ModCtrl}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			event, length, complete := decode([]byte(testCase.data))
+			if !complete {
+				t.Fatalf("decode(%q) incomplete", testCase.data)
+			}
+			if length != len(testCase.data) {
+				t.Errorf("decode(%q) length = %d, want %d", testCase.data, length, len(testCase.data))
+			}
+			if event != testCase.expected {
+				t.Errorf("decode(%q) = %#v, want %#v", testCase.data, event, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestDecodeMouse(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected MouseEvent
+	}{
+		{"left press", "\x1b[<0;1;1M", MouseEvent{Action: MousePress, Button: MouseLeft, Column: 0, Row: 0}},
+		{"middle press", "\x1b[<1;5;3M", MouseEvent{Action: MousePress, Button: MouseMiddle, Column: 4, Row: 2}},
+		{"right release", "\x1b[<2;10;20m", MouseEvent{Action: MouseRelease, Button: MouseRight, Column: 9, Row: 19}},
+		{"left drag", "\x1b[<32;3;4M", MouseEvent{Action: MouseMotion, Button: MouseLeft, Column: 2, Row: 3}},
+		{"motion without button", "\x1b[<35;3;4M", MouseEvent{Action: MouseMotion, Button: MouseNone, Column: 2, Row: 3}},
+		{"wheel up", "\x1b[<64;7;8M", MouseEvent{Action: MousePress, Button: MouseWheelUp, Column: 6, Row: 7}},
+		{"wheel down", "\x1b[<65;7;8M", MouseEvent{Action: MousePress, Button: MouseWheelDown, Column: 6, Row: 7}},
+		{"ctrl shift click", "\x1b[<20;2;2M",
+			MouseEvent{Action: MousePress, Button: MouseLeft, Column: 1, Row: 1, Modifiers: ModCtrl | ModShift}},
+		{"alt click", "\x1b[<8;2;2M",
+			MouseEvent{Action: MousePress, Button: MouseLeft, Column: 1, Row: 1, Modifiers: ModAlt}},
+		{"large coordinates", "\x1b[<0;300;120M", MouseEvent{Action: MousePress, Button: MouseLeft, Column: 299, Row: 119}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			event, length, complete := decode([]byte(testCase.data))
+			if !complete || length != len(testCase.data) {
+				t.Fatalf("decode(%q) = length %d, complete %v", testCase.data, length, complete)
+			}
+			if event != testCase.expected {
+				t.Errorf("decode(%q) = %#v, want %#v", testCase.data, event, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestDecodeIncomplete(t *testing.T) {
+	for _, data := range []string{"\x1b[1", "\x1b[1;5", "\x1b[<0;1;", "\xe2\xa3"} {
+		if _, _, complete := decode([]byte(data)); complete {
+			t.Errorf("decode(%q) complete, want incomplete", data)
+		}
+	}
+}
+
+func TestDecodeUnknownSequence(t *testing.T) {
+	for _, data := range []string{"\x1b[99~", "\x1b[?1u", "\x1bOz", "\x1b[<0;0;1M"} {
+		event, length, complete := decode([]byte(data))
+		if !complete || length != len(data) {
+			t.Errorf("decode(%q) = length %d, complete %v, want whole sequence consumed", data, length, complete)
+		}
+		if event != nil {
+			t.Errorf("decode(%q) = %#v, want nil", data, event)
+		}
+	}
+}
+
+func TestDecodeConsumesOnlyFirstEvent(t *testing.T) {
+	event, length, _ := decode([]byte("\x1b[Ab"))
+	if event != (KeyEvent{Key: KeyUp}) || length != 3 {
+		t.Errorf("decode() = %#v, length %d, want Up with length 3", event, length)
+	}
+}
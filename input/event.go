@@ -0,0 +1,183 @@
+// Package input decodes raw-mode terminal input into key and mouse events.
+//
+// Keys are decoded from plain bytes, UTF-8 runes, and the common VT and xterm
+// escape sequences, including modifier parameters. Mouse events are decoded
+// from SGR 1006 reports (enabled with term.Terminal.EnableMouse) and can be
+// translated into canvas pixel coordinates.
+package input
+
+import (
+	"strings"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// Event is a decoded input event: a KeyEvent or a MouseEvent.
+type Event interface {
+	isEvent()
+}
+
+// Key identifies a key. Printable characters use KeyRune with KeyEvent.Rune set.
+type Key uint8
+
+// Keys (grouped, with KeyRune at 0).
+const (
+	KeyRune Key = iota
+	KeyBackspace
+	KeyDelete
+	KeyDown
+	KeyEnd
+	KeyEnter
+	KeyEscape
+	KeyHome
+	KeyInsert
+	KeyLeft
+	KeyPageDown
+	KeyPageUp
+	KeyRight
+	KeyTab
+	KeyUp
+	KeyF1
+	KeyF2
+	KeyF3
+	KeyF4
+	KeyF5
+	KeyF6
+	KeyF7
+	KeyF8
+	KeyF9
+	KeyF10
+	KeyF11
+	KeyF12
+)
+
+// keyNames maps Key values to their names.
+var keyNames = [...]string{
+	KeyRune:      "Rune",
+	KeyBackspace: "Backspace",
+	KeyDelete:    "Delete",
+	KeyDown:      "Down",
+	KeyEnd:       "End",
+	KeyEnter:     "Enter",
+	KeyEscape:    "Escape",
+	KeyHome:      "Home",
+	KeyInsert:    "Insert",
+	KeyLeft:      "Left",
+	KeyPageDown:  "PageDown",
+	KeyPageUp:    "PageUp",
+	KeyRight:     "Right",
+	KeyTab:       "Tab",
+	KeyUp:        "Up",
+	KeyF1:        "F1",
+	KeyF2:        "F2",
+	KeyF3:        "F3",
+	KeyF4:        "F4",
+	KeyF5:        "F5",
+	KeyF6:        "F6",
+	KeyF7:        "F7",
+	KeyF8:        "F8",
+	KeyF9:        "F9",
+	KeyF10:       "F10",
+	KeyF11:       "F11",
+	KeyF12:       "F12",
+}
+
+// String returns the name of the key.
+func (key Key) String() string {
+	if int(key) >= len(keyNames) {
+		return "Unknown"
+	}
+	return keyNames[key]
+}
+
+// Modifier is a set of modifier keys held during a key or mouse event.
+type Modifier uint8
+
+// Modifier flags.
+const (
+	ModShift Modifier = 1 << iota
+	ModAlt
+	ModCtrl
+)
+
+// String returns the modifiers joined with "+", such as "Ctrl+Shift".
+func (modifier Modifier) String() string {
+	var names []string
+	if modifier&ModCtrl != 0 {
+		names = append(names, "Ctrl")
+	}
+	if modifier&ModAlt != 0 {
+		names = append(names, "Alt")
+	}
+	if modifier&ModShift != 0 {
+		names = append(names, "Shift")
+	}
+	return strings.Join(names, "+")
+}
+
+// KeyEvent is a key press.
+// Control characters are reported as KeyRune with ModCtrl, so Ctrl+C is
+// KeyEvent{Key: KeyRune, Rune: 'c', Modifiers: ModCtrl}.
+type KeyEvent struct {
+	Key       Key
+	Rune      rune // the character for KeyRune, zero otherwise
+	Modifiers Modifier
+}
+
+func (KeyEvent) isEvent() {}
+
+// String returns a readable form of the key, such as "Ctrl+Up" or "a".
+func (event KeyEvent) String() string {
+	name := event.Key.String()
+	if event.Key == KeyRune {
+		name = string(event.Rune)
+	}
+	if event.Modifiers == 0 {
+		return name
+	}
+	return event.Modifiers.String() + "+" + name
+}
+
+// MouseButton identifies the mouse button or wheel direction of a MouseEvent.
+type MouseButton uint8
+
+// Mouse buttons (grouped, with MouseNone at 0 for motion without a button).
+const (
+	MouseNone MouseButton = iota
+	MouseLeft
+	MouseMiddle
+	MouseRight
+	MouseWheelUp
+	MouseWheelDown
+	MouseWheelLeft
+	MouseWheelRight
+)
+
+// MouseAction describes what happened in a MouseEvent.
+type MouseAction uint8
+
+// Mouse actions.
+const (
+	MousePress MouseAction = iota
+	MouseRelease
+	MouseMotion
+)
+
+// MouseEvent is a mouse press, release, drag, or wheel movement.
+// Column and Row are 0-based terminal cell positions from the top-left.
+type MouseEvent struct {
+	Action    MouseAction
+	Button    MouseButton
+	Column    int
+	Row       int
+	Modifiers Modifier
+}
+
+func (MouseEvent) isEvent() {}
+
+// Pixel translates the event's cell position into the coordinates of the
+// cell's top-left pixel on a canvas drawn at the top-left of the terminal,
+// using the canvas cell geometry and Y-axis direction.
+func (event MouseEvent) Pixel(c *canvas.Canvas) (x, y float64) {
+	return c.CellToPixel(event.Column, event.Row)
+}
@@ -0,0 +1,50 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+func TestMouseEventPixel(t *testing.T) {
+	event := MouseEvent{Column: 3, Row: 2}
+
+	tests := []struct {
+		name      string
+		canvas    *canvas.Canvas
+		expectedX float64
+		expectedY float64
+	}{
+		{"screen coordinates", canvas.New(20, 16), 6, 8},
+		{"inverted Y", canvas.New(20, 16, canvas.WithInvertedY()), 6, 7},
+		{"half block", canvas.New(20, 16, canvas.WithHalfBlock()), 3, 4},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			x, y := event.Pixel(testCase.canvas)
+			if x != testCase.expectedX || y != testCase.expectedY {
+				t.Errorf("Pixel() = (%.0f, %.0f), want (%.0f, %.0f)", x, y, testCase.expectedX, testCase.expectedY)
+			}
+		})
+	}
+}
+
+func TestKeyEventString(t *testing.T) {
+	tests := []struct {
+		event    KeyEvent
+		expected string
+	}{
+		{KeyEvent{Key: KeyRune, Rune: 'a'}, "a"},
+		{KeyEvent{Key: KeyUp, Modifiers: ModCtrl}, "Ctrl+Up"},
+		{KeyEvent{Key: KeyRune, Rune: 'x', Modifiers: ModAlt | ModShift}, "Alt+Shift+x"},
+		{KeyEvent{Key: KeyF12}, "F12"},
+		{KeyEvent{Key: Key(200)}, "Unknown"},
+	}
+
+	for _, testCase := range tests {
+		if result := testCase.event.String(); result != testCase.expected {
+			t.Errorf("String() = %q, want %q", result, testCase.expected)
+		}
+	}
+}
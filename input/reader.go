@@ -0,0 +1,62 @@
+package input
+
+import (
+	"errors"
+	"io"
+)
+
+// readSize is the number of bytes requested from the source per read.
+const readSize = 256
+
+// Reader decodes events from a byte stream, typically a terminal in raw mode.
+type Reader struct {
+	buffer []byte    // bytes read but not yet decoded
+	source io.Reader // where input bytes come from
+}
+
+// NewReader creates a Reader that decodes events from source.
+func NewReader(source io.Reader) *Reader {
+	return &Reader{source: source}
+}
+
+// ReadEvent returns the next event, blocking until one is available.
+// Unrecognized escape sequences are skipped. A lone escape byte at the end
+// of a read is reported as KeyEscape, and "ESC [" or "ESC O" as Alt-[ or
+// Alt-O, since terminals send escape sequences in a single write. At the end of input, io.EOF is returned.
+func (reader *Reader) ReadEvent() (Event, error) {
+	for {
+		if len(reader.buffer) > 0 {
+			event, length, complete := decode(reader.buffer)
+			if complete {
+				reader.buffer = reader.buffer[length:]
+				if event != nil {
+					return event, nil
+				}
+				continue
+			}
+		}
+
+		chunk := make([]byte, readSize)
+		count, err := reader.source.Read(chunk)
+		reader.buffer = append(reader.buffer, chunk[:count]...)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if count > 0 {
+			continue
+		}
+		if len(reader.buffer) == 0 {
+			return nil, io.EOF
+		}
+
+		// The input ended inside a sequence: report its first byte on its own
+		event, _, _ := decode(reader.buffer[:1])
+		reader.buffer = reader.buffer[1:]
+		if event != nil {
+			return event, nil
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package input
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func readAll(t *testing.T, reader *Reader) []Event {
+	t.Helper()
+	var events []Event
+	for {
+		event, err := reader.ReadEvent()
+		if errors.Is(err, io.EOF) {
+			return events
+		}
+		if err != nil {
+			t.Fatalf("ReadEvent() error = %v", err)
+		}
+		events = append(events, event)
+	}
+}
+
+func TestReaderStream(t *testing.T) {
+	stream := "hi\x1b[A\x1b[<0;3;2M\x1b[<0;3;2m\x03\x1b"
+	events := readAll(t, NewReader(strings.NewReader(stream)))
+
+	expected := []Event{
+		KeyEvent{Key: KeyRune, Rune: 'h'},
+		KeyEvent{Key: KeyRune, Rune: 'i'},
+		KeyEvent{Key: KeyUp},
+		MouseEvent{Action: MousePress, Button: MouseLeft, Column: 2, Row: 1},
+		MouseEvent{Action: MouseRelease, Button: MouseLeft, Column: 2, Row: 1},
+		KeyEvent{Key: KeyRune, Rune: 'c', Modifiers: ModCtrl},
+		KeyEvent{Key: KeyEscape},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("ReadEvent() returned %d events %v, want %d", len(events), events, len(expected))
+	}
+	for index := range expected {
+		if events[index] != expected[index] {
+			t.Errorf("event %d = %#v, want %#v", index, events[index], expected[index])
+		}
+	}
+}
+
+func TestReaderSkipsUnknownSequences(t *testing.T) {
+	events := readAll(t, NewReader(strings.NewReader("\x1b[?1u\x1b[99~z")))
+	if len(events) != 1 || events[0] != (KeyEvent{Key: KeyRune, Rune: 'z'}) {
+		t.Errorf("ReadEvent() = %v, want only 'z'", events)
+	}
+}
+
+func TestReaderSplitRune(t *testing.T) {
+	// Multi-byte runes split across reads are joined before decoding
+	events := readAll(t, NewReader(iotest.OneByteReader(strings.NewReader("é⣿"))))
+	expected := []Event{KeyEvent{Key: KeyRune, Rune: 'é'}, KeyEvent{Key: KeyRune, Rune: '⣿'}}
+	if len(events) != len(expected) || events[0] != expected[0] || events[1] != expected[1] {
+		t.Errorf("ReadEvent() = %v, want %v", events, expected)
+	}
+}
+
+func TestReaderTruncatedSequence(t *testing.T) {
+	// Input ending inside a sequence reports the escape key and the remaining bytes
+	events := readAll(t, NewReader(strings.NewReader("\x1b[1")))
+	expected := []Event{
+		KeyEvent{Key: KeyEscape},
+		KeyEvent{Key: KeyRune, Rune: '['},
+		KeyEvent{Key: KeyRune, Rune: '1'},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("ReadEvent() = %v, want %v", events, expected)
+	}
+	for index := range expected {
+		if events[index] != expected[index] {
+			t.Errorf("event %d = %#v, want %#v", index, events[index], expected[index])
+		}
+	}
+}
+
+func TestReaderError(t *testing.T) {
+	failure := errors.New("read failed")
+	_, err := NewReader(iotest.ErrReader(failure)).ReadEvent()
+	if !errors.Is(err, failure) {
+		t.Errorf("ReadEvent() error = %v, want %v", err, failure)
+	}
+}
+
+func TestReaderAltBracketDoesNotBlock(t *testing.T) {
+	// Alt-[ arrives as "ESC [" in a read of its own, and the next read blocks
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	go writer.Write([]byte("\x1b["))
+
+	event, err := NewReader(reader).ReadEvent()
+	if err != nil {
+		t.Fatalf("ReadEvent() error = %v", err)
+	}
+	if expected := (KeyEvent{Key: KeyRune, Rune: '[', Modifiers: ModAlt}); event != expected {
+		t.Errorf("ReadEvent() = %#v, want %#v", event, expected)
+	}
+}
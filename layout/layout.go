@@ -0,0 +1,182 @@
+// Package layout splits the terminal into rectangular regions, such as a 3D
+// view, a minimap, and a status bar, and turns them into canvas regions.
+//
+// A Layout is a tree: each node splits its region among its children side by
+// side or stacked, giving each child a fixed number of cells, a percentage of
+// the node, or a flexible share of the cells left over. Sizes are computed in
+// terminal cells, so regions line up with text and never split a braille
+// cell, and then converted to pixel rectangles or Sub views of a canvas with
+// Region.Rect and Region.Sub.
+package layout
+
+import (
+	"math"
+	"slices"
+)
+
+// Direction is how a node arranges its children.
+type Direction uint8
+
+// Available directions.
+const (
+	Horizontal Direction = iota // children side by side, left to right
+	Vertical                    // children stacked, top to bottom
+)
+
+// sizeKind is the unit of a Size.
+type sizeKind uint8
+
+// Size kinds, with flexible sizes as the zero value.
+const (
+	sizeFlex sizeKind = iota
+	sizeFixed
+	sizePercent
+)
+
+// Size is how much of its parent's split a node takes. The zero Size is
+// Flex(1).
+type Size struct {
+	cells int      // cells of a fixed size, kept as an int so it cannot overflow
+	kind  sizeKind // unit of the size
+	value float64  // percentage or weight
+}
+
+// Fixed returns a size of the given number of cells. Fixed sizes are given
+// out first, in order, and are cut short when the parent runs out of cells.
+func Fixed(cells int) Size {
+	return Size{cells: max(cells, 0), kind: sizeFixed}
+}
+
+// Percent returns a size of the given percentage of the parent, rounded down
+// to whole cells. Percentages are given out along with fixed sizes, in order.
+// A percentage that is not a number counts as 0.
+func Percent(percent float64) Size {
+	if math.IsNaN(percent) {
+		percent = 0
+	}
+	return Size{kind: sizePercent, value: math.Max(0, math.Min(percent, 100))}
+}
+
+// Flex returns a flexible size: the cells left after fixed and percentage
+// sizes are shared among flexible children in proportion to their weights.
+// Weights of zero or less, infinite weights, and NaN count as 1.
+func Flex(weight float64) Size {
+	return Size{kind: sizeFlex, value: weight}
+}
+
+// weight returns the weight of a flexible size.
+func (size Size) weight() float64 {
+	if size.value <= 0 || math.IsInf(size.value, 0) || math.IsNaN(size.value) {
+		return 1
+	}
+	return size.value
+}
+
+// Layout is a node of a layout tree.
+type Layout struct {
+	Name      string    // key of the node's region in Regions, empty for none
+	Size      Size      // share of the parent's split, ignored for the root
+	Direction Direction // how Children are arranged
+	Children  []Layout  // nodes the region is split among, none for a leaf
+}
+
+// Compute lays the tree out over a terminal of the given size and returns the
+// region of every named node. When two nodes share a name, the later one in
+// depth-first order wins.
+func (layout Layout) Compute(columns, rows int) Regions {
+	regions := Regions{}
+	layout.place(Region{Columns: max(columns, 0), Rows: max(rows, 0)}, regions)
+	return regions
+}
+
+// place records the node's region and splits it among the children.
+func (layout Layout) place(region Region, regions Regions) {
+	if layout.Name != "" {
+		regions[layout.Name] = region
+	}
+	if len(layout.Children) == 0 {
+		return
+	}
+
+	sizes := make([]Size, len(layout.Children))
+	for index, child := range layout.Children {
+		sizes[index] = child.Size
+	}
+	total := region.Columns
+	if layout.Direction == Vertical {
+		total = region.Rows
+	}
+
+	offset := 0
+	for index, length := range split(sizes, total) {
+		child := region
+		if layout.Direction == Vertical {
+			child.Row, child.Rows = region.Row+offset, length
+		} else {
+			child.Column, child.Columns = region.Column+offset, length
+		}
+		layout.Children[index].place(child, regions)
+		offset += length
+	}
+}
+
+// split divides total cells among sizes. Fixed and percentage sizes are given
+// out first, then the rest is shared among flexible sizes by weight, handing
+// leftover cells to the largest remainders so the flexible lengths add up to
+// what was left.
+func split(sizes []Size, total int) []int {
+	lengths := make([]int, len(sizes))
+	remaining := total
+	largest := 0.0
+	for index, size := range sizes {
+		switch size.kind {
+		case sizeFixed:
+			lengths[index] = min(size.cells, remaining)
+		case sizePercent:
+			lengths[index] = min(int(math.Floor(float64(total)*size.value/100)), remaining)
+		default:
+			largest = math.Max(largest, size.weight())
+			continue
+		}
+		remaining -= lengths[index]
+	}
+	if largest == 0 || remaining == 0 {
+		return lengths
+	}
+
+	// Weights are measured against the largest, so huge weights cannot
+	// overflow their sum
+	weights := 0.0
+	for _, size := range sizes {
+		if size.kind == sizeFlex {
+			weights += size.weight() / largest
+		}
+	}
+
+	var flexible []int
+	remainders := make([]float64, len(sizes))
+	left := remaining
+	for index, size := range sizes {
+		if size.kind != sizeFlex {
+			continue
+		}
+		exact := float64(remaining) * (size.weight() / largest) / weights
+		lengths[index] = int(math.Floor(exact))
+		remainders[index] = exact - float64(lengths[index])
+		left -= lengths[index]
+		flexible = append(flexible, index)
+	}
+	slices.SortStableFunc(flexible, func(first, second int) int {
+		switch {
+		case remainders[first] > remainders[second]:
+			return -1
+		case remainders[first] < remainders[second]:
+			return 1
+		}
+		return 0
+	})
+	for _, index := range flexible[:min(left, len(flexible))] {
+		lengths[index]++
+	}
+	return lengths
+}
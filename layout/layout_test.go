@@ -0,0 +1,133 @@
+package layout
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		sizes    []Size
+		total    int
+		expected []int
+	}{
+		{"fixed and flex", []Size{Fixed(3), Flex(1)}, 10, []int{3, 7}},
+		{"percent", []Size{Percent(25), Flex(1), Percent(25)}, 10, []int{2, 6, 2}},
+		{"flex weights", []Size{Flex(1), Flex(2), Flex(1)}, 12, []int{3, 6, 3}},
+		{"zero size is flex 1", []Size{{}, Flex(1)}, 8, []int{4, 4}},
+		{"largest remainder", []Size{Flex(1), Flex(1), Flex(1)}, 10, []int{4, 3, 3}},
+		{"remainders by weight", []Size{Flex(1), Flex(2)}, 10, []int{3, 7}},
+		{"fixed cut short", []Size{Fixed(6), Fixed(6), Flex(1)}, 10, []int{6, 4, 0}},
+		{"no flex leaves cells unused", []Size{Fixed(2), Percent(50)}, 10, []int{2, 5}},
+		{"negative fixed", []Size{Fixed(-4), Flex(1)}, 5, []int{0, 5}},
+		{"huge fixed", []Size{Fixed(math.MaxInt), Flex(1)}, 10, []int{10, 0}},
+		{"huge fixed after flex", []Size{Flex(1), Fixed(math.MaxInt), Fixed(math.MaxInt)}, 10, []int{0, 10, 0}},
+		{"percent clamped", []Size{Percent(150)}, 8, []int{8}},
+		{"nothing to split", []Size{Fixed(2), Flex(1)}, 0, []int{0, 0}},
+		{"infinite flex is flex 1", []Size{Flex(math.Inf(1)), Flex(1)}, 10, []int{5, 5}},
+		{"NaN flex is flex 1", []Size{Flex(math.NaN()), Flex(1)}, 10, []int{5, 5}},
+		{"huge weights", []Size{Flex(math.MaxFloat64), Flex(math.MaxFloat64)}, 10, []int{5, 5}},
+		{"NaN percent is 0", []Size{Percent(math.NaN()), Flex(1)}, 10, []int{0, 10}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			if actual := split(testCase.sizes, testCase.total); !slices.Equal(actual, testCase.expected) {
+				t.Errorf("split(%v, %d) = %v, want %v", testCase.sizes, testCase.total, actual, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestCompute(t *testing.T) {
+	// A 3D view with a minimap beside it, above a status bar
+	root := Layout{
+		Name:      "screen",
+		Direction: Vertical,
+		Children: []Layout{
+			{Name: "top", Children: []Layout{
+				{Name: "view"},
+				{Name: "minimap", Size: Fixed(20)},
+			}},
+			{Name: "status", Size: Fixed(1)},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		columns  int
+		rows     int
+		expected Regions
+	}{
+		{"80x24", 80, 24, Regions{
+			"screen":  {Column: 0, Row: 0, Columns: 80, Rows: 24},
+			"top":     {Column: 0, Row: 0, Columns: 80, Rows: 23},
+			"view":    {Column: 0, Row: 0, Columns: 60, Rows: 23},
+			"minimap": {Column: 60, Row: 0, Columns: 20, Rows: 23},
+			"status":  {Column: 0, Row: 23, Columns: 80, Rows: 1},
+		}},
+		{"too narrow for the minimap", 12, 5, Regions{
+			"screen":  {Column: 0, Row: 0, Columns: 12, Rows: 5},
+			"top":     {Column: 0, Row: 0, Columns: 12, Rows: 4},
+			"view":    {Column: 0, Row: 0, Columns: 0, Rows: 4},
+			"minimap": {Column: 0, Row: 0, Columns: 12, Rows: 4},
+			"status":  {Column: 0, Row: 4, Columns: 12, Rows: 1},
+		}},
+		{"negative size", -5, 3, Regions{
+			"screen":  {Column: 0, Row: 0, Columns: 0, Rows: 3},
+			"top":     {Column: 0, Row: 0, Columns: 0, Rows: 2},
+			"view":    {Column: 0, Row: 0, Columns: 0, Rows: 2},
+			"minimap": {Column: 0, Row: 0, Columns: 0, Rows: 2},
+			"status":  {Column: 0, Row: 2, Columns: 0, Rows: 1},
+		}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := root.Compute(testCase.columns, testCase.rows)
+			if len(actual) != len(testCase.expected) {
+				t.Errorf("Compute() returned %d regions, want %d", len(actual), len(testCase.expected))
+			}
+			for name, expected := range testCase.expected {
+				if region := actual[name]; region != expected {
+					t.Errorf("Compute()[%q] = %+v, want %+v", name, region, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestComputeHugeFixed(t *testing.T) {
+	root := Layout{Children: []Layout{{Name: "huge", Size: Fixed(math.MaxInt)}, {Name: "rest"}}}
+	regions := root.Compute(10, 5)
+
+	if huge := regions["huge"]; huge != (Region{Column: 0, Row: 0, Columns: 10, Rows: 5}) {
+		t.Errorf("Compute()[\"huge\"] = %+v, want all 10 columns", huge)
+	}
+	if rest := regions["rest"]; !rest.Empty() {
+		t.Errorf("Compute()[\"rest\"] = %+v, want an empty region", rest)
+	}
+}
+
+func TestComputeUnnamedNodes(t *testing.T) {
+	root := Layout{Children: []Layout{
+		{Size: Percent(50), Direction: Vertical, Children: []Layout{{}, {Name: "corner", Size: Fixed(2)}}},
+		{Name: "right"},
+	}}
+	regions := root.Compute(10, 6)
+
+	expected := Regions{
+		"corner": {Column: 0, Row: 4, Columns: 5, Rows: 2},
+		"right":  {Column: 5, Row: 0, Columns: 5, Rows: 6},
+	}
+	if len(regions) != len(expected) {
+		t.Errorf("Compute() = %v, want %v", regions, expected)
+	}
+	for name, region := range expected {
+		if regions[name] != region {
+			t.Errorf("Compute()[%q] = %+v, want %+v", name, regions[name], region)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package layout
+
+import "github.com/cboone/stipple/canvas"
+
+// Region is a rectangle of terminal cells, counted from the top-left cell.
+type Region struct {
+	Column  int // leftmost column
+	Row     int // top row
+	Columns int // width in cells
+	Rows    int // height in cells
+}
+
+// Regions maps the names of layout nodes to their regions.
+type Regions map[string]Region
+
+// Empty reports whether the region covers no cells.
+func (region Region) Empty() bool {
+	return region.Columns <= 0 || region.Rows <= 0
+}
+
+// Rect returns the pixels of the region as a rectangle in the canvas
+// coordinates of c, accounting for WithHalfBlock and WithInvertedY, for
+// drawing functions and widgets that take bounds.
+func (region Region) Rect(c *canvas.Canvas) canvas.Rect {
+	cellWidth, cellHeight := c.CellSize()
+	top := region.Row * cellHeight
+	height := region.Rows * cellHeight
+	if c.InvertedY() {
+		top = c.Height() - top - height
+	}
+	return canvas.Rect{
+		X:      float64(region.Column * cellWidth),
+		Y:      float64(top),
+		Width:  float64(region.Columns * cellWidth),
+		Height: float64(height),
+	}
+}
+
+// Sub returns a view of the region backed by the storage of c, as
+// Canvas.Sub does, so the region can be drawn as a canvas of its own.
+func (region Region) Sub(c *canvas.Canvas) *canvas.Canvas {
+	return c.Sub(region.Rect(c))
+}
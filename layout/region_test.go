@@ -0,0 +1,89 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+func TestRegionEmpty(t *testing.T) {
+	tests := []struct {
+		region   Region
+		expected bool
+	}{
+		{Region{Columns: 2, Rows: 1}, false},
+		{Region{Columns: 0, Rows: 1}, true},
+		{Region{Columns: 2, Rows: 0}, true},
+	}
+
+	for _, testCase := range tests {
+		if actual := testCase.region.Empty(); actual != testCase.expected {
+			t.Errorf("%+v.Empty() = %v, want %v", testCase.region, actual, testCase.expected)
+		}
+	}
+}
+
+func TestRegionRect(t *testing.T) {
+	region := Region{Column: 1, Row: 2, Columns: 3, Rows: 1}
+	tests := []struct {
+		name     string
+		options  []canvas.Option
+		expected canvas.Rect
+	}{
+		{"braille", nil, canvas.Rect{X: 2, Y: 8, Width: 6, Height: 4}},
+		{"half block", []canvas.Option{canvas.WithHalfBlock()}, canvas.Rect{X: 1, Y: 4, Width: 3, Height: 2}},
+		{"inverted", []canvas.Option{canvas.WithInvertedY()}, canvas.Rect{X: 2, Y: 4, Width: 6, Height: 4}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := canvas.NewCells(8, 4, testCase.options...)
+			if actual := region.Rect(c); actual != testCase.expected {
+				t.Errorf("Rect() = %v, want %v", actual, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestRegionSub(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []canvas.Option
+	}{
+		{"braille", nil},
+		{"half block", []canvas.Option{canvas.WithHalfBlock()}},
+		{"inverted", []canvas.Option{canvas.WithInvertedY()}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := canvas.NewCells(8, 4, testCase.options...)
+			regions := Layout{Children: []Layout{{Name: "left", Size: Fixed(3)}, {Name: "right"}}}.Compute(8, 4)
+
+			right := regions["right"].Sub(c)
+			if right.Cols() != 5 || right.Rows() != 4 {
+				t.Errorf("Sub() = %dx%d cells, want 5x4", right.Cols(), right.Rows())
+			}
+
+			// The view's top-left cell is the region's top-left cell
+			right.SetText(0, 0, "R")
+			if character := c.Text(3, 0); character != 'R' {
+				t.Errorf("parent Text(3, 0) = %q, want 'R'", character)
+			}
+			x, y := right.CellToPixel(0, 0)
+			right.Set(x, y)
+			parentX, parentY := c.CellToPixel(3, 0)
+			if !c.Get(parentX, parentY) {
+				t.Error("drawing in the view did not reach the parent")
+			}
+		})
+	}
+}
+
+func TestRegionSubEmpty(t *testing.T) {
+	c := canvas.NewCells(8, 4)
+	view := Region{Column: 2, Row: 1, Columns: 0, Rows: 3}.Sub(c)
+	if view.Width() != 0 || view.Height() != 0 {
+		t.Errorf("Sub() of an empty region = %dx%d, want 0x0", view.Width(), view.Height())
+	}
+}
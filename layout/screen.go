@@ -0,0 +1,58 @@
+package layout
+
+import (
+	"sync"
+
+	"github.com/cboone/stipple/term"
+)
+
+// Screen keeps a layout computed for the current terminal size and recomputes
+// it when the terminal is resized. It is safe to resize from one goroutine
+// while reading regions from another.
+type Screen struct {
+	columns int        // terminal width in cells
+	layout  Layout     // tree being laid out
+	mutex   sync.Mutex // guards the size and regions
+	regions Regions    // regions for the current size
+	rows    int        // terminal height in cells
+}
+
+// NewScreen creates a Screen that lays out layout over a terminal of the given
+// size. Follow creates a Screen that tracks a terminal, or one can be
+// connected by hand:
+//
+//	stop := terminal.OnResize(screen.Resize)
+func NewScreen(layout Layout, columns, rows int) *Screen {
+	screen := &Screen{layout: layout}
+	screen.Resize(columns, rows)
+	return screen
+}
+
+// Follow creates a Screen for the current size of terminal that recomputes
+// whenever the terminal is resized, until stop is called.
+func Follow(terminal *term.Terminal, layout Layout) (screen *Screen, stop func(), err error) {
+	columns, rows, err := terminal.Size()
+	if err != nil {
+		return nil, nil, err
+	}
+	screen = NewScreen(layout, columns, rows)
+	return screen, terminal.OnResize(screen.Resize), nil
+}
+
+// Resize recomputes the regions for a terminal of the given size.
+func (screen *Screen) Resize(columns, rows int) {
+	regions := screen.layout.Compute(columns, rows)
+
+	screen.mutex.Lock()
+	defer screen.mutex.Unlock()
+	screen.columns, screen.rows, screen.regions = columns, rows, regions
+}
+
+// Regions returns the current regions and the terminal size they were
+// computed for. A renderer can compare the size with the previous frame's to
+// know when to create a new canvas. The returned map must not be modified.
+func (screen *Screen) Regions() (regions Regions, columns, rows int) {
+	screen.mutex.Lock()
+	defer screen.mutex.Unlock()
+	return screen.regions, screen.columns, screen.rows
+}
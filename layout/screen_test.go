@@ -0,0 +1,68 @@
+package layout
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/cboone/stipple/term"
+)
+
+func TestScreenResize(t *testing.T) {
+	root := Layout{Children: []Layout{{Name: "main"}, {Name: "side", Size: Percent(25)}}}
+	screen := NewScreen(root, 40, 10)
+
+	regions, columns, rows := screen.Regions()
+	if columns != 40 || rows != 10 {
+		t.Errorf("Regions() size = %dx%d, want 40x10", columns, rows)
+	}
+	if side := regions["side"]; side != (Region{Column: 30, Row: 0, Columns: 10, Rows: 10}) {
+		t.Errorf("side = %+v before resize, want 10 columns at column 30", side)
+	}
+
+	screen.Resize(80, 20)
+	regions, columns, rows = screen.Regions()
+	if columns != 80 || rows != 20 {
+		t.Errorf("Regions() size = %dx%d after resize, want 80x20", columns, rows)
+	}
+	if side := regions["side"]; side != (Region{Column: 60, Row: 0, Columns: 20, Rows: 20}) {
+		t.Errorf("side = %+v after resize, want 20 columns at column 60", side)
+	}
+}
+
+func TestScreenConcurrentResize(t *testing.T) {
+	screen := NewScreen(Layout{Name: "all"}, 10, 10)
+
+	var group sync.WaitGroup
+	group.Add(2)
+	go func() {
+		defer group.Done()
+		for size := range 100 {
+			screen.Resize(size, size)
+		}
+	}()
+	go func() {
+		defer group.Done()
+		for range 100 {
+			regions, columns, rows := screen.Regions()
+			if all := regions["all"]; all.Columns != columns || all.Rows != rows {
+				t.Errorf("regions %+v do not match size %dx%d", all, columns, rows)
+				return
+			}
+		}
+	}()
+	group.Wait()
+}
+
+func TestFollowRequiresSize(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	defer reader.Close()
+	defer writer.Close()
+
+	if _, _, err := Follow(term.New(reader, writer), Layout{}); err == nil {
+		t.Error("Follow() on a pipe error = nil, want error")
+	}
+}
@@ -0,0 +1,95 @@
+package lsystem
+
+import (
+	"math"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/turtle"
+)
+
+// Draw expands the system and draws the result with a turtle, scaled to fill
+// as much of the canvas as it can without distorting it, and centered.
+func (system System) Draw(c *canvas.Canvas, iterations int) error {
+	return system.DrawColor(c, iterations, canvas.ColorDefault)
+}
+
+// DrawColor expands and draws the system like Draw in the given color.
+func (system System) DrawColor(c *canvas.Canvas, iterations int, color canvas.Color) error {
+	modules, err := system.Expand(iterations)
+	if err != nil {
+		return err
+	}
+
+	// Walk once with the pen up to measure the drawing at unit scale
+	pen := turtle.New(c)
+	left, top := math.Inf(1), math.Inf(1)
+	right, bottom := math.Inf(-1), math.Inf(-1)
+	system.walk(pen, modules, 0, 0, 1, false, func(x, y float64) {
+		left, top = math.Min(left, x), math.Min(top, y)
+		right, bottom = math.Max(right, x), math.Max(bottom, y)
+	})
+
+	width, height := float64(c.Width()-1), float64(c.Height()-1)
+	scale := math.Inf(1)
+	if right > left {
+		scale = width / (right - left)
+	}
+	if bottom > top {
+		scale = math.Min(scale, height/(bottom-top))
+	}
+	if math.IsInf(scale, 1) || scale <= 0 {
+		return nil
+	}
+
+	// Positions run between pixel centers, so rounding error at the edges
+	// never tips a line onto the next pixel or off the canvas
+	startX := 0.5 + (width-(right-left)*scale)/2 - left*scale
+	startY := 0.5 + (height-(bottom-top)*scale)/2 - top*scale
+	pen.SetPenColor(color)
+	system.walk(pen, modules, startX, startY, scale, true, nil)
+	return nil
+}
+
+// walk interprets modules with the turtle from a starting position, moving
+// scale pixels per unit. With draw false the pen stays up; visit, when not
+// nil, is called with the start and with every position the turtle reaches.
+func (system System) walk(pen *turtle.Turtle, modules []Module, startX, startY, scale float64, draw bool, visit func(x, y float64)) {
+	pen.PenUp()
+	pen.Goto(startX, startY)
+	pen.SetHeading(system.Heading)
+	if visit != nil {
+		visit(startX, startY)
+	}
+
+	for _, module := range modules {
+		switch module.Symbol {
+		case 'F', 'G', 'f':
+			if draw && module.Symbol != 'f' {
+				pen.PenDown()
+			}
+			pen.Forward(module.param(1) * scale)
+			pen.PenUp()
+			if visit != nil {
+				visit(pen.Position())
+			}
+		case '+':
+			pen.Left(module.param(system.Angle))
+		case '-':
+			pen.Right(module.param(system.Angle))
+		case '|':
+			pen.Left(180)
+		case '[':
+			pen.PushState()
+		case ']':
+			pen.PopState()
+		}
+	}
+}
+
+// param returns the module's first parameter, or fallback when it has none.
+func (module Module) param(fallback float64) float64 {
+	if len(module.Params) == 0 {
+		return fallback
+	}
+	return module.Params[0]
+}
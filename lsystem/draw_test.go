@@ -0,0 +1,139 @@
+package lsystem
+
+import (
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+func TestDrawSquareFillsCanvas(t *testing.T) {
+	actual := canvas.New(20, 20)
+	square := System{Axiom: "F+F+F+F", Angle: 90}
+	if err := square.Draw(actual, 0); err != nil {
+		t.Fatalf("Draw() error = %v", err)
+	}
+
+	expected := canvas.New(20, 20)
+	draw.Rectangle(expected, 0, 0, 20, 20)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestDrawCentersFlatDrawing(t *testing.T) {
+	actual := canvas.New(20, 8)
+	line := System{Axiom: "FfF"}
+	if err := line.Draw(actual, 0); err != nil {
+		t.Fatalf("Draw() error = %v", err)
+	}
+
+	// Three units across the width, drawn through the middle with a gap
+	expected := canvas.New(20, 8)
+	draw.Line(expected, 0, 4, 6, 4)
+	draw.Line(expected, 13, 4, 19, 4)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestDrawFitsFractal(t *testing.T) {
+	c := canvas.New(80, 40)
+	koch := System{Axiom: "F--F--F", Rules: []Rule{{Symbol: 'F', Successor: "F+F--F+F"}}, Angle: 60}
+	if err := koch.Draw(c, 3); err != nil {
+		t.Fatalf("Draw() error = %v", err)
+	}
+	stippletest.PrintVisual(t, "Koch snowflake", c)
+
+	// The snowflake is taller than it is wide, so it touches the top and
+	// bottom of the canvas but not its sides
+	lit := func(x, y int) bool { return c.Get(float64(x), float64(y)) }
+	rowLit := func(y int) bool {
+		for x := range c.Width() {
+			if lit(x, y) {
+				return true
+			}
+		}
+		return false
+	}
+	columnLit := func(x int) bool {
+		for y := range c.Height() {
+			if lit(x, y) {
+				return true
+			}
+		}
+		return false
+	}
+	if !rowLit(0) || !rowLit(c.Height()-1) {
+		t.Error("snowflake does not span the canvas height")
+	}
+	if columnLit(0) || columnLit(c.Width()-1) {
+		t.Error("snowflake touches the sides of a wide canvas")
+	}
+}
+
+func TestDrawBranches(t *testing.T) {
+	actual := canvas.New(20, 20)
+	// A T shape: a stem, then a branch each way from its top, twice as wide
+	// as it is tall, so it is centered vertically
+	tee := System{Axiom: "F[+F][-F]", Angle: 90, Heading: 90}
+	if err := tee.Draw(actual, 0); err != nil {
+		t.Fatalf("Draw() error = %v", err)
+	}
+
+	expected := canvas.New(20, 20)
+	draw.Line(expected, 10, 14, 10, 5)
+	draw.Line(expected, 0, 5, 19, 5)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestDrawColor(t *testing.T) {
+	c := canvas.New(20, 8, canvas.WithColor())
+	line := System{Axiom: "F"}
+	if err := line.DrawColor(c, 0, canvas.ColorMagenta); err != nil {
+		t.Fatalf("DrawColor() error = %v", err)
+	}
+	if color := c.GetColor(10, 4); color != canvas.ColorMagenta {
+		t.Errorf("GetColor(10, 4) = %d, want %d", color, canvas.ColorMagenta)
+	}
+}
+
+func TestDrawInvertedY(t *testing.T) {
+	// A Hilbert curve spans 7 units after 3 iterations, so on 64 pixels every
+	// corner lands on a pixel center and both canvases show the same picture
+	curve := System{
+		Axiom: "A",
+		Rules: []Rule{
+			{Symbol: 'A', Successor: "+BF-AFA-FB+"},
+			{Symbol: 'B', Successor: "-AF+BFB+FA-"},
+		},
+		Angle: 90,
+	}
+
+	normal := canvas.New(64, 64)
+	if err := curve.Draw(normal, 3); err != nil {
+		t.Fatalf("Draw() error = %v", err)
+	}
+	inverted := canvas.New(64, 64, canvas.WithInvertedY())
+	if err := curve.Draw(inverted, 3); err != nil {
+		t.Fatalf("Draw() error = %v", err)
+	}
+	stippletest.PrintVisual(t, "Hilbert curve", normal)
+
+	if normal.Frame() != inverted.Frame() {
+		t.Errorf("inverted frame:\n%s\nwant:\n%s", inverted.Frame(), normal.Frame())
+	}
+}
+
+func TestDrawNothingToDraw(t *testing.T) {
+	c := canvas.New(8, 8)
+	turns := System{Axiom: "+-X", Angle: 90}
+	if err := turns.Draw(c, 0); err != nil {
+		t.Fatalf("Draw() error = %v", err)
+	}
+	stippletest.AssertEqual(t, canvas.New(8, 8), c)
+}
+
+func TestDrawError(t *testing.T) {
+	broken := System{Axiom: "F("}
+	if err := broken.Draw(canvas.New(8, 8), 0); err == nil {
+		t.Error("Draw() error = nil, want a parse error")
+	}
+}
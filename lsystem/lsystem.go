@@ -0,0 +1,108 @@
+// Package lsystem grows and draws Lindenmayer systems with turtle graphics.
+//
+// A System rewrites an axiom with production rules for a number of
+// iterations, then interprets the result with a turtle.Turtle, scaled to fit
+// the canvas. Rules may be deterministic, stochastic (several weighted rules
+// for one symbol), or parametric (successors computed from a module's
+// parameters, optionally guarded by a condition).
+//
+// The turtle interprets these symbols; all others are ignored, so they can
+// stand for growth stages that only matter to the rules:
+//
+//	F, G  move forward one unit (or by the first parameter), drawing
+//	f     move forward without drawing
+//	+     turn left by the angle (or by the first parameter)
+//	-     turn right by the angle (or by the first parameter)
+//	|     turn around
+//	[     save the turtle's position and heading
+//	]     restore the last saved position and heading
+package lsystem
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// Rule rewrites every module with a given symbol on each iteration.
+type Rule struct {
+	Symbol    rune                            // symbol rewritten by the rule
+	Successor string                          // replacement, in the notation Parse reads
+	Produce   func(params []float64) []Module // computes the replacement from the parameters instead of Successor
+	Condition func(params []float64) bool     // whether the rule applies to a module, nil for always
+	Weight    float64                         // relative chance among rules that apply to a module; zero or less counts as 1
+}
+
+// System is an L-system and how to draw it.
+type System struct {
+	Axiom   string  // starting string, in the notation Parse reads
+	Rules   []Rule  // production rules
+	Angle   float64 // turn angle in degrees for + and - without parameters
+	Heading float64 // initial heading in degrees: 0 is right, 90 is up
+	Seed    uint64  // seed for choosing among stochastic rules
+}
+
+// Expand rewrites the axiom with the rules the given number of times and
+// returns the result. Modules no rule applies to are kept as they are. When
+// several rules apply to a module, one is chosen at random by weight; the
+// same Seed always chooses the same way.
+func (system System) Expand(iterations int) ([]Module, error) {
+	modules, err := Parse(system.Axiom)
+	if err != nil {
+		return nil, fmt.Errorf("axiom: %w", err)
+	}
+	successors := make([][]Module, len(system.Rules))
+	for index, rule := range system.Rules {
+		if rule.Produce != nil {
+			continue
+		}
+		if successors[index], err = Parse(rule.Successor); err != nil {
+			return nil, fmt.Errorf("rule %d (%c): %w", index+1, rule.Symbol, err)
+		}
+	}
+
+	random := rand.New(rand.NewPCG(system.Seed, system.Seed))
+	var candidates []int
+	for range iterations {
+		var next []Module
+		for _, module := range modules {
+			candidates = candidates[:0]
+			total := 0.0
+			for index, rule := range system.Rules {
+				if rule.Symbol == module.Symbol && (rule.Condition == nil || rule.Condition(module.Params)) {
+					candidates = append(candidates, index)
+					total += rule.weight()
+				}
+			}
+			if len(candidates) == 0 {
+				next = append(next, module)
+				continue
+			}
+
+			chosen := candidates[0]
+			if len(candidates) > 1 {
+				pick := random.Float64() * total
+				for _, index := range candidates {
+					chosen = index
+					if pick -= system.Rules[index].weight(); pick < 0 {
+						break
+					}
+				}
+			}
+			if produce := system.Rules[chosen].Produce; produce != nil {
+				next = append(next, produce(module.Params)...)
+			} else {
+				next = append(next, successors[chosen]...)
+			}
+		}
+		modules = next
+	}
+	return modules, nil
+}
+
+// weight returns the rule's relative chance of being chosen.
+func (rule Rule) weight() float64 {
+	if rule.Weight <= 0 {
+		return 1
+	}
+	return rule.Weight
+}
@@ -0,0 +1,133 @@
+package lsystem
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpandDeterministic(t *testing.T) {
+	algae := System{
+		Axiom: "A",
+		Rules: []Rule{
+			{Symbol: 'A', Successor: "AB"},
+			{Symbol: 'B', Successor: "A"},
+		},
+	}
+	expected := []string{"A", "AB", "ABA", "ABAAB", "ABAABABA"}
+
+	for iterations, want := range expected {
+		modules, err := algae.Expand(iterations)
+		if err != nil {
+			t.Fatalf("Expand(%d) error = %v", iterations, err)
+		}
+		if got := Format(modules); got != want {
+			t.Errorf("Expand(%d) = %q, want %q", iterations, got, want)
+		}
+	}
+}
+
+func TestExpandKeepsUnmatchedSymbols(t *testing.T) {
+	koch := System{Axiom: "F", Rules: []Rule{{Symbol: 'F', Successor: "F+F-F-F+F"}}}
+	modules, err := koch.Expand(2)
+	if err != nil {
+		t.Fatalf("Expand(2) error = %v", err)
+	}
+	want := strings.ReplaceAll("F+F-F-F+F", "F", "F+F-F-F+F")
+	if got := Format(modules); got != want {
+		t.Errorf("Expand(2) = %q, want %q", got, want)
+	}
+}
+
+func TestExpandStochastic(t *testing.T) {
+	system := System{
+		Axiom: strings.Repeat("X", 1000),
+		Rules: []Rule{
+			{Symbol: 'X', Successor: "A", Weight: 1},
+			{Symbol: 'X', Successor: "B", Weight: 3},
+		},
+		Seed: 7,
+	}
+
+	first, err := system.Expand(1)
+	if err != nil {
+		t.Fatalf("Expand(1) error = %v", err)
+	}
+	second, _ := system.Expand(1)
+	if Format(first) != Format(second) {
+		t.Error("Expand(1) with the same seed chose differently")
+	}
+
+	count := strings.Count(Format(first), "A")
+	if count < 200 || count > 300 {
+		t.Errorf("rule with weight 1 of 4 chosen %d times in 1000, want about 250", count)
+	}
+
+	system.Seed = 8
+	other, _ := system.Expand(1)
+	if Format(first) == Format(other) {
+		t.Error("Expand(1) with a different seed chose the same way")
+	}
+}
+
+func TestExpandParametric(t *testing.T) {
+	// Each stage draws a segment and sprouts a half-size stage, until the
+	// stage is too small to grow
+	system := System{
+		Axiom: "A(1)",
+		Rules: []Rule{{
+			Symbol: 'A',
+			Produce: func(params []float64) []Module {
+				return []Module{
+					{Symbol: 'F', Params: []float64{params[0]}},
+					{Symbol: 'A', Params: []float64{params[0] / 2}},
+				}
+			},
+			Condition: func(params []float64) bool { return params[0] > 0.2 },
+		}},
+	}
+
+	tests := []struct {
+		iterations int
+		expected   string
+	}{
+		{0, "A(1)"},
+		{1, "F(1)A(0.5)"},
+		{2, "F(1)F(0.5)A(0.25)"},
+		{3, "F(1)F(0.5)F(0.25)A(0.125)"},
+		{5, "F(1)F(0.5)F(0.25)A(0.125)"},
+	}
+
+	for _, testCase := range tests {
+		modules, err := system.Expand(testCase.iterations)
+		if err != nil {
+			t.Fatalf("Expand(%d) error = %v", testCase.iterations, err)
+		}
+		if got := Format(modules); got != testCase.expected {
+			t.Errorf("Expand(%d) = %q, want %q", testCase.iterations, got, testCase.expected)
+		}
+	}
+}
+
+func TestExpandErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		system   System
+		expected string
+	}{
+		{"axiom", System{Axiom: "F("}, "axiom: position 2: unterminated parameters"},
+		{
+			"rule",
+			System{Axiom: "F", Rules: []Rule{{Symbol: 'G', Successor: "G"}, {Symbol: 'F', Successor: ")"}}},
+			"rule 2 (F): position 1: unexpected ')'",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := testCase.system.Expand(1)
+			if err == nil || err.Error() != testCase.expected {
+				t.Errorf("Expand(1) error = %v, want %q", err, testCase.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package lsystem
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Module is one symbol of an L-system string, with optional numeric
+// parameters, such as F or F(2.5).
+type Module struct {
+	Symbol rune      // the symbol
+	Params []float64 // parameters, nil for a plain symbol
+}
+
+// String returns the module in the notation Parse reads, such as F(2.5).
+func (module Module) String() string {
+	if len(module.Params) == 0 {
+		return string(module.Symbol)
+	}
+	params := make([]string, len(module.Params))
+	for index, param := range module.Params {
+		params[index] = strconv.FormatFloat(param, 'g', -1, 64)
+	}
+	return string(module.Symbol) + "(" + strings.Join(params, ",") + ")"
+}
+
+// Format returns modules in the notation Parse reads, such as F(1)[+F(0.5)].
+func Format(modules []Module) string {
+	var builder strings.Builder
+	for _, module := range modules {
+		builder.WriteString(module.String())
+	}
+	return builder.String()
+}
+
+// Parse reads an L-system string into modules. Every rune other than
+// whitespace and parentheses is a symbol, and a symbol may be followed by
+// comma-separated numeric parameters in parentheses: "F(1)[+(30)F(0.5)]".
+func Parse(text string) ([]Module, error) {
+	var modules []Module
+	runes := []rune(text)
+	for position := 0; position < len(runes); position++ {
+		character := runes[position]
+		switch {
+		case unicode.IsSpace(character):
+			continue
+		case character == '(':
+			return nil, fmt.Errorf("position %d: parameters without a symbol", position+1)
+		case character == ')':
+			return nil, fmt.Errorf("position %d: unexpected ')'", position+1)
+		}
+
+		module := Module{Symbol: character}
+		if position+1 < len(runes) && runes[position+1] == '(' {
+			end := position + 2
+			for end < len(runes) && runes[end] != ')' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("position %d: unterminated parameters", position+2)
+			}
+			for _, field := range strings.Split(string(runes[position+2:end]), ",") {
+				param, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+				if err != nil {
+					return nil, fmt.Errorf("position %d: invalid parameter %q", position+2, field)
+				}
+				module.Params = append(module.Params, param)
+			}
+			position = end
+		}
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
@@ -0,0 +1,67 @@
+package lsystem
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []Module
+	}{
+		{"empty", "", nil},
+		{"symbols", "F+F", []Module{{Symbol: 'F'}, {Symbol: '+'}, {Symbol: 'F'}}},
+		{"whitespace", " F \n+", []Module{{Symbol: 'F'}, {Symbol: '+'}}},
+		{"parameter", "F(2.5)", []Module{{Symbol: 'F', Params: []float64{2.5}}}},
+		{"parameters", "A(1, -2)B", []Module{{Symbol: 'A', Params: []float64{1, -2}}, {Symbol: 'B'}}},
+		{"brackets", "[+(30)F]", []Module{{Symbol: '['}, {Symbol: '+', Params: []float64{30}}, {Symbol: 'F'}, {Symbol: ']'}}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			modules, err := Parse(testCase.text)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", testCase.text, err)
+			}
+			if !reflect.DeepEqual(modules, testCase.expected) {
+				t.Errorf("Parse(%q) = %v, want %v", testCase.text, modules, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"no symbol", "(1)", "position 1: parameters without a symbol"},
+		{"unexpected close", "F)", "position 2: unexpected ')'"},
+		{"unterminated", "F(1", "position 2: unterminated parameters"},
+		{"invalid number", "F(x)", `position 2: invalid parameter "x"`},
+		{"empty parameter", "F()", `position 2: invalid parameter ""`},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := Parse(testCase.text)
+			if err == nil || err.Error() != testCase.expected {
+				t.Errorf("Parse(%q) error = %v, want %q", testCase.text, err, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	text := "F(1)[+(22.5)F(0.5,2)]-G"
+	modules, err := Parse(text)
+	if err != nil {
+		t.Fatalf("Parse(%q) error = %v", text, err)
+	}
+	if formatted := Format(modules); formatted != text {
+		t.Errorf("Format() = %q, want %q", formatted, text)
+	}
+}
@@ -0,0 +1,194 @@
+package plot
+
+import (
+	"math"
+	"strconv"
+)
+
+// Scale maps data values to positions along an axis.
+type Scale uint8
+
+// Available scales.
+const (
+	ScaleLinear Scale = iota // evenly spaced values
+	ScaleLog                 // evenly spaced powers of ten; non-positive values are skipped
+)
+
+// defaultTicks is the approximate number of tick intervals per axis.
+const defaultTicks = 5
+
+// epsilon absorbs floating-point error when comparing tick positions.
+const epsilon = 1e-9
+
+// minRelativeStep is the smallest linear tick step, relative to the size of
+// the bounds, for which ticks one step apart are still distinct values.
+const minRelativeStep = 1e-12
+
+// axis holds the configuration of one chart axis.
+type axis struct {
+	fixed bool    // whether min and max were set explicitly
+	max   float64 // upper bound of a fixed range
+	min   float64 // lower bound of a fixed range
+	scale Scale   // how values are spaced
+	ticks int     // approximate number of tick intervals
+}
+
+// axisRange is an axis resolved for drawing: bounds in scaled space
+// (log10 of the data for ScaleLog) and the tick positions.
+type axisRange struct {
+	high  float64   // scaled upper bound
+	low   float64   // scaled lower bound
+	scale Scale     // how values are spaced
+	step  float64   // scaled distance between ticks
+	ticks []float64 // tick values in data space
+}
+
+// transform converts a data value to scaled space.
+// It returns ok = false for values the scale cannot show.
+func (scale Scale) transform(value float64) (scaled float64, ok bool) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false
+	}
+	if scale == ScaleLog {
+		if value <= 0 {
+			return 0, false
+		}
+		return math.Log10(value), true
+	}
+	return value, true
+}
+
+// resolve computes the drawing range for values seen in the data.
+// Autoscaled ranges are widened to whole tick steps; fixed ranges are kept.
+func (axis axis) resolve(dataLow, dataHigh float64, hasData bool) axisRange {
+	ticks := axis.ticks
+	if ticks <= 0 {
+		ticks = defaultTicks
+	}
+
+	low, high := dataLow, dataHigh
+	fixed := false
+	if axis.fixed && axis.min < axis.max {
+		fixedLow, lowOK := axis.scale.transform(axis.min)
+		fixedHigh, highOK := axis.scale.transform(axis.max)
+		if lowOK && highOK {
+			low, high, fixed, hasData = fixedLow, fixedHigh, true, true
+		}
+	}
+	if !hasData {
+		low, high = 0, 1
+	}
+
+	if axis.scale == ScaleLog {
+		return logRange(low, high, ticks, fixed)
+	}
+	return linearRange(low, high, ticks, fixed)
+}
+
+// linearRange builds a linear axis range from low to high.
+func linearRange(low, high float64, ticks int, fixed bool) axisRange {
+	if low == high {
+		padding := math.Max(math.Abs(low)/2, 1)
+		low, high = low-padding, high+padding
+	}
+	step := niceStep(high-low, ticks)
+
+	// Large values with a small spread, or bounds whose span overflows, leave
+	// no room for ticks in between, so only the endpoints are marked
+	magnitude := math.Max(math.Abs(low), math.Abs(high))
+	if math.IsInf(step, 0) || math.IsNaN(step) || step <= magnitude*minRelativeStep {
+		return axisRange{high: high, low: low, scale: ScaleLinear, step: high - low, ticks: []float64{low, high}}
+	}
+	if !fixed {
+		low = math.Floor(low/step) * step
+		high = math.Ceil(high/step) * step
+	}
+
+	// Each tick is computed from its index rather than by accumulating steps,
+	// so rounding cannot drift or stall the loop. Widening to whole steps
+	// leaves at most ticks + 2 ticks, and the loop never runs much past that.
+	axisRange := axisRange{high: high, low: low, scale: ScaleLinear, step: step}
+	first := math.Ceil(low/step-epsilon) * step
+	for index := range ticks + 4 {
+		tick := first + float64(index)*step
+		if tick > high+step*epsilon {
+			break
+		}
+		axisRange.ticks = append(axisRange.ticks, snap(tick, step))
+	}
+	return axisRange
+}
+
+// snap rounds a tick value to a whole multiple of step, so values such as
+// 0.6000000000000001 and -0 print cleanly. Steps below 1 are handled through
+// their exact integer reciprocal to avoid reintroducing rounding error.
+func snap(value, step float64) float64 {
+	var snapped float64
+	if step < 1 {
+		reciprocal := math.Round(1 / step)
+		snapped = math.Round(value*reciprocal) / reciprocal
+	} else {
+		snapped = math.Round(value/step) * step
+	}
+	if snapped == 0 {
+		return 0
+	}
+	return snapped
+}
+
+// logRange builds a logarithmic axis range from low to high, given as
+// powers of ten. Ticks are placed on whole powers of ten.
+func logRange(low, high float64, ticks int, fixed bool) axisRange {
+	if !fixed {
+		low, high = math.Floor(low), math.Ceil(high)
+	}
+	if high-low < epsilon {
+		high = low + 1
+	}
+	step := math.Max(1, math.Ceil((high-low)/float64(ticks)))
+
+	axisRange := axisRange{high: high, low: low, scale: ScaleLog, step: step}
+	for exponent := math.Ceil(low - epsilon); exponent <= high+epsilon; exponent += step {
+		axisRange.ticks = append(axisRange.ticks, math.Pow(10, exponent))
+	}
+	return axisRange
+}
+
+// niceStep returns a round step (1, 2, or 5 times a power of ten) that splits
+// span into about count intervals.
+func niceStep(span float64, count int) float64 {
+	raw := span / float64(count)
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, multiple := range []float64{1, 2, 5} {
+		if raw <= multiple*magnitude*(1+epsilon) {
+			return multiple * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// fraction returns where a data value falls between the range bounds,
+// from 0 at low to 1 at high, with ok = false when the scale cannot show it.
+func (axisRange axisRange) fraction(value float64) (position float64, ok bool) {
+	scaled, ok := axisRange.scale.transform(value)
+	if !ok {
+		return 0, false
+	}
+	// Halving both sides first keeps the differences finite when the bounds
+	// are so far apart that their span overflows
+	return (scaled/2 - axisRange.low/2) / (axisRange.high/2 - axisRange.low/2), true
+}
+
+// format returns the default label for a tick value, with only as many
+// decimals as the tick step needs.
+func (axisRange axisRange) format(value float64) string {
+	if axisRange.scale == ScaleLog {
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+	magnitude := math.Abs(value)
+	if magnitude != 0 && (magnitude >= 1e6 || magnitude < 1e-4) {
+		return strconv.FormatFloat(value, 'g', 3, 64)
+	}
+	decimals := max(0, int(-math.Floor(math.Log10(axisRange.step)+epsilon)))
+	return strconv.FormatFloat(value, 'f', decimals, 64)
+}
@@ -0,0 +1,147 @@
+package plot
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestNiceStep(t *testing.T) {
+	tests := []struct {
+		span     float64
+		count    int
+		expected float64
+	}{
+		{10, 5, 2},
+		{1, 5, 0.2},
+		{100, 4, 50},
+		{7, 5, 2},
+		{3, 5, 1},
+		{0.03, 3, 0.01},
+		{1200, 5, 500},
+	}
+
+	for _, testCase := range tests {
+		if result := niceStep(testCase.span, testCase.count); math.Abs(result-testCase.expected) > 1e-12 {
+			t.Errorf("niceStep(%v, %d) = %v, want %v", testCase.span, testCase.count, result, testCase.expected)
+		}
+	}
+}
+
+func TestAxisResolveLinear(t *testing.T) {
+	tests := []struct {
+		name          string
+		axis          axis
+		low, high     float64
+		hasData       bool
+		expectedLow   float64
+		expectedHigh  float64
+		expectedTicks []float64
+	}{
+		{"autoscaled", axis{}, 0.3, 9.2, true, 0, 10, []float64{0, 2, 4, 6, 8, 10}},
+		{"negative", axis{}, -3.5, 3.5, true, -4, 4, []float64{-4, -2, 0, 2, 4}},
+		{"fractional", axis{}, 0, 0.9, true, 0, 1, []float64{0, 0.2, 0.4, 0.6, 0.8, 1}},
+		{"single value", axis{}, 5, 5, true, 2, 8, []float64{2, 3, 4, 5, 6, 7, 8}},
+		{"no data", axis{}, 0, 0, false, 0, 1, []float64{0, 0.2, 0.4, 0.6, 0.8, 1}},
+		{"fixed", axis{fixed: true, min: -1, max: 1.5}, 0, 100, true, -1, 1.5, []float64{-1, -0.5, 0, 0.5, 1, 1.5}},
+		{"fixed without data", axis{fixed: true, min: 0, max: 3}, 0, 0, false, 0, 3, []float64{0, 1, 2, 3}},
+		{"reversed fixed range ignored", axis{fixed: true, min: 5, max: 1}, 0, 10, true, 0, 10, []float64{0, 2, 4, 6, 8, 10}},
+		{"tick count", axis{ticks: 2}, 0, 10, true, 0, 10, []float64{0, 5, 10}},
+		{"large values small spread", axis{}, 1e16, 1e16 + 4, true, 1e16, 1e16 + 4, []float64{1e16, 1e16 + 4}},
+		{"span overflows", axis{}, -1e308, 1e308, true, -1e308, 1e308, []float64{-1e308, 1e308}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			result := testCase.axis.resolve(testCase.low, testCase.high, testCase.hasData)
+			if result.low != testCase.expectedLow || result.high != testCase.expectedHigh {
+				t.Errorf("range = [%v, %v], want [%v, %v]", result.low, result.high, testCase.expectedLow, testCase.expectedHigh)
+			}
+			if !slices.Equal(result.ticks, testCase.expectedTicks) {
+				t.Errorf("ticks = %v, want %v", result.ticks, testCase.expectedTicks)
+			}
+		})
+	}
+}
+
+func TestAxisResolveLog(t *testing.T) {
+	tests := []struct {
+		name          string
+		axis          axis
+		low, high     float64 // in powers of ten
+		expectedLow   float64
+		expectedHigh  float64
+		expectedTicks []float64
+	}{
+		{"autoscaled", axis{scale: ScaleLog}, 0.2, 2.7, 0, 3, []float64{1, 10, 100, 1000}},
+		{"single decade", axis{scale: ScaleLog}, 1, 1, 1, 2, []float64{10, 100}},
+		{"many decades", axis{scale: ScaleLog}, -6, 6, -6, 6, []float64{1e-6, 1e-3, 1, 1e3, 1e6}},
+		{"fixed", axis{scale: ScaleLog, fixed: true, min: 2, max: 500}, 0, 9, math.Log10(2), math.Log10(500), []float64{10, 100}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			result := testCase.axis.resolve(testCase.low, testCase.high, true)
+			if math.Abs(result.low-testCase.expectedLow) > 1e-9 || math.Abs(result.high-testCase.expectedHigh) > 1e-9 {
+				t.Errorf("range = [%v, %v], want [%v, %v]", result.low, result.high, testCase.expectedLow, testCase.expectedHigh)
+			}
+			if len(result.ticks) != len(testCase.expectedTicks) {
+				t.Fatalf("ticks = %v, want %v", result.ticks, testCase.expectedTicks)
+			}
+			for index, tick := range result.ticks {
+				if math.Abs(tick-testCase.expectedTicks[index]) > tick*1e-9 {
+					t.Errorf("ticks = %v, want %v", result.ticks, testCase.expectedTicks)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestScaleTransform(t *testing.T) {
+	tests := []struct {
+		scale    Scale
+		value    float64
+		expected float64
+		ok       bool
+	}{
+		{ScaleLinear, -3, -3, true},
+		{ScaleLinear, math.NaN(), 0, false},
+		{ScaleLinear, math.Inf(1), 0, false},
+		{ScaleLog, 100, 2, true},
+		{ScaleLog, 0, 0, false},
+		{ScaleLog, -5, 0, false},
+	}
+
+	for _, testCase := range tests {
+		result, ok := testCase.scale.transform(testCase.value)
+		if result != testCase.expected || ok != testCase.ok {
+			t.Errorf("transform(%v) = (%v, %v), want (%v, %v)", testCase.value, result, ok, testCase.expected, testCase.ok)
+		}
+	}
+}
+
+func TestAxisRangeFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		axis     axisRange
+		value    float64
+		expected string
+	}{
+		{"integer step", axisRange{step: 2}, 4, "4"},
+		{"tenths", axisRange{step: 0.2}, 0.6000000000000001, "0.6"},
+		{"hundredths", axisRange{step: 0.05}, 0.1, "0.10"},
+		{"negative", axisRange{step: 1}, -3, "-3"},
+		{"large", axisRange{step: 5e6}, 1.5e7, "1.5e+07"},
+		{"log", axisRange{step: 1, scale: ScaleLog}, 1000, "1000"},
+		{"log fraction", axisRange{step: 1, scale: ScaleLog}, 0.01, "0.01"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			if result := testCase.axis.format(testCase.value); result != testCase.expected {
+				t.Errorf("format(%v) = %q, want %q", testCase.value, result, testCase.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package plot
+
+import (
+	"math"
+	"slices"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// Orientation is the direction in which bars grow.
+type Orientation uint8
+
+// Available orientations.
+const (
+	Vertical   Orientation = iota // categories along x, bars grow up or down
+	Horizontal                    // categories along y, bars grow left or right
+)
+
+// defaultBarWidth is the fraction of each category slot covered by bars when
+// no width is given.
+const defaultBarWidth = 0.8
+
+// legendBar is the legend symbol for bars and histograms.
+const legendBar = "█"
+
+// BarSeries is a named set of bar values, one per category.
+// NaN and infinite values draw no bar.
+type BarSeries struct {
+	Name   string       // legend label, empty for no legend entry
+	Values []float64    // one value per category
+	Color  canvas.Color // bar color
+}
+
+// Bars is a bar chart of one or more series over shared categories.
+// Category i is centered at Positions[i], or at i when Positions is nil.
+//
+// Unstacked bars run from Baseline to their value, so values below the
+// baseline hang down from it (or extend left, for horizontal bars). The bars
+// of each category are placed side by side in series order. Stacked bars are
+// drawn as one bar per category: each value is a segment length added on top
+// of the previous positive values, or below the previous negative values,
+// starting from Baseline.
+type Bars struct {
+	Series      []BarSeries // bar values, drawn side by side or stacked
+	Positions   []float64   // category centers, nil for 0, 1, 2, and so on
+	Width       float64     // fraction of each category slot covered, 0 for 0.8
+	Baseline    float64     // value bars grow from
+	Stacked     bool        // whether to stack the series instead of grouping them
+	Orientation Orientation // direction in which the bars grow
+}
+
+// AddBars adds a bar chart to the chart.
+//
+// Bars are drawn at pixel precision, so with braille a bar can end on any of
+// a cell's four dot rows.
+func (chart *Chart) AddBars(bars Bars) {
+	chart.layers = append(chart.layers, bars)
+}
+
+// bar is one filled rectangle in data space: it spans from low to high along
+// the category axis, and from start to end along the value axis.
+type bar struct {
+	color canvas.Color // fill color
+	end   float64      // value the bar grows to
+	high  float64      // upper edge along the category axis
+	low   float64      // lower edge along the category axis
+	start float64      // value the bar grows from
+}
+
+// categories returns the number of categories.
+func (bars Bars) categories() int {
+	count := 0
+	for _, series := range bars.Series {
+		count = max(count, len(series.Values))
+	}
+	if bars.Positions != nil {
+		count = min(count, len(bars.Positions))
+	}
+	return count
+}
+
+// position returns the center of the index-th category.
+func (bars Bars) position(index int) float64 {
+	if bars.Positions == nil {
+		return float64(index)
+	}
+	return bars.Positions[index]
+}
+
+// slot returns the width of each category slot: the smallest distance between
+// neighboring categories, or 1 when there are fewer than two.
+func (bars Bars) slot() float64 {
+	positions := make([]float64, 0, bars.categories())
+	for index := range bars.categories() {
+		positions = append(positions, bars.position(index))
+	}
+	slices.Sort(positions)
+
+	slot := math.Inf(1)
+	for index := 1; index < len(positions); index++ {
+		if gap := positions[index] - positions[index-1]; gap > 0 {
+			slot = math.Min(slot, gap)
+		}
+	}
+	if math.IsInf(slot, 1) {
+		return 1
+	}
+	return slot
+}
+
+// rectangles returns the bars to draw, in drawing order.
+func (bars Bars) rectangles() []bar {
+	width := bars.Width
+	if width <= 0 {
+		width = defaultBarWidth
+	}
+	width *= bars.slot()
+
+	var rectangles []bar
+	for category := range bars.categories() {
+		center := bars.position(category)
+		if math.IsNaN(center) || math.IsInf(center, 0) {
+			continue
+		}
+		low := center - width/2
+
+		if bars.Stacked {
+			positive, negative := bars.Baseline, bars.Baseline
+			for _, series := range bars.Series {
+				value, ok := seriesValue(series, category)
+				if !ok || value == 0 {
+					continue
+				}
+				cursor := &positive
+				if value < 0 {
+					cursor = &negative
+				}
+				rectangles = append(rectangles, bar{color: series.Color, end: *cursor + value, high: low + width, low: low, start: *cursor})
+				*cursor += value
+			}
+			continue
+		}
+
+		share := width / float64(len(bars.Series))
+		for index, series := range bars.Series {
+			value, ok := seriesValue(series, category)
+			if !ok || value == bars.Baseline {
+				continue
+			}
+			left := low + float64(index)*share
+			rectangles = append(rectangles, bar{color: series.Color, end: value, high: left + share, low: left, start: bars.Baseline})
+		}
+	}
+	return rectangles
+}
+
+// seriesValue returns the value of a series for a category, with ok = false
+// when it has none.
+func seriesValue(series BarSeries, category int) (value float64, ok bool) {
+	if category >= len(series.Values) {
+		return 0, false
+	}
+	value = series.Values[category]
+	return value, !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+func (bars Bars) extent(visit func(x, y float64)) {
+	barExtent(bars.rectangles(), bars.Orientation, visit)
+}
+
+func (bars Bars) draw(c *canvas.Canvas, projection projection) {
+	drawBars(c, projection, bars.rectangles(), bars.Orientation)
+}
+
+func (bars Bars) legend(visit func(name, symbol string, color canvas.Color)) {
+	for _, series := range bars.Series {
+		if series.Name != "" {
+			visit(series.Name, legendBar, series.Color)
+		}
+	}
+}
+
+// barExtent visits the corners of each bar, with the value axis vertical for
+// Vertical bars and horizontal for Horizontal bars.
+func barExtent(rectangles []bar, orientation Orientation, visit func(x, y float64)) {
+	for _, bar := range rectangles {
+		if orientation == Horizontal {
+			visit(bar.start, bar.low)
+			visit(bar.end, bar.high)
+		} else {
+			visit(bar.low, bar.start)
+			visit(bar.high, bar.end)
+		}
+	}
+}
+
+// drawBars fills each bar, clipped to the plot area.
+func drawBars(c *canvas.Canvas, projection projection, rectangles []bar, orientation Orientation) {
+	for _, bar := range rectangles {
+		if orientation == Horizontal {
+			low, lowOK := projection.row(bar.low)
+			high, highOK := projection.row(bar.high)
+			start, startOK := projection.column(bar.start)
+			end, endOK := projection.column(bar.end)
+			if !startOK {
+				// A baseline a log axis cannot show starts at the edge of the plot area
+				start = float64(projection.left)
+			}
+			if !lowOK || !highOK || !endOK {
+				continue
+			}
+			top, bottom := categorySpan(low, high, projection.top, projection.bottom)
+			left, right := valueSpan(start, end, projection.left, projection.right)
+			projection.fill(c, left, top, right, bottom, bar.color)
+			continue
+		}
+
+		low, lowOK := projection.column(bar.low)
+		high, highOK := projection.column(bar.high)
+		start, startOK := projection.row(bar.start)
+		end, endOK := projection.row(bar.end)
+		if !startOK {
+			start = float64(projection.bottom)
+		}
+		if !lowOK || !highOK || !endOK {
+			continue
+		}
+		left, right := categorySpan(low, high, projection.left, projection.right)
+		top, bottom := valueSpan(start, end, projection.top, projection.bottom)
+		projection.fill(c, left, top, right, bottom, bar.color)
+	}
+}
+
+// categorySpan returns the pixels covered by a bar's width: those from the
+// first edge up to, but not including, the second, and at least one pixel.
+// Neighboring bars that share an edge therefore never overlap. Positions are
+// clamped just outside first..last so they fit in an int.
+func categorySpan(a, b float64, first, last int) (from, to int) {
+	low, high := clampPixel(math.Min(a, b), first, last), clampPixel(math.Max(a, b), first, last)
+	from = int(math.Round(low))
+	return from, max(from, int(math.Round(high))-1)
+}
+
+// valueSpan returns the pixels covered by a bar's length, from the pixel of
+// its start value to the pixel of its end value, inclusive.
+func valueSpan(a, b float64, first, last int) (from, to int) {
+	low, high := clampPixel(math.Min(a, b), first, last), clampPixel(math.Max(a, b), first, last)
+	return int(math.Round(low)), int(math.Round(high))
+}
+
+// clampPixel limits a screen position to one pixel beyond first..last.
+func clampPixel(position float64, first, last int) float64 {
+	return math.Max(float64(first-1), math.Min(position, float64(last+1)))
+}
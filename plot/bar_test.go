@@ -0,0 +1,192 @@
+package plot
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+func TestBarsDraw(t *testing.T) {
+	tests := []struct {
+		name     string
+		bars     Bars
+		expected func(c *canvas.Canvas)
+	}{
+		{
+			name: "vertical",
+			bars: Bars{
+				Series:    []BarSeries{{Values: []float64{4, 7}, Color: canvas.ColorRed}},
+				Positions: []float64{2, 6},
+				Width:     0.5,
+			},
+			expected: func(c *canvas.Canvas) {
+				draw.RectangleFilledColor(c, 1, 6, 2, 5, canvas.ColorRed)
+				draw.RectangleFilledColor(c, 5, 3, 2, 8, canvas.ColorRed)
+			},
+		},
+		{
+			name: "baseline",
+			bars: Bars{
+				Series:    []BarSeries{{Values: []float64{8, 2}, Color: canvas.ColorRed}},
+				Positions: []float64{2, 6},
+				Width:     0.5,
+				Baseline:  5,
+			},
+			expected: func(c *canvas.Canvas) {
+				draw.RectangleFilledColor(c, 1, 2, 2, 4, canvas.ColorRed)
+				draw.RectangleFilledColor(c, 5, 5, 2, 4, canvas.ColorRed)
+			},
+		},
+		{
+			name: "grouped",
+			bars: Bars{
+				Series: []BarSeries{
+					{Values: []float64{3, 5}, Color: canvas.ColorRed},
+					{Values: []float64{6, 1}, Color: canvas.ColorBlue},
+				},
+				Positions: []float64{2, 7},
+			},
+			expected: func(c *canvas.Canvas) {
+				draw.RectangleFilledColor(c, 0, 7, 2, 4, canvas.ColorRed)
+				draw.RectangleFilledColor(c, 2, 4, 2, 7, canvas.ColorBlue)
+				draw.RectangleFilledColor(c, 5, 5, 2, 6, canvas.ColorRed)
+				draw.RectangleFilledColor(c, 7, 9, 2, 2, canvas.ColorBlue)
+			},
+		},
+		{
+			name: "stacked",
+			bars: Bars{
+				Series: []BarSeries{
+					{Values: []float64{3, -2}, Color: canvas.ColorRed},
+					{Values: []float64{2, 4}, Color: canvas.ColorBlue},
+				},
+				Positions: []float64{2, 7},
+				Baseline:  5,
+				Stacked:   true,
+			},
+			expected: func(c *canvas.Canvas) {
+				draw.RectangleFilledColor(c, 0, 2, 4, 4, canvas.ColorRed)
+				draw.RectangleFilledColor(c, 0, 0, 4, 3, canvas.ColorBlue)
+				draw.RectangleFilledColor(c, 5, 5, 4, 3, canvas.ColorRed)
+				draw.RectangleFilledColor(c, 5, 1, 4, 5, canvas.ColorBlue)
+			},
+		},
+		{
+			name: "horizontal",
+			bars: Bars{
+				Series:      []BarSeries{{Values: []float64{4, 8}, Color: canvas.ColorRed}},
+				Positions:   []float64{2, 7},
+				Orientation: Horizontal,
+			},
+			expected: func(c *canvas.Canvas) {
+				draw.RectangleFilledColor(c, 0, 6, 5, 4, canvas.ColorRed)
+				draw.RectangleFilledColor(c, 0, 1, 9, 4, canvas.ColorRed)
+			},
+		},
+		{
+			name: "missing values",
+			bars: Bars{
+				Series: []BarSeries{
+					{Values: []float64{math.NaN(), 4}, Color: canvas.ColorRed},
+					{Values: []float64{2}, Color: canvas.ColorBlue},
+				},
+				Positions: []float64{2, 7},
+			},
+			expected: func(c *canvas.Canvas) {
+				draw.RectangleFilledColor(c, 2, 8, 2, 3, canvas.ColorBlue)
+				draw.RectangleFilledColor(c, 5, 6, 2, 5, canvas.ColorRed)
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := canvas.New(12, 12, canvas.WithColor())
+			testCase.bars.draw(actual, plotArea(actual))
+
+			expected := canvas.New(12, 12, canvas.WithColor())
+			testCase.expected(expected)
+			stippletest.AssertEqual(t, expected, actual)
+		})
+	}
+}
+
+func TestBarsClippedToPlotArea(t *testing.T) {
+	c := canvas.New(16, 16)
+	bars := Bars{Series: []BarSeries{{Values: []float64{1e300, -1e300}}}, Positions: []float64{2, 8}}
+	bars.draw(c, plotArea(c))
+
+	for y := 0.0; y < 16; y++ {
+		for x := 0.0; x < 16; x++ {
+			if c.Get(x, y) && (x > 10 || y > 10) {
+				t.Errorf("pixel (%.0f, %.0f) outside the plot area is set", x, y)
+			}
+		}
+	}
+	if !c.Get(2, 0) || !c.Get(8, 10) {
+		t.Error("bars beyond the range are not drawn to the plot area edges")
+	}
+}
+
+func TestBarsAutoRange(t *testing.T) {
+	chart := New()
+	chart.AddBars(Bars{Series: []BarSeries{{Values: []float64{1, 2, 3}}}})
+	xRange, yRange := chart.ranges()
+
+	// The x range covers the bar edges, and the y range includes the baseline
+	if xRange.low > -0.4 || xRange.high < 2.4 {
+		t.Errorf("x range = [%v, %v], want it to include [-0.4, 2.4]", xRange.low, xRange.high)
+	}
+	if yRange.low != 0 || yRange.high != 3 {
+		t.Errorf("y range = [%v, %v], want [0, 3]", yRange.low, yRange.high)
+	}
+}
+
+func TestBarsSubCellPrecision(t *testing.T) {
+	// One braille row of 4 pixels: each bar ends on a different dot row
+	c := canvas.New(12, 4)
+	projection := projection{bottom: 3, height: 4, left: 0, right: 11, top: 0,
+		x: linearRange(-0.5, 2.5, 3, true), y: linearRange(0, 3, 3, true)}
+	Bars{Series: []BarSeries{{Values: []float64{1, 2, 3}}}, Width: 0.5}.draw(c, projection)
+
+	for index, column := range []float64{2, 5, 9} {
+		top := 3 - float64(index+1)
+		if !c.Get(column, top) || (top > 0 && c.Get(column, top-1)) {
+			t.Errorf("bar %d does not end at pixel row %.0f", index, top)
+		}
+	}
+}
+
+func TestBarsInvertedY(t *testing.T) {
+	bars := Bars{Series: []BarSeries{{Values: []float64{3, -1, 2}}}}
+
+	normal := canvas.NewCells(30, 8)
+	chart := New()
+	chart.AddBars(bars)
+	chart.Draw(normal)
+
+	inverted := canvas.NewCells(30, 8, canvas.WithInvertedY())
+	chart.Draw(inverted)
+	stippletest.PrintVisual(t, "TestBarsInvertedY", inverted)
+
+	if normal.Frame() != inverted.Frame() {
+		t.Errorf("inverted frame differs:\n%s\nwant:\n%s", inverted.Frame(), normal.Frame())
+	}
+}
+
+func TestBarsLegend(t *testing.T) {
+	bars := Bars{Series: []BarSeries{
+		{Name: "a", Color: canvas.ColorRed},
+		{},
+		{Name: "c", Color: canvas.ColorBlue},
+	}}
+	entries := legendEntries(bars)
+	expected := []legendEntry{{"a", legendBar, canvas.ColorRed}, {"c", legendBar, canvas.ColorBlue}}
+	if !slices.Equal(entries, expected) {
+		t.Errorf("legend() visited %v, want %v", entries, expected)
+	}
+}
@@ -0,0 +1,390 @@
+// Package plot draws charts of numeric data on a canvas.
+//
+// A Chart autoscales its axes to the data (or uses fixed ranges), draws the
+// axes with tick marks, and renders each series as a colored polyline. Tick
+// labels and the legend are written with the canvas text overlay, so they
+// take up whole terminal cells along the left and bottom edges.
+//
+// A Sparkline is a compact chart of a stream of values, without axes, for
+// dashboards with only a row or two of cells to spare.
+package plot
+
+import (
+	"math"
+	"unicode/utf8"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+)
+
+// tickLength is the length in pixels of tick marks drawn into the plot area.
+const tickLength = 2
+
+// legendLine is the legend symbol for line series.
+const legendLine = "━"
+
+// layer is one kind of data drawn in the plot area.
+type layer interface {
+	// extent calls visit with every data point, for autoscaling.
+	extent(visit func(x, y float64))
+	// draw draws the layer through the projection.
+	draw(c *canvas.Canvas, projection projection)
+	// legend calls visit with the name, symbol, and color of each of the
+	// layer's legend entries. Unnamed data has no entry.
+	legend(visit func(name, symbol string, color canvas.Color))
+}
+
+// Option configures a Chart.
+type Option func(*Chart)
+
+// WithXRange fixes the x-axis range instead of autoscaling it to the data.
+// Data outside the range is clipped.
+func WithXRange(low, high float64) Option {
+	return func(chart *Chart) {
+		chart.x.fixed, chart.x.min, chart.x.max = true, low, high
+	}
+}
+
+// WithYRange fixes the y-axis range instead of autoscaling it to the data.
+// Data outside the range is clipped.
+func WithYRange(low, high float64) Option {
+	return func(chart *Chart) {
+		chart.y.fixed, chart.y.min, chart.y.max = true, low, high
+	}
+}
+
+// WithXScale sets the x-axis scale. The default is ScaleLinear.
+func WithXScale(scale Scale) Option {
+	return func(chart *Chart) {
+		chart.x.scale = scale
+	}
+}
+
+// WithYScale sets the y-axis scale. The default is ScaleLinear.
+func WithYScale(scale Scale) Option {
+	return func(chart *Chart) {
+		chart.y.scale = scale
+	}
+}
+
+// WithTicks sets the approximate number of tick intervals on each axis.
+// The default is 5; log axes place ticks on whole powers of ten.
+func WithTicks(x, y int) Option {
+	return func(chart *Chart) {
+		chart.x.ticks, chart.y.ticks = x, y
+	}
+}
+
+// WithTickFormat sets the function that turns tick values into labels.
+// By default, labels use as many decimals as the tick spacing needs.
+func WithTickFormat(format func(value float64) string) Option {
+	return func(chart *Chart) {
+		chart.format = format
+	}
+}
+
+// WithAxisColor sets the color of the axes and tick labels.
+func WithAxisColor(color canvas.Color) Option {
+	return func(chart *Chart) {
+		chart.axisColor = color
+	}
+}
+
+// Chart plots data on a canvas with axes, tick labels, and a legend.
+type Chart struct {
+	axisColor canvas.Color               // color of axes and tick labels
+	format    func(value float64) string // tick label formatter, nil for the default
+	layers    []layer                    // data to draw, in order
+	x         axis                       // horizontal axis
+	y         axis                       // vertical axis
+}
+
+// New creates an empty Chart.
+func New(options ...Option) *Chart {
+	chart := &Chart{}
+	for _, option := range options {
+		option(chart)
+	}
+	return chart
+}
+
+// Draw renders the chart onto the whole canvas. The canvas should be cleared
+// first if it already holds a frame. Charts smaller than a few terminal cells
+// draw nothing.
+func (chart *Chart) Draw(c *canvas.Canvas) {
+	if c.Cols() < 4 || c.Rows() < 2 {
+		return
+	}
+	cellWidth, cellHeight := c.CellSize()
+
+	xRange, yRange := chart.ranges()
+	yLabels := chart.labels(yRange)
+	margin := 0
+	for _, label := range yLabels {
+		margin = max(margin, utf8.RuneCountInString(label))
+	}
+	margin = min(margin, c.Cols()/2)
+
+	projection := projection{
+		bottom:  (c.Rows()-1)*cellHeight - 2,
+		height:  c.Height(),
+		invertY: c.InvertedY(),
+		left:    margin*cellWidth + 1,
+		right:   c.Cols()*cellWidth - 1,
+		top:     0,
+		x:       xRange,
+		y:       yRange,
+	}
+	if projection.right <= projection.left || projection.bottom <= projection.top {
+		return
+	}
+
+	chart.drawAxes(c, projection, margin, cellWidth, cellHeight, yLabels)
+	for _, layer := range chart.layers {
+		layer.draw(c, projection)
+	}
+	chart.drawLegend(c, margin)
+}
+
+// ranges resolves both axes from the data of every layer.
+func (chart *Chart) ranges() (xRange, yRange axisRange) {
+	xLow, xHigh, yLow, yHigh := math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+	for _, layer := range chart.layers {
+		layer.extent(func(x, y float64) {
+			scaledX, xOK := chart.x.scale.transform(x)
+			scaledY, yOK := chart.y.scale.transform(y)
+			if !xOK || !yOK {
+				return
+			}
+			xLow, xHigh = math.Min(xLow, scaledX), math.Max(xHigh, scaledX)
+			yLow, yHigh = math.Min(yLow, scaledY), math.Max(yHigh, scaledY)
+		})
+	}
+	hasData := xLow <= xHigh
+	return chart.x.resolve(xLow, xHigh, hasData), chart.y.resolve(yLow, yHigh, hasData)
+}
+
+// labels formats the tick labels of an axis.
+func (chart *Chart) labels(axisRange axisRange) []string {
+	labels := make([]string, len(axisRange.ticks))
+	for index, tick := range axisRange.ticks {
+		if chart.format != nil {
+			labels[index] = chart.format(tick)
+		} else {
+			labels[index] = axisRange.format(tick)
+		}
+	}
+	return labels
+}
+
+// drawAxes draws both axes with tick marks and their labels. The y labels are
+// right-aligned in the margin, which is the number of cells left of the y axis.
+func (chart *Chart) drawAxes(c *canvas.Canvas, projection projection, margin, cellWidth, cellHeight int, yLabels []string) {
+	axisX := float64(projection.left - 1)
+	axisY := float64(projection.bottom + 1)
+	projection.line(c, axisX, float64(projection.top), axisX, axisY, chart.axisColor)
+	projection.line(c, axisX, axisY, float64(projection.right), axisY, chart.axisColor)
+
+	// Y ticks: marks to the right of the axis, labels right-aligned in the margin
+	lastRow := -1
+	for index, tick := range projection.y.ticks {
+		pixelY, ok := projection.row(tick)
+		if !ok {
+			continue
+		}
+		pixelY = math.Round(pixelY)
+		projection.line(c, axisX, pixelY, axisX+tickLength, pixelY, chart.axisColor)
+
+		row := int(pixelY) / cellHeight
+		if row == lastRow {
+			continue
+		}
+		lastRow = row
+		label := yLabels[index]
+		c.SetTextColor(max(margin-utf8.RuneCountInString(label), 0), row, label, chart.axisColor)
+	}
+
+	// X ticks: marks above the axis, labels centered in the bottom row
+	labelRow := c.Rows() - 1
+	nextFree := 0
+	for index, label := range chart.labels(projection.x) {
+		pixelX, ok := projection.column(projection.x.ticks[index])
+		if !ok {
+			continue
+		}
+		pixelX = math.Round(pixelX)
+		projection.line(c, pixelX, axisY, pixelX, axisY-tickLength, chart.axisColor)
+
+		width := utf8.RuneCountInString(label)
+		column := int(pixelX)/cellWidth - width/2
+		column = max(min(column, c.Cols()-width), 0)
+		if column < nextFree {
+			continue
+		}
+		c.SetTextColor(column, labelRow, label, chart.axisColor)
+		nextFree = column + width + 1
+	}
+}
+
+// drawLegend lists named layers in the top-right corner of the plot area,
+// right of the margin.
+func (chart *Chart) drawLegend(c *canvas.Canvas, margin int) {
+	row := 0
+	for _, layer := range chart.layers {
+		layer.legend(func(name, symbol string, color canvas.Color) {
+			if row >= c.Rows()-1 {
+				return
+			}
+			width := utf8.RuneCountInString(symbol) + 1 + utf8.RuneCountInString(name)
+			column := max(c.Cols()-width, margin+1)
+			c.SetTextColor(column, row, symbol, color)
+			c.SetTextColor(column+utf8.RuneCountInString(symbol), row, " "+name, chart.axisColor)
+			row++
+		})
+	}
+}
+
+// projection maps data values to pixels of the plot area.
+// Pixel positions are in screen space (y down) and converted to canvas
+// coordinates when drawn, so charts look the same with WithInvertedY.
+type projection struct {
+	bottom  int       // last pixel row of the plot area
+	height  int       // canvas height, for Y inversion
+	invertY bool      // whether the canvas uses WithInvertedY
+	left    int       // first pixel column of the plot area
+	right   int       // last pixel column of the plot area
+	top     int       // first pixel row of the plot area
+	x       axisRange // horizontal range
+	y       axisRange // vertical range
+}
+
+// pixel returns the screen position of a data point, with ok = false for
+// values the axis scales cannot show. Points outside the ranges are returned
+// outside the plot area.
+func (projection projection) pixel(x, y float64) (pixelX, pixelY float64, ok bool) {
+	pixelX, xOK := projection.column(x)
+	pixelY, yOK := projection.row(y)
+	return pixelX, pixelY, xOK && yOK
+}
+
+// column returns the screen x position of a data x value.
+func (projection projection) column(x float64) (pixelX float64, ok bool) {
+	fraction, ok := projection.x.fraction(x)
+	return float64(projection.left) + fraction*float64(projection.right-projection.left), ok
+}
+
+// row returns the screen y position of a data y value.
+func (projection projection) row(y float64) (pixelY float64, ok bool) {
+	fraction, ok := projection.y.fraction(y)
+	return float64(projection.bottom) - fraction*float64(projection.bottom-projection.top), ok
+}
+
+// inside reports whether a screen position lies in the plot area.
+func (projection projection) inside(pixelX, pixelY float64) bool {
+	return pixelX >= float64(projection.left) && pixelX < float64(projection.right+1) &&
+		pixelY >= float64(projection.top) && pixelY < float64(projection.bottom+1)
+}
+
+// area returns the plot area as a rectangle in canvas coordinates, for clipping.
+func (projection projection) area() canvas.Rect {
+	top := projection.top
+	if projection.invertY {
+		top = projection.height - 1 - projection.bottom
+	}
+	return canvas.Rect{
+		X:      float64(projection.left),
+		Y:      float64(top),
+		Width:  float64(projection.right - projection.left + 1),
+		Height: float64(projection.bottom - projection.top + 1),
+	}
+}
+
+// set lights one screen pixel in the given color.
+func (projection projection) set(c *canvas.Canvas, pixelX, pixelY float64, color canvas.Color) {
+	c.SetColor(pixelX, projection.canvasY(pixelY), color)
+}
+
+// line draws a line between two screen positions in the given color.
+func (projection projection) line(c *canvas.Canvas, x0, y0, x1, y1 float64, color canvas.Color) {
+	draw.LineColor(c, x0, projection.canvasY(y0), x1, projection.canvasY(y1), color)
+}
+
+// fill lights the screen pixels from (left, top) to (right, bottom), inclusive,
+// that lie inside the plot area.
+func (projection projection) fill(c *canvas.Canvas, left, top, right, bottom int, color canvas.Color) {
+	left, right = max(left, projection.left), min(right, projection.right)
+	top, bottom = max(top, projection.top), min(bottom, projection.bottom)
+	if left > right || top > bottom {
+		return
+	}
+	canvasTop := top
+	if projection.invertY {
+		canvasTop = projection.height - 1 - bottom
+	}
+	draw.RectangleFilledColor(c, float64(left), float64(canvasTop),
+		float64(right-left+1), float64(bottom-top+1), color)
+}
+
+// clippedLine draws the part of a line between two screen positions that
+// lies inside the plot area.
+func (projection projection) clippedLine(c *canvas.Canvas, x0, y0, x1, y1 float64, color canvas.Color) {
+	x0, y0, x1, y1, ok := clipSegment(x0, y0, x1, y1,
+		float64(projection.left), float64(projection.top), float64(projection.right), float64(projection.bottom))
+	if ok {
+		projection.line(c, x0, y0, x1, y1, color)
+	}
+}
+
+// canvasY converts a screen row to a canvas Y coordinate.
+func (projection projection) canvasY(pixelY float64) float64 {
+	if projection.invertY {
+		return float64(projection.height-1) - math.Floor(pixelY)
+	}
+	return pixelY
+}
+
+// clipSegment clips a line segment to a rectangle with the Liang-Barsky
+// algorithm. It returns ok = false when no part of the segment is inside, or
+// when a coordinate or the segment's extent is not finite.
+func clipSegment(x0, y0, x1, y1, left, top, right, bottom float64) (clippedX0, clippedY0, clippedX1, clippedY1 float64, ok bool) {
+	deltaX, deltaY := x1-x0, y1-y0
+	if !finite(x0, y0, x1, y1, deltaX, deltaY) {
+		return 0, 0, 0, 0, false
+	}
+	enter, exit := 0.0, 1.0
+	edges := [4][2]float64{
+		{-deltaX, x0 - left},
+		{deltaX, right - x0},
+		{-deltaY, y0 - top},
+		{deltaY, bottom - y0},
+	}
+	for _, edge := range edges {
+		direction, distance := edge[0], edge[1]
+		if direction == 0 {
+			if distance < 0 {
+				return 0, 0, 0, 0, false
+			}
+			continue
+		}
+		crossing := distance / direction
+		if direction < 0 {
+			enter = math.Max(enter, crossing)
+		} else {
+			exit = math.Min(exit, crossing)
+		}
+		if enter > exit {
+			return 0, 0, 0, 0, false
+		}
+	}
+	return x0 + enter*deltaX, y0 + enter*deltaY, x0 + exit*deltaX, y0 + exit*deltaY, true
+}
+
+// finite reports whether every value is neither infinite nor NaN.
+func finite(values ...float64) bool {
+	for _, value := range values {
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,266 @@
+package plot
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/stippletest"
+)
+
+// row returns the text of one frame row of a canvas without colors.
+func row(c *canvas.Canvas, index int) string {
+	return strings.Split(c.Frame(), "\n")[index]
+}
+
+// legendEntry is one entry visited by a layer's legend method.
+type legendEntry struct {
+	name   string
+	symbol string
+	color  canvas.Color
+}
+
+// legendEntries collects the legend entries of a layer.
+func legendEntries(layer layer) []legendEntry {
+	var entries []legendEntry
+	layer.legend(func(name, symbol string, color canvas.Color) {
+		entries = append(entries, legendEntry{name, symbol, color})
+	})
+	return entries
+}
+
+func TestChartAxesAndLabels(t *testing.T) {
+	c := canvas.NewCells(30, 8)
+	chart := New()
+	chart.AddSeries(Series{Y: []float64{0, 10}})
+	chart.Draw(c)
+	stippletest.PrintVisual(t, "TestChartAxesAndLabels", c)
+
+	// Y labels are right-aligned in the margin: "10" on the top row, "0" at the bottom
+	if text := string([]rune(row(c, 0))[:2]); text != "10" {
+		t.Errorf("top-left label = %q, want %q", text, "10")
+	}
+	if character := c.Text(1, 6); character != '0' {
+		t.Errorf("bottom y label = %q, want '0'", character)
+	}
+
+	// X labels sit in the last row, from 0 under the y axis to 1 at the right edge
+	bottom := row(c, 7)
+	if !strings.HasPrefix(bottom, "⠀0.0") || !strings.HasSuffix(bottom, "1.0") {
+		t.Errorf("x label row = %q, want labels from 0.0 to 1.0", bottom)
+	}
+
+	// The axes meet at the bottom-left corner of the plot area
+	axisX, axisY := 4.0, 27.0
+	for y := 0.0; y <= axisY; y++ {
+		if !c.Get(axisX, y) {
+			t.Errorf("y axis pixel (%.0f, %.0f) not set", axisX, y)
+			break
+		}
+	}
+	for x := axisX; x < 60; x++ {
+		if !c.Get(x, axisY) {
+			t.Errorf("x axis pixel (%.0f, %.0f) not set", x, axisY)
+			break
+		}
+	}
+
+	// Tick marks extend into the plot area
+	if !c.Get(axisX+tickLength, 0) || !c.Get(axisX+tickLength, 26) {
+		t.Error("y tick marks at the top and bottom ticks not set")
+	}
+	if !c.Get(59, axisY-tickLength) {
+		t.Error("x tick mark at the last tick not set")
+	}
+}
+
+func TestChartSeriesPosition(t *testing.T) {
+	c := canvas.NewCells(30, 8)
+	chart := New(WithXRange(0, 10), WithYRange(0, 10))
+	chart.AddSeries(Series{X: []float64{0, 10}, Y: []float64{0, 10}})
+	chart.Draw(c)
+
+	// The series runs from the bottom-left to the top-right of the plot area
+	if !c.Get(5, 26) || !c.Get(59, 0) {
+		t.Errorf("series endpoints (5, 26) and (59, 0) = %v, %v, want true", c.Get(5, 26), c.Get(59, 0))
+	}
+}
+
+func TestChartInvertedY(t *testing.T) {
+	build := func(options ...canvas.Option) *canvas.Canvas {
+		c := canvas.NewCells(30, 8, options...)
+		chart := New()
+		chart.AddSeries(Series{Y: []float64{3, 1, 4, 1, 5, 9, 2, 6}})
+		chart.Draw(c)
+		return c
+	}
+
+	normal := build()
+	inverted := build(canvas.WithInvertedY())
+	if normal.Frame() != inverted.Frame() {
+		t.Errorf("inverted canvas frame differs:\n%s\nwant:\n%s", inverted.Frame(), normal.Frame())
+	}
+}
+
+func TestChartFixedRangeClipsData(t *testing.T) {
+	c := canvas.NewCells(30, 8)
+	chart := New(WithYRange(0, 1))
+	chart.AddSeries(Series{Y: []float64{0.5, 1e12, -1e12, 0.5}})
+	chart.Draw(c)
+
+	// Nothing is drawn in the label row or left of the y axis
+	for y := 28.0; y < 32; y++ {
+		for x := 0.0; x < 60; x++ {
+			if c.Get(x, y) {
+				t.Fatalf("pixel (%.0f, %.0f) below the x axis is set", x, y)
+			}
+		}
+	}
+	for y := 0.0; y < 27; y++ {
+		for x := 0.0; x < 4; x++ {
+			if c.Get(x, y) {
+				t.Fatalf("pixel (%.0f, %.0f) left of the y axis is set", x, y)
+			}
+		}
+	}
+}
+
+func TestChartLegend(t *testing.T) {
+	c := canvas.NewCells(30, 8, canvas.WithColor())
+	chart := New()
+	chart.AddSeries(Series{Name: "cpu", Y: []float64{1, 2}, Color: canvas.ColorRed})
+	chart.AddSeries(Series{Y: []float64{2, 1}})
+	chart.AddSeries(Series{Name: "memory", Y: []float64{2, 3}, Color: canvas.ColorBlue})
+	chart.Draw(c)
+
+	frame := c.Frame()
+	if !strings.Contains(frame, canvas.ColorRed.ANSI()+legendLine+canvas.ANSIReset()+" cpu") {
+		t.Errorf("frame has no red legend entry for cpu:\n%s", frame)
+	}
+	rows := strings.Split(frame, "\n")
+	if !strings.HasSuffix(rows[1], " memory") {
+		t.Errorf("second legend row = %q, want it to end with %q", rows[1], " memory")
+	}
+}
+
+func TestChartLogScale(t *testing.T) {
+	c := canvas.NewCells(30, 8)
+	chart := New(WithYScale(ScaleLog))
+	chart.AddSeries(Series{Y: []float64{1, 10, 100, 0, -5, 1000}})
+	chart.Draw(c)
+	stippletest.PrintVisual(t, "TestChartLogScale", c)
+
+	if text := string([]rune(row(c, 0))[:4]); text != "1000" {
+		t.Errorf("top label = %q, want %q", text, "1000")
+	}
+	if character := c.Text(3, 6); character != '1' {
+		t.Errorf("bottom label = %q, want '1'", character)
+	}
+}
+
+func TestChartTickFormat(t *testing.T) {
+	c := canvas.NewCells(30, 8)
+	chart := New(WithTickFormat(func(value float64) string { return "v" }), WithTicks(2, 2))
+	chart.AddSeries(Series{Y: []float64{0, 10}})
+	chart.Draw(c)
+
+	if character := c.Text(0, 0); character != 'v' {
+		t.Errorf("top label = %q, want 'v'", character)
+	}
+}
+
+func TestChartSmallCanvas(t *testing.T) {
+	for _, size := range [][2]int{{0, 0}, {2, 4}, {6, 8}, {4, 4}} {
+		c := canvas.New(size[0], size[1])
+		chart := New()
+		chart.AddSeries(Series{Y: []float64{1, 2, 3}})
+		chart.Draw(c)
+	}
+}
+
+func TestChartLargeValuesSmallSpread(t *testing.T) {
+	// Steps too small to change such values once made the tick loop spin forever
+	c := canvas.NewCells(30, 8)
+	chart := New()
+	chart.AddSeries(Series{Y: []float64{1e16, 1e16 + 4}})
+	chart.Draw(c)
+	if !strings.Contains(c.Frame(), "1e+16") {
+		t.Errorf("chart does not label its y range:\n%s", c.Frame())
+	}
+}
+
+func TestChartSpanOverflows(t *testing.T) {
+	// A span of values that overflows once made every position NaN, and the
+	// line drawing from those positions never finished
+	done := make(chan *canvas.Canvas)
+	go func() {
+		c := canvas.NewCells(30, 8)
+		chart := New()
+		chart.AddSeries(Series{Y: []float64{-1.7e308, 1.7e308}})
+		chart.Draw(c)
+		done <- c
+	}()
+
+	select {
+	case c := <-done:
+		// The series runs from the bottom-left to the top-right of the plot area
+		if !c.Get(59, 0) {
+			t.Errorf("series does not reach the top-right corner:\n%s", c.Frame())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Draw() did not finish for a span that overflows")
+	}
+}
+
+func TestChartPartialCells(t *testing.T) {
+	// A canvas 10 pixels high has two whole braille rows of 4 pixels, not two
+	// rows of 5, so the axes land where they do on a canvas of exactly 2 rows
+	build := func(c *canvas.Canvas) string {
+		chart := New(WithXRange(0, 10), WithYRange(0, 10))
+		chart.AddSeries(Series{X: []float64{0, 10}, Y: []float64{0, 10}})
+		chart.Draw(c)
+		return c.Frame()
+	}
+
+	partial, full := build(canvas.New(60, 10)), build(canvas.NewCells(30, 2))
+	if partial != full {
+		t.Errorf("chart on a 60x10 canvas:\n%s\nwant the same as on 30x2 cells:\n%s", partial, full)
+	}
+}
+
+func TestChartEmpty(t *testing.T) {
+	c := canvas.NewCells(20, 6)
+	New().Draw(c)
+	if !c.Get(6, 0) {
+		t.Error("empty chart did not draw its y axis")
+	}
+}
+
+func TestClipSegment(t *testing.T) {
+	tests := []struct {
+		name           string
+		x0, y0, x1, y1 float64
+		expected       [4]float64
+		ok             bool
+	}{
+		{"inside", 1, 1, 5, 5, [4]float64{1, 1, 5, 5}, true},
+		{"crossing", -10, 5, 20, 5, [4]float64{0, 5, 10, 5}, true},
+		{"diagonal", -5, -5, 15, 15, [4]float64{0, 0, 10, 10}, true},
+		{"outside", 20, 20, 30, 30, [4]float64{}, false},
+		{"parallel outside", -1, -5, -1, 5, [4]float64{}, false},
+		{"not a number", math.NaN(), 1, 5, 5, [4]float64{}, false},
+		{"infinite", 1, 1, math.Inf(1), 5, [4]float64{}, false},
+		{"extent overflows", -math.MaxFloat64, 5, math.MaxFloat64, 5, [4]float64{}, false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			x0, y0, x1, y1, ok := clipSegment(testCase.x0, testCase.y0, testCase.x1, testCase.y1, 0, 0, 10, 10)
+			if ok != testCase.ok || (ok && [4]float64{x0, y0, x1, y1} != testCase.expected) {
+				t.Errorf("clipSegment() = (%v, %v, %v, %v, %v), want %v, %v", x0, y0, x1, y1, ok, testCase.expected, testCase.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package plot
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/cboone/stipple/draw"
+)
+
+// ditherRanks returns the order in which the pixels of a width by height block
+// are lit as its intensity rises, following the ordered-dither matrix, indexed
+// [y][x]. Lighting the pixels ranked below n lights n evenly spread pixels.
+// Blocks narrower or shorter than the matrix sample it at a stride, so a 2x4
+// braille cell alternates columns as it fills.
+func ditherRanks(width, height int) [][]int {
+	strideX, strideY := max(1, 4/max(width, 1)), max(1, 4/max(height, 1))
+	type pixel struct{ x, y int }
+	pixels := make([]pixel, 0, width*height)
+	for y := range height {
+		for x := range width {
+			pixels = append(pixels, pixel{x, y})
+		}
+	}
+	slices.SortStableFunc(pixels, func(a, b pixel) int {
+		return cmp.Compare(draw.DitherThreshold(a.x*strideX, a.y*strideY), draw.DitherThreshold(b.x*strideX, b.y*strideY))
+	})
+
+	ranks := make([][]int, height)
+	for y := range ranks {
+		ranks[y] = make([]int, width)
+	}
+	for rank, pixel := range pixels {
+		ranks[pixel.y][pixel.x] = rank
+	}
+	return ranks
+}
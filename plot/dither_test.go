@@ -0,0 +1,32 @@
+package plot
+
+import "testing"
+
+func TestDitherRanks(t *testing.T) {
+	ranks := ditherRanks(2, 4)
+	seen := make(map[int]bool)
+	for _, row := range ranks {
+		for _, rank := range row {
+			seen[rank] = true
+		}
+	}
+	if len(seen) != 8 {
+		t.Fatalf("ditherRanks(2, 4) has %d distinct ranks, want 8: %v", len(seen), ranks)
+	}
+
+	// The first two pixels lit are in different columns and rows
+	var first, second [2]int
+	for y, row := range ranks {
+		for x, rank := range row {
+			switch rank {
+			case 0:
+				first = [2]int{x, y}
+			case 1:
+				second = [2]int{x, y}
+			}
+		}
+	}
+	if first[0] == second[0] || first[1] == second[1] {
+		t.Errorf("first pixels lit are %v and %v, want them spread apart", first, second)
+	}
+}
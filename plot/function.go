@@ -0,0 +1,146 @@
+package plot
+
+import (
+	"math"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+)
+
+// Sampling limits for Function and Parametric.
+const (
+	initialSamples = 128 // evenly spaced intervals sampled before refining
+	maxRefinement  = 12  // times an interval may be halved
+	flatLength     = 2   // pixel length below which a segment is drawn as is
+)
+
+// Function draws y = f(x) for x from xMin to xMax, in canvas coordinates.
+// On a canvas created with WithInvertedY(), y increases upward, so functions
+// appear as they would on paper.
+//
+// The curve is sampled adaptively: intervals are halved until each segment
+// spans at most a couple of pixels, and consecutive samples are joined with
+// draw.Line. Where f returns NaN or an infinite value, or jumps by more than
+// the refinement can resolve (such as tan x at its asymptotes), the curve is
+// broken instead of joined by a spurious vertical line. Parts of the curve
+// outside the canvas are clipped.
+func Function(c *canvas.Canvas, f func(x float64) float64, xMin, xMax float64) {
+	Parametric(c, identity, f, xMin, xMax)
+}
+
+// FunctionColor draws y = f(x) like Function in the given color.
+func FunctionColor(c *canvas.Canvas, f func(x float64) float64, xMin, xMax float64, color canvas.Color) {
+	ParametricColor(c, identity, f, xMin, xMax, color)
+}
+
+// Parametric draws the curve (fx(t), fy(t)) for t from tMin to tMax, in canvas
+// coordinates. Sampling, discontinuities, and clipping are handled as in
+// Function.
+func Parametric(c *canvas.Canvas, fx, fy func(t float64) float64, tMin, tMax float64) {
+	parametric(c, func(startX, startY, endX, endY float64) {
+		draw.Line(c, startX, startY, endX, endY)
+	}, fx, fy, tMin, tMax)
+}
+
+// ParametricColor draws a parametric curve like Parametric in the given color.
+func ParametricColor(c *canvas.Canvas, fx, fy func(t float64) float64, tMin, tMax float64, color canvas.Color) {
+	parametric(c, func(startX, startY, endX, endY float64) {
+		draw.LineColor(c, startX, startY, endX, endY, color)
+	}, fx, fy, tMin, tMax)
+}
+
+// identity returns x, for plotting functions as parametric curves.
+func identity(x float64) float64 {
+	return x
+}
+
+// parametric samples a curve and joins the samples with line.
+func parametric(c *canvas.Canvas, line func(startX, startY, endX, endY float64), fx, fy func(t float64) float64, tMin, tMax float64) {
+	if math.IsNaN(tMin) || math.IsNaN(tMax) || math.IsInf(tMin, 0) || math.IsInf(tMax, 0) {
+		return
+	}
+	curve := curve{
+		bottom: float64(c.Height() - 1),
+		fx:     fx,
+		fy:     fy,
+		line:   line,
+		right:  float64(c.Width() - 1),
+	}
+
+	step := (tMax - tMin) / initialSamples
+	start := curve.sample(tMin)
+	for index := 1; index <= initialSamples; index++ {
+		t := tMin + float64(index)*step
+		if index == initialSamples {
+			t = tMax
+		}
+		end := curve.sample(t)
+		curve.refine(start, end, 0)
+		start = end
+	}
+}
+
+// curve draws one adaptively sampled parametric curve.
+type curve struct {
+	bottom float64                                  // last pixel row
+	fx     func(t float64) float64                  // x coordinate of the curve
+	fy     func(t float64) float64                  // y coordinate of the curve
+	line   func(startX, startY, endX, endY float64) // draws a segment
+	right  float64                                  // last pixel column
+}
+
+// sample is one evaluated point of a curve.
+type sample struct {
+	t     float64 // parameter value
+	x     float64 // canvas x
+	y     float64 // canvas y
+	valid bool    // whether x and y are finite
+}
+
+// sample evaluates the curve at t.
+func (curve curve) sample(t float64) sample {
+	x, y := curve.fx(t), curve.fy(t)
+	valid := !math.IsNaN(x) && !math.IsInf(x, 0) && !math.IsNaN(y) && !math.IsInf(y, 0)
+	return sample{t: t, x: x, y: y, valid: valid}
+}
+
+// refine draws the curve between two samples, halving the interval until the
+// segment is short enough to draw or the refinement limit is reached.
+func (curve curve) refine(start, end sample, depth int) {
+	if start.valid && end.valid {
+		length := math.Hypot(end.x-start.x, end.y-start.y)
+		if length <= flatLength {
+			curve.segment(start, end)
+			return
+		}
+		if curve.outside(start, end) {
+			return
+		}
+	}
+	if depth >= maxRefinement {
+		// Still long at this resolution: a discontinuity, so leave a gap
+		return
+	}
+
+	middle := curve.sample((start.t + end.t) / 2)
+	if !start.valid && !middle.valid && !end.valid {
+		return
+	}
+	curve.refine(start, middle, depth+1)
+	curve.refine(middle, end, depth+1)
+}
+
+// outside reports whether a segment lies entirely beyond one edge of the
+// canvas. Such segments are not refined further.
+func (curve curve) outside(start, end sample) bool {
+	return (start.x < 0 && end.x < 0) || (start.x > curve.right && end.x > curve.right) ||
+		(start.y < 0 && end.y < 0) || (start.y > curve.bottom && end.y > curve.bottom)
+}
+
+// segment draws the part of a line between two samples that lies on the canvas.
+func (curve curve) segment(start, end sample) {
+	x0, y0, x1, y1, ok := clipSegment(start.x, start.y, end.x, end.y, 0, 0, curve.right, curve.bottom)
+	if ok {
+		curve.line(x0, y0, x1, y1)
+	}
+}
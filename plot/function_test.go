@@ -0,0 +1,165 @@
+package plot
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+// litRows returns the rows lit in a column of the canvas.
+func litRows(c *canvas.Canvas, x float64) []float64 {
+	var rows []float64
+	for y := 0.0; y < float64(c.Height()); y++ {
+		if c.Get(x, y) {
+			rows = append(rows, y)
+		}
+	}
+	return rows
+}
+
+func TestFunctionLine(t *testing.T) {
+	actual := canvas.New(12, 12)
+	Function(actual, func(x float64) float64 { return x }, 0, 11)
+
+	expected := canvas.New(12, 12)
+	draw.Line(expected, 0, 0, 11, 11)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestFunctionContinuous(t *testing.T) {
+	c := canvas.New(80, 40)
+	Function(c, func(x float64) float64 { return 20 + 18*math.Sin(x/6) }, 0, 79)
+	stippletest.PrintVisual(t, "TestFunctionContinuous", c)
+
+	// Each column is lit, and its pixels touch the next column's
+	for x := 0.0; x < 80; x++ {
+		rows := litRows(c, x)
+		if len(rows) == 0 {
+			t.Fatalf("column %.0f is empty", x)
+		}
+		if x == 79 {
+			continue
+		}
+		next := litRows(c, x+1)
+		if len(next) > 0 && (next[0] > rows[len(rows)-1]+1 || next[len(next)-1] < rows[0]-1) {
+			t.Errorf("columns %.0f and %.0f are not connected: %v, %v", x, x+1, rows, next)
+		}
+	}
+}
+
+func TestFunctionBreaksAtNaN(t *testing.T) {
+	c := canvas.New(10, 8)
+	Function(c, func(x float64) float64 {
+		if x > 4.2 && x < 6 {
+			return math.NaN()
+		}
+		return 3
+	}, 0, 9)
+
+	for x := 0.0; x < 10; x++ {
+		lit := c.Get(x, 3)
+		if expected := x <= 4 || x >= 6; lit != expected {
+			t.Errorf("Get(%.0f, 3) = %v, want %v", x, lit, expected)
+		}
+	}
+}
+
+func TestFunctionBreaksAtJumps(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(x float64) float64
+	}{
+		{"step", func(x float64) float64 {
+			if x < 5 {
+				return 1
+			}
+			return 18
+		}},
+		{"pole", func(x float64) float64 { return 10 + 1/(x-5.01) }},
+		{"infinity", func(x float64) float64 { return 10 + 1/(x-5) }},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := canvas.New(10, 20)
+			Function(c, testCase.f, 0, 9)
+
+			for x := 0.0; x < 10; x++ {
+				if rows := litRows(c, x); len(rows) > 12 {
+					t.Errorf("column %.0f lights rows %v, want no vertical across the jump", x, rows)
+				}
+			}
+		})
+	}
+}
+
+func TestFunctionClipped(t *testing.T) {
+	c := canvas.New(12, 12)
+	Function(c, func(x float64) float64 { return 1e9 * (x - 5) }, 0, 11)
+	Function(c, func(x float64) float64 { return x * x }, -100, 100)
+
+	// Only the parabola from x = 0 to about 3.3 is on the canvas
+	for x := 0.0; x < 12; x++ {
+		if lit := len(litRows(c, x)) > 0; lit != (x <= 3) {
+			t.Errorf("column %.0f lit = %v, want %v", x, lit, x <= 3)
+		}
+	}
+	if !c.Get(0, 0) || !c.Get(3, 11) {
+		t.Error("visible part of the parabola is not drawn")
+	}
+}
+
+func TestFunctionInvertedY(t *testing.T) {
+	// With inverted Y, y = x rises to the right
+	c := canvas.New(8, 8, canvas.WithInvertedY())
+	Function(c, func(x float64) float64 { return x }, 0, 7)
+
+	expected := canvas.New(8, 8)
+	draw.Line(expected, 0, 7, 7, 0)
+	if c.Frame() != expected.Frame() {
+		t.Errorf("Frame() =\n%s\nwant:\n%s", c.Frame(), expected.Frame())
+	}
+}
+
+func TestParametricCircle(t *testing.T) {
+	c := canvas.New(40, 40)
+	ParametricColor(c,
+		func(t float64) float64 { return 20 + 15*math.Cos(t) },
+		func(t float64) float64 { return 20 + 15*math.Sin(t) },
+		0, 2*math.Pi, canvas.ColorRed)
+	stippletest.PrintVisual(t, "TestParametricCircle", c)
+
+	lit := 0
+	for y := 0.0; y < 40; y++ {
+		for x := 0.0; x < 40; x++ {
+			if !c.Get(x, y) {
+				continue
+			}
+			lit++
+			if distance := math.Hypot(x-20, y-20); math.Abs(distance-15) > 1.5 {
+				t.Errorf("pixel (%.0f, %.0f) is %.1f from the center, want about 15", x, y, distance)
+			}
+		}
+	}
+	// The circumference is about 94 pixels
+	if lit < 80 {
+		t.Errorf("circle lights %d pixels, want a closed curve", lit)
+	}
+	for _, point := range [][2]float64{{35, 20}, {20, 35}, {5, 20}, {20, 5}} {
+		if !c.Get(point[0], point[1]) {
+			t.Errorf("pixel (%.0f, %.0f) on the circle is not set", point[0], point[1])
+		}
+	}
+}
+
+func TestParametricInvalidRange(t *testing.T) {
+	c := canvas.New(10, 10)
+	called := false
+	Parametric(c, func(t float64) float64 { called = true; return t }, math.Sin, math.NaN(), 1)
+	if called {
+		t.Error("Parametric() sampled a NaN range")
+	}
+}
@@ -0,0 +1,194 @@
+package plot
+
+import (
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// colorbarColumns is the width of the colorbar, in terminal cells.
+const colorbarColumns = 2
+
+// Heatmap draws a matrix of values as colored cells.
+//
+// In color mode, each terminal cell (each pixel on a WithHalfBlock canvas)
+// is filled with the ramp color of its value, which needs a canvas created
+// with WithColor. In density mode, each cell lights 0 to 8 of its dots in
+// proportion to its value (0 to 2 on a half-block canvas), for monochrome
+// terminals.
+type Heatmap struct {
+	Values      [][]float64 // rows of values, top row first; NaN leaves an area empty
+	Ramp        Ramp        // colors from low to high values, nil for RampViridis
+	Low         float64     // value at the start of the ramp
+	High        float64     // value at the end of the ramp; when not above Low, both fit the data
+	Interpolate bool        // whether to blend neighboring values instead of using the nearest
+	Density     bool        // whether to draw dot density instead of color
+	Colorbar    bool        // whether to draw a colorbar with the range on the right
+}
+
+// Draw renders the heatmap onto the whole canvas, stretching the matrix to
+// fit. With Colorbar, the rightmost cells show the ramp from High at the top
+// to Low at the bottom, with both values labeled. The canvas should be
+// cleared first if it already holds a frame.
+func (heatmap Heatmap) Draw(c *canvas.Canvas) {
+	if c.Cols() == 0 || c.Rows() == 0 {
+		return
+	}
+	cellWidth, cellHeight := c.CellSize()
+	low, high := heatmap.bounds()
+	areaColumns := c.Cols()
+
+	if heatmap.Colorbar {
+		highLabel, lowLabel := formatValue(high), formatValue(low)
+		labelWidth := max(utf8.RuneCountInString(highLabel), utf8.RuneCountInString(lowLabel))
+		barColumn := c.Cols() - labelWidth - colorbarColumns - 1
+		if barColumn > 1 {
+			areaColumns = barColumn - 1
+			bar := heatmapArea{
+				left:   barColumn * cellWidth,
+				width:  colorbarColumns * cellWidth,
+				height: c.Rows() * cellHeight,
+			}
+			bar.fill(c, heatmap, func(_, y, _, height int) float64 {
+				if height == 1 {
+					return 1
+				}
+				return 1 - float64(y)/float64(height-1)
+			})
+			labelColumn := barColumn + colorbarColumns + 1
+			c.SetText(labelColumn, 0, highLabel)
+			c.SetText(labelColumn, c.Rows()-1, lowLabel)
+		}
+	}
+
+	area := heatmapArea{width: areaColumns * cellWidth, height: c.Rows() * cellHeight}
+	area.fill(c, heatmap, func(x, y, width, height int) float64 {
+		value := heatmap.sample(x, y, width, height)
+		if high == low && !math.IsNaN(value) {
+			return 0.5
+		}
+		return (value - low) / (high - low)
+	})
+}
+
+// bounds returns the range of values mapped onto the ramp.
+func (heatmap Heatmap) bounds() (low, high float64) {
+	if heatmap.High > heatmap.Low {
+		return heatmap.Low, heatmap.High
+	}
+	low, high = math.Inf(1), math.Inf(-1)
+	for _, row := range heatmap.Values {
+		for _, value := range row {
+			if !math.IsNaN(value) && !math.IsInf(value, 0) {
+				low, high = math.Min(low, value), math.Max(high, value)
+			}
+		}
+	}
+	if low > high {
+		return 0, 1
+	}
+	return low, high
+}
+
+// value returns the value at a row and column of the matrix, or NaN outside it.
+func (heatmap Heatmap) value(row, column int) float64 {
+	if row < 0 || row >= len(heatmap.Values) || column < 0 || column >= len(heatmap.Values[row]) {
+		return math.NaN()
+	}
+	return heatmap.Values[row][column]
+}
+
+// sample returns the value for area unit (x, y) of a width by height grid,
+// stretching the matrix over the grid.
+func (heatmap Heatmap) sample(x, y, width, height int) float64 {
+	rows := len(heatmap.Values)
+	if rows == 0 {
+		return math.NaN()
+	}
+	columns := len(heatmap.Values[0])
+	// Matrix coordinates of the unit's center, with values at whole numbers
+	row := (float64(y)+0.5)/float64(height)*float64(rows) - 0.5
+	column := (float64(x)+0.5)/float64(width)*float64(columns) - 0.5
+	nearest := heatmap.value(int(math.Round(row)), int(math.Round(column)))
+	if !heatmap.Interpolate {
+		return nearest
+	}
+
+	row = math.Max(0, math.Min(row, float64(rows-1)))
+	column = math.Max(0, math.Min(column, float64(columns-1)))
+	top, left := int(row), int(column)
+	bottom, right := min(top+1, rows-1), min(left+1, columns-1)
+	rowFraction, columnFraction := row-float64(top), column-float64(left)
+
+	topLeft, topRight := heatmap.value(top, left), heatmap.value(top, right)
+	bottomLeft, bottomRight := heatmap.value(bottom, left), heatmap.value(bottom, right)
+	if math.IsNaN(topLeft) || math.IsNaN(topRight) || math.IsNaN(bottomLeft) || math.IsNaN(bottomRight) {
+		return nearest
+	}
+	upper := topLeft + (topRight-topLeft)*columnFraction
+	lower := bottomLeft + (bottomRight-bottomLeft)*columnFraction
+	return upper + (lower-upper)*rowFraction
+}
+
+// heatmapArea is a block of whole terminal cells filled by a heatmap or its
+// colorbar, in screen pixels.
+type heatmapArea struct {
+	height int // height in pixels
+	left   int // first pixel column
+	width  int // width in pixels
+}
+
+// fill draws the area unit by unit. Position returns the ramp position, from
+// 0 to 1, of unit (x, y) in a width by height grid of units; NaN leaves the
+// unit empty.
+func (area heatmapArea) fill(c *canvas.Canvas, heatmap Heatmap, position func(x, y, width, height int) float64) {
+	ramp := heatmap.Ramp
+	if ramp == nil {
+		ramp = RampViridis
+	}
+	unitWidth, unitHeight := c.CellSize()
+	if c.HalfBlock() && !heatmap.Density {
+		unitWidth, unitHeight = 1, 1
+	}
+	ranks := ditherRanks(unitWidth, unitHeight)
+	units := unitWidth * unitHeight
+	columns, rows := area.width/unitWidth, area.height/unitHeight
+
+	for unitY := range rows {
+		for unitX := range columns {
+			value := position(unitX, unitY, columns, rows)
+			if math.IsNaN(value) {
+				continue
+			}
+			value = math.Max(0, math.Min(1, value))
+			lit := units
+			color := canvas.ColorDefault
+			if heatmap.Density {
+				lit = int(math.Round(value * float64(units)))
+			} else {
+				color = ramp.At(value)
+			}
+
+			for offsetY := range unitHeight {
+				for offsetX := range unitWidth {
+					if ranks[offsetY][offsetX] >= lit {
+						continue
+					}
+					x := area.left + unitX*unitWidth + offsetX
+					y := unitY*unitHeight + offsetY
+					if c.InvertedY() {
+						y = c.Height() - 1 - y
+					}
+					c.SetColor(float64(x), float64(y), color)
+				}
+			}
+		}
+	}
+}
+
+// formatValue returns a short label for a colorbar value.
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', 3, 64)
+}
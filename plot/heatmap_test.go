@@ -0,0 +1,190 @@
+package plot
+
+import (
+	"math"
+	"math/bits"
+	"strings"
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/stippletest"
+)
+
+// cellDots returns the number of lit dots in each cell of a frame's first row.
+func cellDots(c *canvas.Canvas) []int {
+	var counts []int
+	for _, cell := range strings.Split(c.Frame(), "\n")[0] {
+		counts = append(counts, bits.OnesCount8(uint8(cell-canvas.BrailleOffset)))
+	}
+	return counts
+}
+
+func TestHeatmapColor(t *testing.T) {
+	c := canvas.NewCells(4, 2, canvas.WithColor())
+	Heatmap{Values: [][]float64{{0, 1}, {2, 3}}, Ramp: RampBasic}.Draw(c)
+
+	// Each value covers 2x1 cells; positions 0, 1/3, 2/3, and 1 along the ramp
+	tests := []struct {
+		column, row int
+		expected    canvas.Color
+	}{
+		{0, 0, canvas.ColorBlue},
+		{1, 0, canvas.ColorBlue},
+		{2, 0, canvas.ColorCyan},
+		{0, 1, canvas.ColorYellow},
+		{3, 1, canvas.ColorRed},
+	}
+	for _, testCase := range tests {
+		x, y := c.CellToPixel(testCase.column, testCase.row)
+		if color := c.GetColor(x, y); color != testCase.expected {
+			t.Errorf("cell (%d, %d) color = %d, want %d", testCase.column, testCase.row, color, testCase.expected)
+		}
+	}
+	if frame := c.Frame(); strings.Count(frame, "⣿") != 8 {
+		t.Errorf("Frame() = %q, want every cell filled", frame)
+	}
+}
+
+func TestHeatmapTruecolor(t *testing.T) {
+	c := canvas.NewCells(3, 1, canvas.WithColor())
+	Heatmap{Values: [][]float64{{0, 5, 10}}, Ramp: RampGrayscale}.Draw(c)
+
+	for column, expected := range []canvas.Color{canvas.RGB(0, 0, 0), canvas.RGB(128, 128, 128), canvas.RGB(255, 255, 255)} {
+		x, y := c.CellToPixel(column, 0)
+		if color := c.GetColor(x, y); color != expected {
+			t.Errorf("cell %d color = %#x, want %#x", column, uint32(color), uint32(expected))
+		}
+	}
+}
+
+func TestHeatmapDensity(t *testing.T) {
+	c := canvas.NewCells(5, 1)
+	Heatmap{Values: [][]float64{{0, 0.25, 0.5, math.NaN(), 1}}, Low: 0, High: 1, Density: true}.Draw(c)
+
+	expected := []int{0, 2, 4, 0, 8}
+	counts := cellDots(c)
+	for index := range expected {
+		if counts[index] != expected[index] {
+			t.Errorf("cell dots = %v, want %v", counts, expected)
+			break
+		}
+	}
+}
+
+func TestHeatmapPartialCells(t *testing.T) {
+	// A canvas 10 pixels high has two whole braille rows of 4 pixels, so it
+	// dithers the same as a canvas of exactly 2 rows
+	build := func(c *canvas.Canvas) string {
+		Heatmap{Values: [][]float64{{0, 0.25, 0.5, 1}}, Low: 0, High: 1, Density: true}.Draw(c)
+		return c.Frame()
+	}
+
+	partial, full := build(canvas.New(8, 10)), build(canvas.NewCells(4, 2))
+	if partial != full {
+		t.Errorf("heatmap on an 8x10 canvas:\n%s\nwant the same as on 4x2 cells:\n%s", partial, full)
+	}
+}
+
+func TestHeatmapInterpolate(t *testing.T) {
+	tests := []struct {
+		name        string
+		interpolate bool
+		expected    []int
+	}{
+		{"nearest", false, []int{0, 0, 8, 8}},
+		{"interpolated", true, []int{0, 2, 6, 8}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := canvas.NewCells(4, 1)
+			Heatmap{Values: [][]float64{{0, 1}}, Interpolate: testCase.interpolate, Density: true}.Draw(c)
+
+			counts := cellDots(c)
+			for index := range testCase.expected {
+				if counts[index] != testCase.expected[index] {
+					t.Errorf("cell dots = %v, want %v", counts, testCase.expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestHeatmapColorbar(t *testing.T) {
+	c := canvas.NewCells(20, 4, canvas.WithColor())
+	Heatmap{Values: [][]float64{{0, 3}}, Ramp: RampBasic, Colorbar: true}.Draw(c)
+	stippletest.PrintVisual(t, "TestHeatmapColorbar", c)
+
+	// Heat area in columns 0-14, colorbar in 16-17, labels in 19
+	if c.Text(19, 0) != '3' || c.Text(19, 3) != '0' {
+		t.Errorf("labels = %q and %q, want '3' and '0'", c.Text(19, 0), c.Text(19, 3))
+	}
+	for _, testCase := range []struct {
+		column, row int
+		expected    canvas.Color
+		lit         bool
+	}{
+		{14, 0, canvas.ColorRed, true},
+		{15, 0, canvas.ColorDefault, false},
+		{16, 0, canvas.ColorRed, true},
+		{17, 3, canvas.ColorBlue, true},
+		{18, 1, canvas.ColorDefault, false},
+	} {
+		x, y := c.CellToPixel(testCase.column, testCase.row)
+		if lit := c.Get(x, y); lit != testCase.lit {
+			t.Errorf("cell (%d, %d) lit = %v, want %v", testCase.column, testCase.row, lit, testCase.lit)
+		}
+		if color := c.GetColor(x, y); color != testCase.expected {
+			t.Errorf("cell (%d, %d) color = %d, want %d", testCase.column, testCase.row, color, testCase.expected)
+		}
+	}
+}
+
+func TestHeatmapHalfBlock(t *testing.T) {
+	// Half-block pixels each get their own color
+	c := canvas.New(1, 2, canvas.WithHalfBlock(), canvas.WithColor())
+	Heatmap{Values: [][]float64{{0}, {1}}, Ramp: RampBasic}.Draw(c)
+
+	if color := c.GetColor(0, 0); color != canvas.ColorBlue {
+		t.Errorf("GetColor(0, 0) = %d, want %d", color, canvas.ColorBlue)
+	}
+	if color := c.GetColor(0, 1); color != canvas.ColorRed {
+		t.Errorf("GetColor(0, 1) = %d, want %d", color, canvas.ColorRed)
+	}
+}
+
+func TestHeatmapInvertedY(t *testing.T) {
+	heatmap := Heatmap{Values: [][]float64{{0, 1, 2}, {3, 4, 5}}, Density: true, Colorbar: true}
+
+	normal := canvas.NewCells(20, 4)
+	heatmap.Draw(normal)
+	inverted := canvas.NewCells(20, 4, canvas.WithInvertedY())
+	heatmap.Draw(inverted)
+
+	if normal.Frame() != inverted.Frame() {
+		t.Errorf("inverted frame differs:\n%s\nwant:\n%s", inverted.Frame(), normal.Frame())
+	}
+}
+
+func TestHeatmapBounds(t *testing.T) {
+	tests := []struct {
+		name         string
+		heatmap      Heatmap
+		expectedLow  float64
+		expectedHigh float64
+	}{
+		{"fit", Heatmap{Values: [][]float64{{3, math.NaN()}, {-2, math.Inf(1)}}}, -2, 3},
+		{"fixed", Heatmap{Values: [][]float64{{3}}, Low: -10, High: 10}, -10, 10},
+		{"empty", Heatmap{}, 0, 1},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			low, high := testCase.heatmap.bounds()
+			if low != testCase.expectedLow || high != testCase.expectedHigh {
+				t.Errorf("bounds() = (%v, %v), want (%v, %v)", low, high, testCase.expectedLow, testCase.expectedHigh)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package plot
+
+import (
+	"math"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// Histogram is a named set of values drawn as the counts of equal-width bins
+// spanning the values. NaN and infinite values are skipped.
+type Histogram struct {
+	Name        string       // legend label, empty for no legend entry
+	Values      []float64    // values to count
+	Bins        int          // number of bins, 0 for Sturges' rule
+	Color       canvas.Color // bar color
+	Orientation Orientation  // direction in which the bars grow
+}
+
+// AddHistogram adds a histogram to the chart. Each bin is a bar from its lower
+// edge to its upper edge, growing from zero to the number of values in it.
+func (chart *Chart) AddHistogram(histogram Histogram) {
+	chart.layers = append(chart.layers, histogram)
+}
+
+// Bin counts values into bins of equal width between the smallest and largest
+// finite value. It returns the bins+1 bin edges and the count of each bin; the
+// largest value falls in the last bin. When every value is the same, the bins
+// span one unit around it. With no finite values or bins below 1, Bin returns
+// nil slices.
+func Bin(values []float64, bins int) (edges []float64, counts []int) {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, value := range values {
+		if !math.IsNaN(value) && !math.IsInf(value, 0) {
+			low, high = math.Min(low, value), math.Max(high, value)
+		}
+	}
+	if bins < 1 || low > high {
+		return nil, nil
+	}
+	if low == high {
+		low, high = low-0.5, high+0.5
+	}
+
+	width := (high - low) / float64(bins)
+	if math.IsInf(width, 0) {
+		// The span of extreme values overflows, but its fraction does not
+		width = high/float64(bins) - low/float64(bins)
+	}
+	edges = make([]float64, bins+1)
+	for index := range edges {
+		edges[index] = low + float64(index)*width
+	}
+	edges[bins] = high
+
+	counts = make([]int, bins)
+	for _, value := range values {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		// The distance from low can still overflow to infinity, so the
+		// position is bounded before it is converted to an index
+		position := (value - low) / width
+		index := 0
+		switch {
+		case position >= float64(bins-1):
+			index = bins - 1
+		case position > 0:
+			index = int(position)
+		}
+		counts[index]++
+	}
+	return edges, counts
+}
+
+// bins returns the number of bins, applying Sturges' rule when none is set.
+func (histogram Histogram) bins() int {
+	if histogram.Bins > 0 {
+		return histogram.Bins
+	}
+	finite := 0
+	for _, value := range histogram.Values {
+		if !math.IsNaN(value) && !math.IsInf(value, 0) {
+			finite++
+		}
+	}
+	if finite == 0 {
+		return 1
+	}
+	return int(math.Ceil(math.Log2(float64(finite)))) + 1
+}
+
+// rectangles returns one bar per non-empty bin.
+func (histogram Histogram) rectangles() []bar {
+	edges, counts := Bin(histogram.Values, histogram.bins())
+	var rectangles []bar
+	for index, count := range counts {
+		if count == 0 {
+			continue
+		}
+		rectangles = append(rectangles, bar{
+			color: histogram.Color,
+			end:   float64(count),
+			high:  edges[index+1],
+			low:   edges[index],
+		})
+	}
+	return rectangles
+}
+
+func (histogram Histogram) extent(visit func(x, y float64)) {
+	barExtent(histogram.rectangles(), histogram.Orientation, visit)
+}
+
+func (histogram Histogram) draw(c *canvas.Canvas, projection projection) {
+	drawBars(c, projection, histogram.rectangles(), histogram.Orientation)
+}
+
+func (histogram Histogram) legend(visit func(name, symbol string, color canvas.Color)) {
+	if histogram.Name != "" {
+		visit(histogram.Name, legendBar, histogram.Color)
+	}
+}
@@ -0,0 +1,104 @@
+package plot
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+func TestBin(t *testing.T) {
+	tests := []struct {
+		name           string
+		values         []float64
+		bins           int
+		expectedEdges  []float64
+		expectedCounts []int
+	}{
+		{"even", []float64{0, 1, 2, 3, 4}, 2, []float64{0, 2, 4}, []int{2, 3}},
+		{"maximum in last bin", []float64{0, 10}, 5, []float64{0, 2, 4, 6, 8, 10}, []int{1, 0, 0, 0, 1}},
+		{"single value", []float64{5, 5}, 2, []float64{4.5, 5, 5.5}, []int{0, 2}},
+		{"skips invalid", []float64{1, math.NaN(), 3, math.Inf(1)}, 1, []float64{1, 3}, []int{2}},
+		{"extreme values", []float64{-math.MaxFloat64, 0, math.MaxFloat64}, 2, []float64{-math.MaxFloat64, 0, math.MaxFloat64}, []int{1, 2}},
+		{"no values", nil, 3, nil, nil},
+		{"no bins", []float64{1, 2}, 0, nil, nil},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			edges, counts := Bin(testCase.values, testCase.bins)
+			if !slices.Equal(edges, testCase.expectedEdges) {
+				t.Errorf("Bin() edges = %v, want %v", edges, testCase.expectedEdges)
+			}
+			if !slices.Equal(counts, testCase.expectedCounts) {
+				t.Errorf("Bin() counts = %v, want %v", counts, testCase.expectedCounts)
+			}
+		})
+	}
+}
+
+func TestHistogramDefaultBins(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		expected int
+	}{
+		{"sturges", make([]float64, 8), 4},
+		{"rounds up", make([]float64, 9), 5},
+		{"single", []float64{1}, 1},
+		{"empty", nil, 1},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			if bins := (Histogram{Values: testCase.values}).bins(); bins != testCase.expected {
+				t.Errorf("bins() = %d, want %d", bins, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestHistogramDraw(t *testing.T) {
+	actual := canvas.New(12, 12)
+	Histogram{Values: []float64{1, 1, 1, 9}, Bins: 2}.draw(actual, plotArea(actual))
+
+	// Bins [1, 5) and [5, 9] hold 3 and 1 values
+	expected := canvas.New(12, 12)
+	draw.RectangleFilled(expected, 1, 7, 4, 4)
+	draw.RectangleFilled(expected, 5, 9, 4, 2)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestHistogramHorizontal(t *testing.T) {
+	actual := canvas.New(12, 12)
+	Histogram{Values: []float64{1, 1, 1, 9}, Bins: 2, Orientation: Horizontal}.draw(actual, plotArea(actual))
+
+	expected := canvas.New(12, 12)
+	draw.RectangleFilled(expected, 0, 5, 4, 4)
+	draw.RectangleFilled(expected, 0, 1, 2, 4)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestHistogramChart(t *testing.T) {
+	var values []float64
+	for index := range 200 {
+		values = append(values, math.Sin(float64(index))*math.Cos(float64(index)/3)*10)
+	}
+	c := canvas.NewCells(30, 8)
+	chart := New()
+	chart.AddHistogram(Histogram{Name: "noise", Values: values, Bins: 10})
+	chart.Draw(c)
+	stippletest.PrintVisual(t, "TestHistogramChart", c)
+
+	xRange, yRange := chart.ranges()
+	if xRange.low > -10 || xRange.high < 10 || yRange.low != 0 {
+		t.Errorf("ranges() = x [%v, %v], y [%v, %v], want x around [-10, 10] and y from 0",
+			xRange.low, xRange.high, yRange.low, yRange.high)
+	}
+	if entries := legendEntries(Histogram{Name: "noise"}); len(entries) != 1 || entries[0].symbol != legendBar {
+		t.Errorf("legend() visited %v, want one %q entry", entries, legendBar)
+	}
+}
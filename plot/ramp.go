@@ -0,0 +1,69 @@
+package plot
+
+import (
+	"math"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// Ramp maps positions from 0 to 1 to colors, through stops spaced evenly from
+// the first (at 0) to the last (at 1). Between two truecolor stops the color
+// is interpolated; otherwise the nearest stop is used, so ramps of standard
+// colors work on terminals limited to 8 colors.
+type Ramp []canvas.Color
+
+// Predefined ramps.
+var (
+	// RampViridis runs from dark purple through teal to yellow, with evenly
+	// increasing lightness.
+	RampViridis = Ramp{
+		canvas.RGB(68, 1, 84),
+		canvas.RGB(59, 82, 139),
+		canvas.RGB(33, 145, 140),
+		canvas.RGB(94, 201, 98),
+		canvas.RGB(253, 231, 37),
+	}
+	// RampGrayscale runs from black to white.
+	RampGrayscale = Ramp{canvas.RGB(0, 0, 0), canvas.RGB(255, 255, 255)}
+	// RampDiverging runs from blue through light gray to red, for values on
+	// either side of a midpoint.
+	RampDiverging = Ramp{canvas.RGB(59, 76, 192), canvas.RGB(221, 221, 221), canvas.RGB(180, 4, 38)}
+	// RampBasic runs from blue to red through standard colors.
+	RampBasic = Ramp{canvas.ColorBlue, canvas.ColorCyan, canvas.ColorGreen, canvas.ColorYellow, canvas.ColorRed}
+	// RampBasicDiverging runs from blue through white to red in standard colors.
+	RampBasicDiverging = Ramp{canvas.ColorBlue, canvas.ColorCyan, canvas.ColorWhite, canvas.ColorYellow, canvas.ColorRed}
+)
+
+// At returns the color at a position from 0 to 1. Positions outside that range
+// are clamped; NaN and an empty ramp return ColorDefault.
+func (ramp Ramp) At(position float64) canvas.Color {
+	if len(ramp) == 0 || math.IsNaN(position) {
+		return canvas.ColorDefault
+	}
+	scaled := math.Max(0, math.Min(1, position)) * float64(len(ramp)-1)
+	index := int(scaled)
+	if index >= len(ramp)-1 {
+		return ramp[len(ramp)-1]
+	}
+	fraction := scaled - float64(index)
+
+	from, to := ramp[index], ramp[index+1]
+	fromRed, fromGreen, fromBlue, fromOK := from.Components()
+	toRed, toGreen, toBlue, toOK := to.Components()
+	if !fromOK || !toOK {
+		if fraction < 0.5 {
+			return from
+		}
+		return to
+	}
+	return canvas.RGB(
+		blend(fromRed, toRed, fraction),
+		blend(fromGreen, toGreen, fraction),
+		blend(fromBlue, toBlue, fraction),
+	)
+}
+
+// blend interpolates between two color components.
+func blend(from, to uint8, fraction float64) uint8 {
+	return uint8(math.Round(float64(from) + (float64(to)-float64(from))*fraction))
+}
@@ -0,0 +1,57 @@
+package plot
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+func TestRampAt(t *testing.T) {
+	gray := Ramp{canvas.RGB(0, 0, 0), canvas.RGB(200, 100, 50)}
+
+	tests := []struct {
+		name     string
+		ramp     Ramp
+		position float64
+		expected canvas.Color
+	}{
+		{"start", gray, 0, canvas.RGB(0, 0, 0)},
+		{"end", gray, 1, canvas.RGB(200, 100, 50)},
+		{"interpolated", gray, 0.5, canvas.RGB(100, 50, 25)},
+		{"clamped below", gray, -1, canvas.RGB(0, 0, 0)},
+		{"clamped above", gray, 2, canvas.RGB(200, 100, 50)},
+		{"basic nearest lower", RampBasic, 0.1, canvas.ColorBlue},
+		{"basic nearest upper", RampBasic, 0.2, canvas.ColorCyan},
+		{"basic end", RampBasic, 1, canvas.ColorRed},
+		{"middle stop", RampDiverging, 0.5, canvas.RGB(221, 221, 221)},
+		{"NaN", gray, math.NaN(), canvas.ColorDefault},
+		{"empty", nil, 0.5, canvas.ColorDefault},
+		{"single stop", Ramp{canvas.ColorGreen}, 0.7, canvas.ColorGreen},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			if color := testCase.ramp.At(testCase.position); color != testCase.expected {
+				t.Errorf("At(%v) = %#x, want %#x", testCase.position, uint32(color), uint32(testCase.expected))
+			}
+		})
+	}
+}
+
+func TestPredefinedRamps(t *testing.T) {
+	for name, ramp := range map[string]Ramp{
+		"viridis":         RampViridis,
+		"grayscale":       RampGrayscale,
+		"diverging":       RampDiverging,
+		"basic":           RampBasic,
+		"basic diverging": RampBasicDiverging,
+	} {
+		if len(ramp) < 2 {
+			t.Errorf("%s ramp has %d stops, want at least 2", name, len(ramp))
+		}
+		if ramp.At(0) == ramp.At(1) {
+			t.Errorf("%s ramp has the same color at both ends", name)
+		}
+	}
+}
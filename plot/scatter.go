@@ -0,0 +1,217 @@
+package plot
+
+import (
+	"math"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+)
+
+// Marker is the shape drawn at each point of a scatter plot.
+type Marker uint8
+
+// Available markers.
+const (
+	MarkerDot    Marker = iota // filled disc, a single pixel by default
+	MarkerPlus                 // horizontal and vertical strokes
+	MarkerCross                // diagonal strokes
+	MarkerCircle               // circle outline
+	MarkerSquare               // square outline
+)
+
+// defaultMarkerRadius is the radius of markers other than MarkerDot when no size is given.
+const defaultMarkerRadius = 2
+
+// markerSymbols are the legend symbols for each marker.
+var markerSymbols = [...]string{
+	MarkerDot:    "•",
+	MarkerPlus:   "+",
+	MarkerCross:  "×",
+	MarkerCircle: "○",
+	MarkerSquare: "□",
+}
+
+// Scatter is a named set of data points drawn as markers.
+// When X is nil, the points are spaced at x = 0, 1, 2, and so on. Points that
+// are NaN, infinite, or not positive on a log axis are skipped. Markers default
+// to a radius of 2 pixels, except MarkerDot and density mode, which default to
+// a single pixel.
+type Scatter struct {
+	Name    string         // legend label, empty for no legend entry
+	X       []float64      // x values, nil for indices
+	Y       []float64      // y values
+	Color   canvas.Color   // marker color
+	Colors  []canvas.Color // per-point colors overriding Color, nil to use Color
+	Marker  Marker         // marker shape
+	Size    float64        // marker radius in pixels, 0 for the default
+	Sizes   []float64      // per-point radii overriding Size, 0 or nil to use Size
+	Density bool           // whether to draw hit density instead of markers
+}
+
+// AddScatter adds a scatter plot to the chart.
+//
+// In density mode, each point adds a hit to the pixels within its radius
+// instead of drawing a marker. Pixels are then lit by ordered dithering in
+// proportion to their share of the most-hit pixel (on a log scale), so heavy
+// overlap shows as solid areas fading out through dithered edges. The pixel
+// under each point is always lit, so isolated points stay visible. Per-point
+// colors are ignored in density mode.
+func (chart *Chart) AddScatter(scatter Scatter) {
+	chart.layers = append(chart.layers, scatter)
+}
+
+// length returns the number of usable points.
+func (scatter Scatter) length() int {
+	if scatter.X == nil {
+		return len(scatter.Y)
+	}
+	return min(len(scatter.X), len(scatter.Y))
+}
+
+// point returns the index-th point.
+func (scatter Scatter) point(index int) (x, y float64) {
+	if scatter.X == nil {
+		return float64(index), scatter.Y[index]
+	}
+	return scatter.X[index], scatter.Y[index]
+}
+
+// color returns the color of the index-th point.
+func (scatter Scatter) color(index int) canvas.Color {
+	if index < len(scatter.Colors) {
+		return scatter.Colors[index]
+	}
+	return scatter.Color
+}
+
+// radius returns the marker radius of the index-th point, in whole pixels.
+func (scatter Scatter) radius(index int) int {
+	size := scatter.Size
+	if index < len(scatter.Sizes) && scatter.Sizes[index] > 0 {
+		size = scatter.Sizes[index]
+	}
+	if size > 0 {
+		return int(math.Round(size))
+	}
+	if scatter.Marker == MarkerDot || scatter.Density {
+		return 0
+	}
+	return defaultMarkerRadius
+}
+
+func (scatter Scatter) extent(visit func(x, y float64)) {
+	for index := range scatter.length() {
+		visit(scatter.point(index))
+	}
+}
+
+func (scatter Scatter) draw(c *canvas.Canvas, projection projection) {
+	// Markers are clipped to the plot area, within any clip the caller set
+	saved, clipped := c.Clip()
+	clip := projection.area()
+	if clipped {
+		clip = clip.Intersect(saved)
+	}
+	c.SetClip(clip)
+	defer func() {
+		if clipped {
+			c.SetClip(saved)
+		} else {
+			c.ClearClip()
+		}
+	}()
+
+	if scatter.Density {
+		scatter.drawDensity(c, projection)
+		return
+	}
+	for index := range scatter.length() {
+		pixelX, pixelY, ok := projection.pixel(scatter.point(index))
+		if !ok || !projection.inside(pixelX, pixelY) {
+			continue
+		}
+		centerX, centerY := math.Floor(pixelX), projection.canvasY(pixelY)
+		drawMarker(c, scatter.Marker, centerX, centerY, float64(scatter.radius(index)), scatter.color(index))
+	}
+}
+
+// drawMarker draws a marker centered on a canvas pixel.
+func drawMarker(c *canvas.Canvas, marker Marker, x, y, radius float64, color canvas.Color) {
+	switch marker {
+	case MarkerPlus:
+		draw.LineColor(c, x-radius, y, x+radius, y, color)
+		draw.LineColor(c, x, y-radius, x, y+radius, color)
+	case MarkerCross:
+		draw.LineColor(c, x-radius, y-radius, x+radius, y+radius, color)
+		draw.LineColor(c, x-radius, y+radius, x+radius, y-radius, color)
+	case MarkerCircle:
+		draw.CircleColor(c, x, y, radius, color)
+	case MarkerSquare:
+		draw.RectangleColor(c, x-radius, y-radius, 2*radius+1, 2*radius+1, color)
+	default:
+		draw.CircleFilledColor(c, x, y, radius, color)
+	}
+}
+
+// drawDensity accumulates hits per plot-area pixel and dithers them.
+func (scatter Scatter) drawDensity(c *canvas.Canvas, projection projection) {
+	width := projection.right - projection.left + 1
+	height := projection.bottom - projection.top + 1
+	hits := make([]float64, width*height)
+	centers := make([]bool, width*height)
+
+	for index := range scatter.length() {
+		pixelX, pixelY, ok := projection.pixel(scatter.point(index))
+		if !ok || !projection.inside(pixelX, pixelY) {
+			continue
+		}
+		column := int(pixelX) - projection.left
+		row := int(pixelY) - projection.top
+		centers[row*width+column] = true
+
+		radius := scatter.radius(index)
+		for offsetY := -radius; offsetY <= radius; offsetY++ {
+			for offsetX := -radius; offsetX <= radius; offsetX++ {
+				hitColumn, hitRow := column+offsetX, row+offsetY
+				if offsetX*offsetX+offsetY*offsetY > radius*radius ||
+					hitColumn < 0 || hitColumn >= width || hitRow < 0 || hitRow >= height {
+					continue
+				}
+				hits[hitRow*width+hitColumn]++
+			}
+		}
+	}
+
+	most := 0.0
+	for _, count := range hits {
+		most = math.Max(most, count)
+	}
+	if most == 0 {
+		return
+	}
+
+	for row := range height {
+		for column := range width {
+			count := hits[row*width+column]
+			if count == 0 {
+				continue
+			}
+			pixelX, pixelY := projection.left+column, projection.top+row
+			intensity := math.Log1p(count) / math.Log1p(most)
+			if centers[row*width+column] || draw.Dithered(pixelX, pixelY, intensity) {
+				projection.set(c, float64(pixelX), float64(pixelY), scatter.Color)
+			}
+		}
+	}
+}
+
+func (scatter Scatter) legend(visit func(name, symbol string, color canvas.Color)) {
+	if scatter.Name == "" {
+		return
+	}
+	symbol := markerSymbols[MarkerDot]
+	if int(scatter.Marker) < len(markerSymbols) {
+		symbol = markerSymbols[scatter.Marker]
+	}
+	visit(scatter.Name, symbol, scatter.Color)
+}
@@ -0,0 +1,187 @@
+package plot
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+func TestScatterMarkers(t *testing.T) {
+	tests := []struct {
+		name     string
+		marker   Marker
+		expected func(c *canvas.Canvas)
+	}{
+		{"dot", MarkerDot, func(c *canvas.Canvas) { c.Set(5, 5) }},
+		{"plus", MarkerPlus, func(c *canvas.Canvas) {
+			draw.Line(c, 3, 5, 7, 5)
+			draw.Line(c, 5, 3, 5, 7)
+		}},
+		{"cross", MarkerCross, func(c *canvas.Canvas) {
+			draw.Line(c, 3, 3, 7, 7)
+			draw.Line(c, 3, 7, 7, 3)
+		}},
+		{"circle", MarkerCircle, func(c *canvas.Canvas) { draw.Circle(c, 5, 5, 2) }},
+		{"square", MarkerSquare, func(c *canvas.Canvas) { draw.Rectangle(c, 3, 3, 5, 5) }},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := canvas.New(12, 12)
+			Scatter{X: []float64{5}, Y: []float64{5}, Marker: testCase.marker}.draw(actual, plotArea(actual))
+
+			expected := canvas.New(12, 12)
+			testCase.expected(expected)
+			stippletest.AssertEqual(t, expected, actual)
+		})
+	}
+}
+
+func TestScatterSizes(t *testing.T) {
+	actual := canvas.New(12, 12)
+	scatter := Scatter{
+		X:      []float64{2, 7},
+		Y:      []float64{8, 3},
+		Marker: MarkerSquare,
+		Size:   1,
+		Sizes:  []float64{0, 3},
+	}
+	scatter.draw(actual, plotArea(actual))
+
+	// The first point falls back to Size, the second uses its own radius
+	expected := canvas.New(12, 12)
+	draw.Rectangle(expected, 1, 1, 3, 3)
+	draw.Rectangle(expected, 4, 4, 7, 7)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestScatterColors(t *testing.T) {
+	c := canvas.New(12, 12, canvas.WithColor())
+	scatter := Scatter{
+		X:      []float64{1, 5, 9},
+		Y:      []float64{5, 5, 5},
+		Color:  canvas.ColorYellow,
+		Colors: []canvas.Color{canvas.ColorRed, canvas.ColorBlue},
+	}
+	scatter.draw(c, plotArea(c))
+
+	for _, testCase := range []struct {
+		x        float64
+		expected canvas.Color
+	}{
+		{1, canvas.ColorRed},
+		{5, canvas.ColorBlue},
+		{9, canvas.ColorYellow},
+	} {
+		if color := c.GetColor(testCase.x, 5); color != testCase.expected {
+			t.Errorf("GetColor(%.0f, 5) = %v, want %v", testCase.x, color, testCase.expected)
+		}
+	}
+}
+
+func TestScatterClippedToPlotArea(t *testing.T) {
+	c := canvas.New(16, 16)
+	projection := plotArea(c)
+	projection.left, projection.top = 2, 2
+	Scatter{X: []float64{0, 10, 20}, Y: []float64{0, 10, 5}, Marker: MarkerSquare, Size: 3}.draw(c, projection)
+
+	for y := 0.0; y < 16; y++ {
+		for x := 0.0; x < 16; x++ {
+			inside := x >= 2 && x <= 10 && y >= 2 && y <= 10
+			if c.Get(x, y) && !inside {
+				t.Errorf("pixel (%.0f, %.0f) outside the plot area is set", x, y)
+			}
+		}
+	}
+}
+
+func TestScatterKeepsCanvasClip(t *testing.T) {
+	c := canvas.New(12, 12)
+	clip := canvas.Rect{X: 0, Y: 0, Width: 6, Height: 12}
+	c.SetClip(clip)
+	Scatter{X: []float64{2, 8}, Y: []float64{5, 5}}.draw(c, plotArea(c))
+
+	if actual, clipped := c.Clip(); !clipped || actual != clip {
+		t.Errorf("Clip() after draw = %v, %v, want %v, true", actual, clipped, clip)
+	}
+	if !c.Get(2, 5) {
+		t.Error("marker inside the canvas clip was not drawn")
+	}
+	if c.Get(8, 5) {
+		t.Error("marker outside the canvas clip was drawn")
+	}
+}
+
+func TestScatterDensity(t *testing.T) {
+	c := canvas.New(12, 12)
+	var xs, ys []float64
+	// A heavy cluster at (2, 8) and one isolated point at (8, 2)
+	for range 200 {
+		xs = append(xs, 2)
+		ys = append(ys, 8)
+	}
+	xs = append(xs, 8)
+	ys = append(ys, 2)
+	Scatter{X: xs, Y: ys, Size: 2, Density: true}.draw(c, plotArea(c))
+
+	countLit := func(centerX, centerY float64) int {
+		lit := 0
+		for y := centerY - 2; y <= centerY+2; y++ {
+			for x := centerX - 2; x <= centerX+2; x++ {
+				if c.Get(x, y) {
+					lit++
+				}
+			}
+		}
+		return lit
+	}
+
+	// The cluster is solid, the isolated point is dithered but its center stays lit
+	if lit := countLit(2, 2); lit != 13 {
+		t.Errorf("cluster lights %d pixels, want 13", lit)
+	}
+	if lit := countLit(8, 8); lit == 0 || lit >= 13 {
+		t.Errorf("isolated point lights %d pixels, want a dithered subset of 13", lit)
+	}
+	if !c.Get(8, 8) {
+		t.Error("isolated point center is not lit")
+	}
+}
+
+func TestScatterDensityUniform(t *testing.T) {
+	// Without overlap every hit pixel has the maximum intensity and is lit
+	c := canvas.New(12, 12)
+	Scatter{X: []float64{2, 8}, Y: []float64{2, 8}, Size: 1, Density: true}.draw(c, plotArea(c))
+
+	expected := canvas.New(12, 12)
+	draw.CircleFilled(expected, 2, 8, 1)
+	draw.CircleFilled(expected, 8, 2, 1)
+	stippletest.AssertEqual(t, expected, c)
+}
+
+func TestScatterAutoRange(t *testing.T) {
+	c := canvas.NewCells(30, 8)
+	chart := New()
+	chart.AddScatter(Scatter{X: []float64{-50, 50}, Y: []float64{0.5, 2.5}})
+	chart.Draw(c)
+	stippletest.PrintVisual(t, "TestScatterAutoRange", c)
+
+	if text := string([]rune(row(c, 0))[:3]); text != "2.5" {
+		t.Errorf("top label = %q, want %q", text, "2.5")
+	}
+	if bottom := row(c, 7); !strings.HasSuffix(bottom, "60") {
+		t.Errorf("x label row = %q, want it to end with 60", bottom)
+	}
+}
+
+func TestScatterLegend(t *testing.T) {
+	entries := legendEntries(Scatter{Name: "hits", Marker: MarkerCross, Color: canvas.ColorRed})
+	expected := []legendEntry{{"hits", "×", canvas.ColorRed}}
+	if !slices.Equal(entries, expected) {
+		t.Errorf("legend() visited %v, want %v", entries, expected)
+	}
+}
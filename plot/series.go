@@ -0,0 +1,69 @@
+package plot
+
+import (
+	"github.com/cboone/stipple/canvas"
+)
+
+// Series is a named sequence of data points drawn as a polyline.
+// When X is nil, the points are spaced at x = 0, 1, 2, and so on. When X and
+// Y have different lengths, the extra values are ignored. Points that are
+// NaN, infinite, or not positive on a log axis break the line.
+type Series struct {
+	Name  string       // legend label, empty for no legend entry
+	X     []float64    // x values, nil for indices
+	Y     []float64    // y values
+	Color canvas.Color // line color
+}
+
+// AddSeries adds a series to the chart, drawn as a polyline.
+func (chart *Chart) AddSeries(series Series) {
+	chart.layers = append(chart.layers, series)
+}
+
+// length returns the number of usable points.
+func (series Series) length() int {
+	if series.X == nil {
+		return len(series.Y)
+	}
+	return min(len(series.X), len(series.Y))
+}
+
+// point returns the index-th point.
+func (series Series) point(index int) (x, y float64) {
+	if series.X == nil {
+		return float64(index), series.Y[index]
+	}
+	return series.X[index], series.Y[index]
+}
+
+func (series Series) extent(visit func(x, y float64)) {
+	for index := range series.length() {
+		visit(series.point(index))
+	}
+}
+
+func (series Series) draw(c *canvas.Canvas, projection projection) {
+	var previousX, previousY float64
+	previous := false
+	for index := range series.length() {
+		pixelX, pixelY, ok := projection.pixel(series.point(index))
+		if !ok {
+			previous = false
+			continue
+		}
+		switch {
+		case previous:
+			projection.clippedLine(c, previousX, previousY, pixelX, pixelY, series.Color)
+		case projection.inside(pixelX, pixelY):
+			// A lone point, or the first of a run, is visible even without a segment
+			projection.set(c, pixelX, pixelY, series.Color)
+		}
+		previousX, previousY, previous = pixelX, pixelY, true
+	}
+}
+
+func (series Series) legend(visit func(name, symbol string, color canvas.Color)) {
+	if series.Name != "" {
+		visit(series.Name, legendLine, series.Color)
+	}
+}
@@ -0,0 +1,97 @@
+package plot
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// plotArea returns a projection that maps data 0..10 onto pixels 0..10,
+// with y increasing downward as data y increases upward.
+func plotArea(c *canvas.Canvas) projection {
+	x := linearRange(0, 10, 5, true)
+	y := linearRange(0, 10, 5, true)
+	return projection{bottom: 10, height: c.Height(), left: 0, right: 10, top: 0, x: x, y: y}
+}
+
+func TestSeriesDraw(t *testing.T) {
+	c := canvas.New(12, 12)
+	series := Series{X: []float64{0, 10}, Y: []float64{0, 0}}
+	series.draw(c, plotArea(c))
+
+	for x := 0.0; x <= 10; x++ {
+		if !c.Get(x, 10) {
+			t.Errorf("pixel (%.0f, 10) not set", x)
+		}
+	}
+}
+
+func TestSeriesIndexX(t *testing.T) {
+	series := Series{Y: []float64{5, 6, 7}}
+	var points [][2]float64
+	series.extent(func(x, y float64) { points = append(points, [2]float64{x, y}) })
+
+	expected := [][2]float64{{0, 5}, {1, 6}, {2, 7}}
+	if len(points) != len(expected) {
+		t.Fatalf("extent() visited %v, want %v", points, expected)
+	}
+	for index := range expected {
+		if points[index] != expected[index] {
+			t.Errorf("point %d = %v, want %v", index, points[index], expected[index])
+		}
+	}
+}
+
+func TestSeriesMismatchedLengths(t *testing.T) {
+	series := Series{X: []float64{0, 1, 2, 3}, Y: []float64{4, 5}}
+	count := 0
+	series.extent(func(x, y float64) { count++ })
+	if count != 2 {
+		t.Errorf("extent() visited %d points, want 2", count)
+	}
+}
+
+func TestSeriesBreaksOnInvalidPoints(t *testing.T) {
+	c := canvas.New(12, 12)
+	series := Series{
+		X: []float64{0, 4, 5, 6, 10},
+		Y: []float64{10, 10, math.NaN(), 10, 10},
+	}
+	series.draw(c, plotArea(c))
+
+	if c.Get(5, 0) {
+		t.Error("pixel (5, 0) set across a NaN point, want a gap")
+	}
+	if !c.Get(4, 0) || !c.Get(6, 0) {
+		t.Error("points next to the gap are not set")
+	}
+}
+
+func TestSeriesLonePoint(t *testing.T) {
+	c := canvas.New(12, 12)
+	Series{X: []float64{5}, Y: []float64{5}}.draw(c, plotArea(c))
+	if !c.Get(5, 5) {
+		t.Error("single point not drawn")
+	}
+}
+
+func TestSeriesColor(t *testing.T) {
+	c := canvas.New(12, 12, canvas.WithColor())
+	Series{X: []float64{0, 10}, Y: []float64{5, 5}, Color: canvas.ColorGreen}.draw(c, plotArea(c))
+	if color := c.GetColor(3, 5); color != canvas.ColorGreen {
+		t.Errorf("GetColor(3, 5) = %v, want green", color)
+	}
+}
+
+func TestSeriesLegend(t *testing.T) {
+	if entries := legendEntries(Series{}); len(entries) != 0 {
+		t.Errorf("unnamed series has legend entries %v", entries)
+	}
+	entries := legendEntries(Series{Name: "a", Color: canvas.ColorRed})
+	expected := []legendEntry{{"a", legendLine, canvas.ColorRed}}
+	if !slices.Equal(entries, expected) {
+		t.Errorf("legend() visited %v, want %v", entries, expected)
+	}
+}
@@ -0,0 +1,184 @@
+package plot
+
+import (
+	"math"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+)
+
+// DefaultDecay is the fraction by which a sparkline's range moves toward the
+// range of its window on each push.
+const DefaultDecay = 0.1
+
+// SparklineOption configures a Sparkline.
+type SparklineOption func(*Sparkline)
+
+// WithSparklineColor sets the color of the sparkline.
+func WithSparklineColor(color canvas.Color) SparklineOption {
+	return func(sparkline *Sparkline) {
+		sparkline.color = color
+	}
+}
+
+// WithFilled fills the area under the sparkline instead of drawing a line.
+func WithFilled() SparklineOption {
+	return func(sparkline *Sparkline) {
+		sparkline.filled = true
+	}
+}
+
+// WithDecay sets the fraction, from 0 to 1, by which the range shrinks toward
+// the values in the window on each push. A decay of 0 keeps the widest range
+// seen; a decay of 1 always fits the window exactly. The default is
+// DefaultDecay. Values outside 0 to 1 are ignored.
+func WithDecay(decay float64) SparklineOption {
+	return func(sparkline *Sparkline) {
+		if decay >= 0 && decay <= 1 {
+			sparkline.decay = decay
+		}
+	}
+}
+
+// WithSparklineRange fixes the range instead of autoscaling it. Values outside
+// the range are drawn at its edges.
+func WithSparklineRange(low, high float64) SparklineOption {
+	return func(sparkline *Sparkline) {
+		if low < high {
+			sparkline.fixed, sparkline.low, sparkline.high = true, low, high
+		}
+	}
+}
+
+// Sparkline is a small chart of the most recent values of a stream, without
+// axes or labels, for canvases only a cell or two tall. New values are pushed
+// on the right and old values scroll off the left, one pixel column per value.
+//
+// The vertical range grows at once to fit new values and decays back toward
+// the values in the window, so a single spike does not flatten the line for
+// long after it scrolls away.
+type Sparkline struct {
+	capacity int          // maximum number of values kept
+	color    canvas.Color // line or fill color
+	decay    float64      // fraction the range moves toward the window per push
+	filled   bool         // whether to fill the area under the line
+	fixed    bool         // whether low and high were set explicitly
+	high     float64      // upper bound of the range
+	low      float64      // lower bound of the range
+	scaled   bool         // whether the range has been set from data
+	values   []float64    // the window, oldest first
+}
+
+// NewSparkline creates a Sparkline that keeps the last capacity values.
+// A capacity of zero or less keeps one value.
+func NewSparkline(capacity int, options ...SparklineOption) *Sparkline {
+	sparkline := &Sparkline{capacity: max(capacity, 1), decay: DefaultDecay}
+	for _, option := range options {
+		option(sparkline)
+	}
+	return sparkline
+}
+
+// Push appends values to the window, dropping the oldest values beyond its
+// capacity, and updates the range. NaN and infinite values leave a gap.
+func (sparkline *Sparkline) Push(values ...float64) {
+	for _, value := range values {
+		sparkline.values = append(sparkline.values, value)
+		if overflow := len(sparkline.values) - sparkline.capacity; overflow > 0 {
+			sparkline.values = append(sparkline.values[:0], sparkline.values[overflow:]...)
+		}
+		sparkline.rescale()
+	}
+}
+
+// Values returns a copy of the window, oldest first.
+func (sparkline *Sparkline) Values() []float64 {
+	return append([]float64(nil), sparkline.values...)
+}
+
+// Range returns the current vertical range.
+func (sparkline *Sparkline) Range() (low, high float64) {
+	return sparkline.low, sparkline.high
+}
+
+// Len returns the number of values in the window.
+func (sparkline *Sparkline) Len() int {
+	return len(sparkline.values)
+}
+
+// rescale moves the range toward the window after a push.
+func (sparkline *Sparkline) rescale() {
+	if sparkline.fixed {
+		return
+	}
+	windowLow, windowHigh := math.Inf(1), math.Inf(-1)
+	for _, value := range sparkline.values {
+		if !math.IsNaN(value) && !math.IsInf(value, 0) {
+			windowLow, windowHigh = math.Min(windowLow, value), math.Max(windowHigh, value)
+		}
+	}
+	if windowLow > windowHigh {
+		return
+	}
+	if !sparkline.scaled {
+		sparkline.low, sparkline.high, sparkline.scaled = windowLow, windowHigh, true
+		return
+	}
+
+	// Blending rather than stepping by the difference cannot overflow
+	sparkline.low = sparkline.low*(1-sparkline.decay) + windowLow*sparkline.decay
+	sparkline.high = sparkline.high*(1-sparkline.decay) + windowHigh*sparkline.decay
+	sparkline.low = math.Min(sparkline.low, windowLow)
+	sparkline.high = math.Max(sparkline.high, windowHigh)
+}
+
+// Draw renders the newest values onto the whole canvas, one pixel column per
+// value with the newest in the last column, using every dot row for vertical
+// resolution. The canvas should be cleared first if it already holds a frame.
+// When every value is the same, the line is drawn across the middle.
+func (sparkline *Sparkline) Draw(c *canvas.Canvas) {
+	width, height := c.Width(), c.Height()
+	start := max(len(sparkline.values)-width, 0)
+	offset := width - (len(sparkline.values) - start)
+
+	previousX, previousY := 0.0, 0.0
+	previous := false
+	for index, value := range sparkline.values[start:] {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			previous = false
+			continue
+		}
+		x := float64(offset + index)
+		y := sparkline.row(value, height)
+		bottom := float64(height - 1)
+		if c.InvertedY() {
+			y, bottom = float64(height-1)-y, 0
+		}
+
+		switch {
+		case sparkline.filled:
+			draw.LineColor(c, x, y, x, bottom, sparkline.color)
+		case previous:
+			draw.LineColor(c, previousX, previousY, x, y, sparkline.color)
+		default:
+			c.SetColor(x, y, sparkline.color)
+		}
+		previousX, previousY, previous = x, y, true
+	}
+}
+
+// row returns the screen row of a value, from 0 at the top of the range to
+// height-1 at the bottom.
+func (sparkline *Sparkline) row(value float64, height int) float64 {
+	middle := float64((height - 1) / 2)
+	if !(sparkline.high > sparkline.low) {
+		return middle
+	}
+	// Halving first keeps the span finite when the range is extreme
+	fraction := (value/2 - sparkline.low/2) / (sparkline.high/2 - sparkline.low/2)
+	if math.IsNaN(fraction) {
+		return middle
+	}
+	fraction = math.Max(0, math.Min(1, fraction))
+	return math.Round((1 - fraction) * float64(height-1))
+}
@@ -0,0 +1,157 @@
+package plot
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+func TestSparklinePushScrolls(t *testing.T) {
+	sparkline := NewSparkline(3)
+	sparkline.Push(1, 2)
+	sparkline.Push(3, 4)
+
+	if values := sparkline.Values(); !slices.Equal(values, []float64{2, 3, 4}) {
+		t.Errorf("Values() = %v, want [2 3 4]", values)
+	}
+	if length := sparkline.Len(); length != 3 {
+		t.Errorf("Len() = %d, want 3", length)
+	}
+}
+
+func TestSparklineRange(t *testing.T) {
+	tests := []struct {
+		name         string
+		options      []SparklineOption
+		pushes       []float64
+		expectedLow  float64
+		expectedHigh float64
+	}{
+		{"first values", nil, []float64{2, 6}, 2, 6},
+		{"grows at once", nil, []float64{2, 6, 10}, 2, 10},
+		{"decays toward window", []SparklineOption{WithDecay(0.5)}, []float64{100, 0, 0, 0}, 0, 50},
+		{"no decay keeps widest", []SparklineOption{WithDecay(0)}, []float64{100, 0, 0, 0}, 0, 100},
+		{"full decay fits window", []SparklineOption{WithDecay(1)}, []float64{100, 0, 0, 0, 1}, 0, 1},
+		{"fixed", []SparklineOption{WithSparklineRange(-1, 1)}, []float64{5, -5}, -1, 1},
+		{"invalid values skipped", nil, []float64{math.NaN(), 3, math.Inf(1)}, 3, 3},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			// A capacity of 3 lets the spike scroll out of the window
+			sparkline := NewSparkline(3, testCase.options...)
+			for _, value := range testCase.pushes {
+				sparkline.Push(value)
+			}
+			low, high := sparkline.Range()
+			if low != testCase.expectedLow || high != testCase.expectedHigh {
+				t.Errorf("Range() = (%v, %v), want (%v, %v)", low, high, testCase.expectedLow, testCase.expectedHigh)
+			}
+		})
+	}
+}
+
+func TestSparklineDraw(t *testing.T) {
+	sparkline := NewSparkline(8)
+	sparkline.Push(0, 3, 1, 2)
+
+	// Four pixel rows: 0 at the bottom, 3 at the top, newest value on the right
+	actual := canvas.New(6, 4)
+	sparkline.Draw(actual)
+
+	expected := canvas.New(6, 4)
+	draw.Line(expected, 2, 3, 3, 0)
+	draw.Line(expected, 3, 0, 4, 2)
+	draw.Line(expected, 4, 2, 5, 1)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestSparklineExtremeRange(t *testing.T) {
+	// A span that overflows once made every row NaN, and drawing the line
+	// between them never finished
+	sparkline := NewSparkline(2)
+	done := make(chan *canvas.Canvas)
+	go func() {
+		sparkline.Push(-1.7e308, 1.7e308)
+		actual := canvas.New(2, 4)
+		sparkline.Draw(actual)
+		done <- actual
+	}()
+
+	select {
+	case actual := <-done:
+		expected := canvas.New(2, 4)
+		draw.Line(expected, 0, 3, 1, 0)
+		stippletest.AssertEqual(t, expected, actual)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Draw() did not finish for a range that overflows")
+	}
+
+	// Decaying toward a new window keeps the range finite
+	sparkline.Push(0)
+	if low, high := sparkline.Range(); math.IsInf(low, 0) || math.IsInf(high, 0) {
+		t.Errorf("Range() = (%v, %v) after decaying, want finite bounds", low, high)
+	}
+}
+
+func TestSparklineFilled(t *testing.T) {
+	sparkline := NewSparkline(8, WithFilled(), WithSparklineColor(canvas.ColorGreen))
+	sparkline.Push(0, 3, math.NaN(), 2)
+
+	actual := canvas.New(4, 4, canvas.WithColor())
+	sparkline.Draw(actual)
+
+	expected := canvas.New(4, 4, canvas.WithColor())
+	draw.LineColor(expected, 0, 3, 0, 3, canvas.ColorGreen)
+	draw.LineColor(expected, 1, 0, 1, 3, canvas.ColorGreen)
+	draw.LineColor(expected, 3, 1, 3, 3, canvas.ColorGreen)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestSparklineFlat(t *testing.T) {
+	sparkline := NewSparkline(4)
+	sparkline.Push(5, 5, 5, 5)
+
+	actual := canvas.New(4, 8)
+	sparkline.Draw(actual)
+
+	expected := canvas.New(4, 8)
+	draw.Line(expected, 0, 3, 3, 3)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestSparklineKeepsNewestValues(t *testing.T) {
+	sparkline := NewSparkline(100, WithSparklineRange(0, 1))
+	for index := range 100 {
+		sparkline.Push(float64(index % 2))
+	}
+
+	c := canvas.New(4, 4)
+	sparkline.Draw(c)
+	// The last value pushed is 1, in the top row of the last column
+	if !c.Get(3, 0) || c.Get(3, 3) {
+		t.Error("last column does not show the newest value")
+	}
+}
+
+func TestSparklineInvertedY(t *testing.T) {
+	sparkline := NewSparkline(40, WithFilled())
+	for index := range 40 {
+		sparkline.Push(math.Sin(float64(index) / 4))
+	}
+
+	normal := canvas.NewCells(20, 2)
+	sparkline.Draw(normal)
+	inverted := canvas.NewCells(20, 2, canvas.WithInvertedY())
+	sparkline.Draw(inverted)
+	stippletest.PrintVisual(t, "TestSparklineInvertedY", inverted)
+
+	if normal.Frame() != inverted.Frame() {
+		t.Errorf("inverted frame differs:\n%s\nwant:\n%s", inverted.Frame(), normal.Frame())
+	}
+}
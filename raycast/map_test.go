@@ -0,0 +1,104 @@
+package raycast
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// box is a 5 by 5 room with walls all around and one wall of kind 2.
+var box = NewMap(
+	"#####",
+	"#...#",
+	"#...2",
+	"#...#",
+	"#####",
+)
+
+func TestNewMap(t *testing.T) {
+	expected := Map{
+		{1, 1, 1, 1, 1},
+		{1, 0, 0, 0, 1},
+		{1, 0, 0, 0, 2},
+		{1, 0, 0, 0, 1},
+		{1, 1, 1, 1, 1},
+	}
+	if !reflect.DeepEqual(box, expected) {
+		t.Errorf("NewMap() = %v, want %v", box, expected)
+	}
+}
+
+func TestAtAndSolid(t *testing.T) {
+	tests := []struct {
+		x, y     float64
+		cell     int
+		expected bool
+	}{
+		{2.5, 2.5, 0, false},
+		{0.5, 0.5, 1, true},
+		{4.9, 2.1, 2, true},
+		{-0.5, 2.5, 0, true},
+		{2.5, 5.5, 0, true},
+	}
+
+	for _, testCase := range tests {
+		column, row := int(math.Floor(testCase.x)), int(math.Floor(testCase.y))
+		if cell := box.At(column, row); cell != testCase.cell {
+			t.Errorf("At(%d, %d) = %d, want %d", column, row, cell, testCase.cell)
+		}
+		if solid := box.Solid(testCase.x, testCase.y); solid != testCase.expected {
+			t.Errorf("Solid(%v, %v) = %v, want %v", testCase.x, testCase.y, solid, testCase.expected)
+		}
+	}
+}
+
+func TestCast(t *testing.T) {
+	tests := []struct {
+		name     string
+		x, y     float64
+		angle    float64
+		expected Hit
+	}{
+		{"east", 2.5, 2.25, 0, Hit{Distance: 1.5, Wall: 2, Side: EastWest, Column: 4, Row: 2, X: 4, Y: 2.25, Offset: 0.25}},
+		{"south", 2.25, 2.5, math.Pi / 2, Hit{Distance: 1.5, Wall: 1, Side: NorthSouth, Column: 2, Row: 4, X: 2.25, Y: 4, Offset: 0.25}},
+		{"west", 2.5, 1.5, math.Pi, Hit{Distance: 1.5, Wall: 1, Side: EastWest, Column: 0, Row: 1, X: 1, Y: 1.5, Offset: 0.5}},
+		{"north", 1.5, 3.5, -math.Pi / 2, Hit{Distance: 2.5, Wall: 1, Side: NorthSouth, Column: 1, Row: 0, X: 1.5, Y: 1, Offset: 0.5}},
+		{
+			"diagonal", 1.5, 1.5, math.Atan2(1, 2),
+			Hit{Distance: math.Hypot(2.5, 1.25), Wall: 2, Side: EastWest, Column: 4, Row: 2, X: 4, Y: 2.75, Offset: 0.75},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			hit, ok := box.Cast(testCase.x, testCase.y, testCase.angle, DefaultMaxDistance)
+			if !ok {
+				t.Fatal("Cast() reported no hit")
+			}
+			if !closeHit(hit, testCase.expected) {
+				t.Errorf("Cast() = %+v, want %+v", hit, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestCastMisses(t *testing.T) {
+	open := NewMap(
+		"...",
+		"...",
+	)
+	if hit, ok := open.Cast(1.5, 1, 0.3, DefaultMaxDistance); ok {
+		t.Errorf("Cast() in an open map = %+v, want no hit", hit)
+	}
+	if hit, ok := box.Cast(1.5, 2.5, 0, 2); ok {
+		t.Errorf("Cast() beyond maxDistance = %+v, want no hit", hit)
+	}
+}
+
+// closeHit reports whether two hits match, allowing for rounding error.
+func closeHit(actual, expected Hit) bool {
+	close := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	return close(actual.Distance, expected.Distance) && actual.Wall == expected.Wall &&
+		actual.Side == expected.Side && actual.Column == expected.Column && actual.Row == expected.Row &&
+		close(actual.X, expected.X) && close(actual.Y, expected.Y) && close(actual.Offset, expected.Offset)
+}
@@ -0,0 +1,148 @@
+package raycast
+
+import (
+	"math"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+)
+
+// Defaults for a View.
+const (
+	DefaultFieldOfView = math.Pi / 3 // 60 degrees
+	DefaultMaxDistance = 16          // cells
+)
+
+// minDistance is the smallest distance a wall slice is scaled for, so a
+// camera against a wall fills the column instead of dividing by zero.
+const minDistance = 1e-6
+
+// View is a camera in a map.
+type View struct {
+	Map         Map                                    // the map to render
+	X           float64                                // camera x in map cells
+	Y           float64                                // camera y in map cells
+	Angle       float64                                // viewing direction in radians
+	FieldOfView float64                                // horizontal field of view in radians, 0 for DefaultFieldOfView
+	MaxDistance float64                                // how far rays travel, in cells, 0 for DefaultMaxDistance
+	Shade       bool                                   // whether walls fade with distance, vanishing at MaxDistance
+	Colors      func(wall int, side Side) canvas.Color // color of each wall kind and side, nil for the default color
+}
+
+// Depth holds the distance to the wall drawn in each pixel column, measured
+// perpendicular to the camera plane, or +Inf where no wall was drawn.
+type Depth []float64
+
+// Visible reports whether something at a distance in a pixel column is in
+// front of the wall there. Columns outside the buffer are not visible.
+func (depth Depth) Visible(column int, distance float64) bool {
+	return column >= 0 && column < len(depth) && distance < depth[column]
+}
+
+// Render draws one wall slice per pixel column of the canvas and returns the
+// depth of each column. Walls are one cell tall, centered on the horizon, and
+// scaled so a cell looks as tall as it is wide. With Shade, each slice lights
+// a dithered fraction of its pixels that falls from all of them next to the
+// camera to none at MaxDistance. The canvas should be cleared first if it
+// already holds a frame.
+func (view View) Render(c *canvas.Canvas) Depth {
+	width, height := c.Width(), c.Height()
+	depth := make(Depth, width)
+	directionX, directionY, planeX, planeY := view.camera()
+	focal := view.focal(width)
+	maxDistance := view.maxDistance()
+
+	for column := range width {
+		depth[column] = math.Inf(1)
+		// Position across the camera plane, from -1 on the left to 1 on the right
+		across := 2*(float64(column)+0.5)/float64(width) - 1
+		hit, ok := view.Map.cast(view.X, view.Y, directionX+planeX*across, directionY+planeY*across, maxDistance)
+		if !ok {
+			continue
+		}
+		depth[column] = hit.Distance
+
+		intensity := 1.0
+		if view.Shade {
+			intensity = 1 - hit.Distance/maxDistance
+		}
+		color := canvas.ColorDefault
+		if view.Colors != nil {
+			color = view.Colors(hit.Wall, hit.Side)
+		}
+
+		sliceHeight := focal / math.Max(hit.Distance, minDistance)
+		top := max(0, int(math.Ceil(float64(height)/2-sliceHeight/2-0.5)))
+		bottom := min(height-1, int(math.Floor(float64(height)/2+sliceHeight/2-0.5)))
+		for row := top; row <= bottom; row++ {
+			if !draw.Dithered(column, row, intensity) {
+				continue
+			}
+			y := row
+			if c.InvertedY() {
+				y = height - 1 - row
+			}
+			c.SetColor(float64(column), float64(y), color)
+		}
+	}
+	return depth
+}
+
+// Projection is where a point in the map appears on the canvas.
+type Projection struct {
+	Column   float64 // pixel column of the point
+	Distance float64 // distance perpendicular to the camera plane, for Depth.Visible
+	Scale    float64 // pixels per map cell at that distance
+}
+
+// Project returns where the point (x, y) in the map appears on the canvas, as
+// Render draws walls, for drawing sprites. The horizon is at the middle row,
+// so a sprite standing on the floor spans Scale pixels down to
+// Height/2 + Scale/2. It reports false for points beside or behind the camera.
+func (view View) Project(c *canvas.Canvas, x, y float64) (Projection, bool) {
+	directionX, directionY, planeX, planeY := view.camera()
+	relativeX, relativeY := x-view.X, y-view.Y
+	distance := relativeX*directionX + relativeY*directionY
+	if distance <= 1e-9 {
+		return Projection{}, false
+	}
+
+	// Offset across the camera plane, from -1 at the left edge to 1 at the right
+	planeLength := math.Hypot(planeX, planeY)
+	across := (relativeX*planeX + relativeY*planeY) / (planeLength * planeLength) / distance
+	width := float64(c.Width())
+	return Projection{
+		Column:   width / 2 * (1 + across),
+		Distance: distance,
+		Scale:    view.focal(c.Width()) / distance,
+	}, true
+}
+
+// camera returns the unit viewing direction and the camera plane, the vector
+// from the center to the right edge of the view one cell in front.
+func (view View) camera() (directionX, directionY, planeX, planeY float64) {
+	directionX, directionY = math.Cos(view.Angle), math.Sin(view.Angle)
+	halfWidth := math.Tan(view.fieldOfView() / 2)
+	return directionX, directionY, -directionY * halfWidth, directionX * halfWidth
+}
+
+// focal returns the pixels per cell of something one cell away.
+func (view View) focal(width int) float64 {
+	return float64(width) / 2 / math.Tan(view.fieldOfView()/2)
+}
+
+// fieldOfView returns the field of view, with the default applied.
+func (view View) fieldOfView() float64 {
+	if view.FieldOfView <= 0 || view.FieldOfView >= math.Pi {
+		return DefaultFieldOfView
+	}
+	return view.FieldOfView
+}
+
+// maxDistance returns how far rays travel, with the default applied.
+func (view View) maxDistance() float64 {
+	if view.MaxDistance <= 0 {
+		return DefaultMaxDistance
+	}
+	return view.MaxDistance
+}
@@ -0,0 +1,215 @@
+package raycast
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+// hall is a room whose east wall is 2 cells in front of a camera at (3, 4.5)
+// facing east, and wide enough to fill the view.
+var hall = NewMap(
+	"######",
+	"#....#",
+	"#....#",
+	"#....#",
+	"#....#",
+	"#....#",
+	"#....#",
+	"#....#",
+	"######",
+)
+
+// narrowView has a field of view whose half-width is 0.5 cells one cell in
+// front, so something one cell away is as many pixels tall as the canvas is wide.
+var narrowView = View{Map: hall, X: 3, Y: 4.5, FieldOfView: 2 * math.Atan(0.5)}
+
+func TestRenderFlatWall(t *testing.T) {
+	actual := canvas.New(40, 40)
+	depth := narrowView.Render(actual)
+	stippletest.PrintVisual(t, "flat wall", actual)
+
+	// The wall is 2 cells away across the whole view, without fisheye, so it
+	// is a band 40/2 pixels tall centered on the horizon
+	expected := canvas.New(40, 40)
+	draw.RectangleFilled(expected, 0, 10, 40, 20)
+	stippletest.AssertEqual(t, expected, actual)
+
+	for column, distance := range depth {
+		if math.Abs(distance-2) > 1e-9 {
+			t.Errorf("depth[%d] = %v, want 2", column, distance)
+		}
+	}
+}
+
+func TestRenderAgainstWall(t *testing.T) {
+	// Standing on the west wall and facing it, every ray hits at distance 0
+	view := narrowView
+	view.X, view.Angle = 1, math.Pi
+	actual := canvas.New(40, 40)
+	depth := view.Render(actual)
+
+	expected := canvas.New(40, 40)
+	draw.RectangleFilled(expected, 0, 0, 40, 40)
+	stippletest.AssertEqual(t, expected, actual)
+
+	for column, distance := range depth {
+		if distance != 0 {
+			t.Errorf("depth[%d] = %v, want 0", column, distance)
+		}
+	}
+}
+
+func TestRenderShade(t *testing.T) {
+	c := canvas.New(40, 40)
+	view := narrowView
+	view.Shade = true
+	view.MaxDistance = 4
+	view.Render(c)
+	stippletest.PrintVisual(t, "shaded wall", c)
+
+	// Halfway to MaxDistance, half the pixels of the wall are lit
+	lit := 0
+	for y := range c.Height() {
+		for x := range c.Width() {
+			if c.Get(float64(x), float64(y)) {
+				if y < 10 || y >= 30 {
+					t.Fatalf("pixel (%d, %d) lit outside the wall", x, y)
+				}
+				lit++
+			}
+		}
+	}
+	if lit != 40*20/2 {
+		t.Errorf("lit pixels = %d, want %d", lit, 40*20/2)
+	}
+}
+
+func TestRenderBeyondMaxDistance(t *testing.T) {
+	c := canvas.New(40, 40)
+	view := narrowView
+	view.MaxDistance = 1.5
+	depth := view.Render(c)
+
+	stippletest.AssertEqual(t, canvas.New(40, 40), c)
+	for column, distance := range depth {
+		if !math.IsInf(distance, 1) {
+			t.Errorf("depth[%d] = %v, want +Inf", column, distance)
+		}
+	}
+}
+
+func TestRenderColors(t *testing.T) {
+	colors := func(wall int, side Side) canvas.Color {
+		if side == EastWest {
+			return canvas.ColorRed
+		}
+		return canvas.ColorBlue
+	}
+	tests := []struct {
+		name     string
+		angle    float64
+		expected canvas.Color
+	}{
+		{"facing east", 0, canvas.ColorRed},
+		{"facing south", math.Pi / 2, canvas.ColorBlue},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := canvas.New(40, 40, canvas.WithColor())
+			view := narrowView
+			view.Angle = testCase.angle
+			view.Colors = colors
+			view.Render(c)
+			if color := c.GetColor(20, 20); color != testCase.expected {
+				t.Errorf("GetColor(20, 20) = %d, want %d", color, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestRenderCorner(t *testing.T) {
+	c := canvas.New(40, 40)
+	// Looking into the north-east corner, the corner column is the farthest
+	view := View{Map: hall, X: 3, Y: 3, Angle: -math.Pi / 4}
+	depth := view.Render(c)
+	stippletest.PrintVisual(t, "corner", c)
+
+	farthest := 0
+	for column := range depth {
+		if depth[column] > depth[farthest] {
+			farthest = column
+		}
+	}
+	if farthest < 18 || farthest > 21 {
+		t.Errorf("farthest column = %d, want the middle", farthest)
+	}
+}
+
+func TestRenderInvertedY(t *testing.T) {
+	normal := canvas.New(40, 40)
+	narrowView.Render(normal)
+	inverted := canvas.New(40, 40, canvas.WithInvertedY())
+	narrowView.Render(inverted)
+
+	if normal.Frame() != inverted.Frame() {
+		t.Errorf("inverted frame:\n%s\nwant:\n%s", inverted.Frame(), normal.Frame())
+	}
+}
+
+func TestDepthVisible(t *testing.T) {
+	depth := Depth{2, math.Inf(1)}
+	tests := []struct {
+		column   int
+		distance float64
+		expected bool
+	}{
+		{0, 1, true},
+		{0, 2, false},
+		{0, 3, false},
+		{1, 100, true},
+		{-1, 1, false},
+		{2, 1, false},
+	}
+
+	for _, testCase := range tests {
+		if visible := depth.Visible(testCase.column, testCase.distance); visible != testCase.expected {
+			t.Errorf("Visible(%d, %v) = %v, want %v", testCase.column, testCase.distance, visible, testCase.expected)
+		}
+	}
+}
+
+func TestProject(t *testing.T) {
+	c := canvas.New(40, 40)
+	tests := []struct {
+		name     string
+		x, y     float64
+		expected Projection
+	}{
+		{"ahead", 6, 4.5, Projection{Column: 20, Distance: 3, Scale: 40.0 / 3}},
+		{"right edge", 5, 5.5, Projection{Column: 40, Distance: 2, Scale: 20}},
+		{"left edge", 5, 3.5, Projection{Column: 0, Distance: 2, Scale: 20}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			projection, ok := narrowView.Project(c, testCase.x, testCase.y)
+			if !ok {
+				t.Fatal("Project() reported the point is not in front")
+			}
+			close := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+			if !close(projection.Column, testCase.expected.Column) || !close(projection.Distance, testCase.expected.Distance) ||
+				!close(projection.Scale, testCase.expected.Scale) {
+				t.Errorf("Project() = %+v, want %+v", projection, testCase.expected)
+			}
+		})
+	}
+
+	if projection, ok := narrowView.Project(c, 2, 4.5); ok {
+		t.Errorf("Project() behind the camera = %+v, want false", projection)
+	}
+}
@@ -0,0 +1,248 @@
+// Package scene composes drawables on a canvas with z-order, visibility, and
+// dirty-region tracking.
+//
+// A Scene owns a list of nodes, each wrapping a Drawable. When a node is added,
+// removed, hidden, moved in z-order, or invalidated, the regions it covered are
+// marked dirty. Render clears only the dirty regions and redraws the drawables
+// that overlap them, clipped to those regions, so unchanged parts of the canvas
+// are left alone.
+package scene
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// Drawable is anything that can draw itself onto a canvas.
+// Bounds returns the rectangle, in canvas coordinates, that Draw may touch.
+type Drawable interface {
+	Draw(c *canvas.Canvas)
+	Bounds() canvas.Rect
+}
+
+// Scene is an ordered collection of drawables rendered onto a canvas.
+// Nodes with a higher z are drawn later, on top of lower ones; nodes with the
+// same z are drawn in the order they were added.
+type Scene struct {
+	canvas   *canvas.Canvas // target canvas
+	dirty    []canvas.Rect  // regions to clear and redraw on the next Render
+	nodes    []*Node        // nodes sorted by z, then insertion order
+	sequence uint64         // insertion counter for stable ordering
+}
+
+// Node is a drawable placed in a Scene.
+type Node struct {
+	drawable Drawable    // what the node draws
+	drawn    canvas.Rect // bounds at the last render, empty when not on the canvas
+	order    uint64      // insertion order, breaks z ties
+	scene    *Scene      // owning scene, nil after removal
+	visible  bool        // whether the node is drawn
+	z        int         // drawing order, higher is on top
+}
+
+// New creates an empty Scene that renders onto c.
+func New(c *canvas.Canvas) *Scene {
+	return &Scene{canvas: c}
+}
+
+// Canvas returns the canvas the scene renders onto.
+func (scene *Scene) Canvas() *canvas.Canvas {
+	return scene.canvas
+}
+
+// Add places a visible drawable in the scene at z 0 and returns its node.
+func (scene *Scene) Add(drawable Drawable) *Node {
+	scene.sequence++
+	node := &Node{
+		drawable: drawable,
+		order:    scene.sequence,
+		scene:    scene,
+		visible:  true,
+	}
+	scene.nodes = append(scene.nodes, node)
+	scene.sort()
+	scene.markDirty(drawable.Bounds())
+	return node
+}
+
+// Remove takes a node out of the scene. The area it covered is redrawn on the
+// next Render. Removing a node that is not in the scene does nothing.
+func (scene *Scene) Remove(node *Node) {
+	if node.scene != scene {
+		return
+	}
+	scene.nodes = slices.DeleteFunc(scene.nodes, func(candidate *Node) bool {
+		return candidate == node
+	})
+	scene.markDirty(node.drawn)
+	node.scene = nil
+	node.drawn = canvas.Rect{}
+}
+
+// Nodes returns the scene's nodes in drawing order.
+func (scene *Scene) Nodes() []*Node {
+	return slices.Clone(scene.nodes)
+}
+
+// Invalidate marks a region of the canvas to be cleared and redrawn on the
+// next Render, for example after drawing over the scene directly.
+func (scene *Scene) Invalidate(rect canvas.Rect) {
+	scene.markDirty(rect)
+}
+
+// Dirty reports whether any region is waiting to be redrawn.
+func (scene *Scene) Dirty() bool {
+	return len(scene.dirty) > 0
+}
+
+// Render redraws the dirty regions: each region is cleared, then every visible
+// node overlapping it is drawn in z-order with drawing clipped to the region.
+// Pixels outside the dirty regions are left untouched, and a clip set on the
+// canvas still applies and is restored afterwards.
+func (scene *Scene) Render() {
+	if len(scene.dirty) == 0 {
+		return
+	}
+
+	for _, region := range scene.dirty {
+		scene.clearRegion(region)
+	}
+	saved, clipped := scene.canvas.Clip()
+	for _, region := range scene.dirty {
+		clip := region
+		if clipped {
+			clip = clip.Intersect(saved)
+		}
+		scene.canvas.SetClip(clip)
+		for _, node := range scene.nodes {
+			if node.visible && node.drawable.Bounds().Intersects(region) {
+				node.drawable.Draw(scene.canvas)
+			}
+		}
+	}
+	if clipped {
+		scene.canvas.SetClip(saved)
+	} else {
+		scene.canvas.ClearClip()
+	}
+
+	scene.dirty = scene.dirty[:0]
+	scene.recordDrawn()
+}
+
+// RenderAll clears the whole canvas and draws every visible node, regardless
+// of which regions are dirty.
+func (scene *Scene) RenderAll() {
+	scene.canvas.Clear()
+	for _, node := range scene.nodes {
+		if node.visible {
+			node.drawable.Draw(scene.canvas)
+		}
+	}
+	scene.dirty = scene.dirty[:0]
+	scene.recordDrawn()
+}
+
+// Drawable returns the node's drawable.
+func (node *Node) Drawable() Drawable {
+	return node.drawable
+}
+
+// SetDrawable replaces the node's drawable, marking both the old and the new
+// area dirty. Value-type shapes are moved or resized this way.
+func (node *Node) SetDrawable(drawable Drawable) {
+	node.drawable = drawable
+	node.Invalidate()
+}
+
+// Invalidate marks the node's area dirty. Call it after changing a drawable
+// in place (through a pointer) so the next Render picks up the change.
+func (node *Node) Invalidate() {
+	if node.scene == nil {
+		return
+	}
+	node.scene.markDirty(node.drawn)
+	if node.visible {
+		node.scene.markDirty(node.drawable.Bounds())
+	}
+}
+
+// Visible reports whether the node is drawn.
+func (node *Node) Visible() bool {
+	return node.visible
+}
+
+// SetVisible shows or hides the node.
+func (node *Node) SetVisible(visible bool) {
+	if node.visible == visible {
+		return
+	}
+	node.visible = visible
+	node.Invalidate()
+}
+
+// Z returns the node's drawing order.
+func (node *Node) Z() int {
+	return node.z
+}
+
+// SetZ changes the node's drawing order. Nodes with a higher z are drawn on top.
+func (node *Node) SetZ(z int) {
+	if node.z == z {
+		return
+	}
+	node.z = z
+	if node.scene != nil {
+		node.scene.sort()
+	}
+	node.Invalidate()
+}
+
+// markDirty adds a region to the dirty list, expanded to whole pixels and
+// limited to the canvas. Regions that end up empty are ignored.
+func (scene *Scene) markDirty(rect canvas.Rect) {
+	left, top := math.Floor(rect.X), math.Floor(rect.Y)
+	right, bottom := math.Ceil(rect.X+rect.Width), math.Ceil(rect.Y+rect.Height)
+	region := canvas.Rect{X: left, Y: top, Width: right - left, Height: bottom - top}.Intersect(scene.bounds())
+	if !region.Empty() {
+		scene.dirty = append(scene.dirty, region)
+	}
+}
+
+// bounds returns the rectangle covering the whole canvas.
+func (scene *Scene) bounds() canvas.Rect {
+	return canvas.Rect{Width: float64(scene.canvas.Width()), Height: float64(scene.canvas.Height())}
+}
+
+// clearRegion turns off every pixel in a dirty region.
+func (scene *Scene) clearRegion(region canvas.Rect) {
+	for y := region.Y; y < region.Y+region.Height; y++ {
+		for x := region.X; x < region.X+region.Width; x++ {
+			scene.canvas.Unset(x, y)
+		}
+	}
+}
+
+// recordDrawn remembers where each node is on the canvas after a render.
+func (scene *Scene) recordDrawn() {
+	for _, node := range scene.nodes {
+		if node.visible {
+			node.drawn = node.drawable.Bounds()
+		} else {
+			node.drawn = canvas.Rect{}
+		}
+	}
+}
+
+// sort orders nodes by z, keeping insertion order for equal z.
+func (scene *Scene) sort() {
+	slices.SortFunc(scene.nodes, func(a, b *Node) int {
+		if a.z != b.z {
+			return cmp.Compare(a.z, b.z)
+		}
+		return cmp.Compare(a.order, b.order)
+	})
+}
@@ -0,0 +1,251 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/stippletest"
+)
+
+// colorSquare is a filled square drawn in a single color, for z-order tests.
+type colorSquare struct {
+	x, y, size float64
+	color      canvas.Color
+	draws      *int // incremented on every Draw when not nil
+}
+
+func (square colorSquare) Draw(c *canvas.Canvas) {
+	if square.draws != nil {
+		*square.draws++
+	}
+	for y := square.y; y < square.y+square.size; y++ {
+		for x := square.x; x < square.x+square.size; x++ {
+			c.SetColor(x, y, square.color)
+		}
+	}
+}
+
+func (square colorSquare) Bounds() canvas.Rect {
+	return canvas.Rect{X: square.x, Y: square.y, Width: square.size, Height: square.size}
+}
+
+// expectedCanvas draws the given drawables directly onto a new canvas.
+func expectedCanvas(width, height int, drawables ...Drawable) *canvas.Canvas {
+	c := canvas.New(width, height, canvas.WithColor())
+	for _, drawable := range drawables {
+		drawable.Draw(c)
+	}
+	return c
+}
+
+func TestSceneRender(t *testing.T) {
+	c := canvas.New(20, 16)
+	scene := New(c)
+	line := Line{StartX: 0, StartY: 0, EndX: 19, EndY: 15}
+	circle := Circle{CenterX: 10, CenterY: 8, Radius: 5}
+	scene.Add(line)
+	scene.Add(circle)
+
+	if !scene.Dirty() {
+		t.Fatal("Dirty() = false after Add, want true")
+	}
+	scene.Render()
+	if scene.Dirty() {
+		t.Error("Dirty() = true after Render, want false")
+	}
+
+	expected := canvas.New(20, 16)
+	line.Draw(expected)
+	circle.Draw(expected)
+	stippletest.AssertEqual(t, expected, c)
+}
+
+func TestSceneMoveClearsOldPosition(t *testing.T) {
+	c := canvas.New(24, 16)
+	scene := New(c)
+	node := scene.Add(Rectangle{X: 1, Y: 1, Width: 6, Height: 6, Filled: true})
+	scene.Render()
+
+	node.SetDrawable(Rectangle{X: 14, Y: 6, Width: 6, Height: 6, Filled: true})
+	scene.Render()
+
+	expected := canvas.New(24, 16)
+	Rectangle{X: 14, Y: 6, Width: 6, Height: 6, Filled: true}.Draw(expected)
+	stippletest.AssertEqual(t, expected, c)
+}
+
+func TestSceneVisibility(t *testing.T) {
+	c := canvas.New(20, 16)
+	scene := New(c)
+	circle := Circle{CenterX: 10, CenterY: 8, Radius: 5, Filled: true}
+	node := scene.Add(circle)
+	scene.Render()
+
+	node.SetVisible(false)
+	if node.Visible() {
+		t.Error("Visible() = true after SetVisible(false)")
+	}
+	scene.Render()
+	stippletest.AssertEqual(t, canvas.New(20, 16), c)
+
+	node.SetVisible(true)
+	scene.Render()
+	expected := canvas.New(20, 16)
+	circle.Draw(expected)
+	stippletest.AssertEqual(t, expected, c)
+}
+
+func TestSceneRemove(t *testing.T) {
+	c := canvas.New(20, 16)
+	scene := New(c)
+	keep := Line{StartX: 0, StartY: 15, EndX: 19, EndY: 0}
+	scene.Add(keep)
+	node := scene.Add(Rectangle{X: 4, Y: 4, Width: 8, Height: 8})
+	scene.Render()
+
+	scene.Remove(node)
+	scene.Remove(node)
+	scene.Render()
+
+	if len(scene.Nodes()) != 1 {
+		t.Errorf("len(Nodes()) = %d, want 1", len(scene.Nodes()))
+	}
+	// The crossing line is redrawn where the rectangle was removed
+	expected := canvas.New(20, 16)
+	keep.Draw(expected)
+	stippletest.AssertEqual(t, expected, c)
+}
+
+func TestSceneZOrder(t *testing.T) {
+	red := colorSquare{x: 0, y: 0, size: 4, color: canvas.ColorRed}
+	blue := colorSquare{x: 2, y: 0, size: 4, color: canvas.ColorBlue}
+
+	c := canvas.New(8, 4, canvas.WithColor())
+	scene := New(c)
+	redNode := scene.Add(red)
+	scene.Add(blue)
+	scene.Render()
+	stippletest.AssertEqual(t, expectedCanvas(8, 4, red, blue), c)
+	if color := c.GetColor(2, 0); color != canvas.ColorBlue {
+		t.Errorf("overlap color = %v, want blue (added last)", color)
+	}
+
+	redNode.SetZ(1)
+	scene.Render()
+	if color := c.GetColor(2, 0); color != canvas.ColorRed {
+		t.Errorf("overlap color = %v after SetZ(1), want red", color)
+	}
+
+	nodes := scene.Nodes()
+	if nodes[len(nodes)-1] != redNode || redNode.Z() != 1 {
+		t.Errorf("Nodes() does not end with the raised node")
+	}
+}
+
+func TestSceneRedrawsOnlyDirtyRegions(t *testing.T) {
+	drawsLeft, drawsRight := 0, 0
+	left := colorSquare{x: 0, y: 0, size: 4, color: canvas.ColorRed, draws: &drawsLeft}
+	right := colorSquare{x: 10, y: 0, size: 4, color: canvas.ColorGreen, draws: &drawsRight}
+
+	c := canvas.New(16, 8, canvas.WithColor())
+	scene := New(c)
+	scene.Add(left)
+	rightNode := scene.Add(right)
+	scene.Render()
+
+	// A pixel drawn outside the scene survives a render that does not touch it
+	c.Set(6, 6)
+	right.y = 4
+	rightNode.SetDrawable(right)
+	scene.Render()
+
+	if drawsLeft != 1 || drawsRight != 2 {
+		t.Errorf("draws = (%d, %d), want (1, 2)", drawsLeft, drawsRight)
+	}
+	if !c.Get(6, 6) {
+		t.Error("pixel outside the dirty regions was cleared")
+	}
+	c.Unset(6, 6)
+	stippletest.AssertEqual(t, expectedCanvas(16, 8, left, right), c)
+}
+
+func TestSceneRenderIsClipped(t *testing.T) {
+	// Redrawing a node for a small dirty region must not repaint the colors of
+	// its pixels elsewhere, where a higher node covers it
+	bottom := colorSquare{x: 0, y: 0, size: 8, color: canvas.ColorRed}
+	top := colorSquare{x: 4, y: 0, size: 4, color: canvas.ColorBlue}
+
+	c := canvas.New(8, 8, canvas.WithColor())
+	scene := New(c)
+	scene.Add(bottom)
+	scene.Add(top)
+	scene.Render()
+
+	scene.Invalidate(canvas.Rect{X: 0, Y: 4, Width: 2, Height: 4})
+	scene.Render()
+
+	if color := c.GetColor(4, 0); color != canvas.ColorBlue {
+		t.Errorf("GetColor(4, 0) = %v, want blue", color)
+	}
+}
+
+func TestSceneRenderKeepsCanvasClip(t *testing.T) {
+	c := canvas.New(8, 8)
+	clip := canvas.Rect{X: 0, Y: 0, Width: 4, Height: 8}
+	c.SetClip(clip)
+
+	scene := New(c)
+	scene.Add(colorSquare{x: 0, y: 0, size: 8})
+	scene.Render()
+
+	if actual, clipped := c.Clip(); !clipped || actual != clip {
+		t.Errorf("Clip() after Render = %v, %v, want %v, true", actual, clipped, clip)
+	}
+	if c.Get(6, 2) {
+		t.Error("Render() drew outside the canvas clip")
+	}
+	if !c.Get(2, 2) {
+		t.Error("Render() did not draw inside the canvas clip")
+	}
+}
+
+func TestSceneRenderAll(t *testing.T) {
+	c := canvas.New(20, 16)
+	c.Set(19, 0)
+	scene := New(c)
+	line := Line{StartX: 0, StartY: 8, EndX: 19, EndY: 8}
+	scene.Add(line)
+	scene.RenderAll()
+
+	expected := canvas.New(20, 16)
+	line.Draw(expected)
+	stippletest.AssertEqual(t, expected, c)
+	if scene.Dirty() {
+		t.Error("Dirty() = true after RenderAll, want false")
+	}
+}
+
+func TestSceneDirtyRegionsLimitedToCanvas(t *testing.T) {
+	c := canvas.New(20, 16)
+	scene := New(c)
+	scene.Add(Line{StartX: -1000, StartY: 8, EndX: 1000, EndY: 8})
+	scene.Add(Rectangle{X: 100, Y: 100, Width: 5, Height: 5})
+	scene.Render()
+
+	if !c.Get(0, 8) || !c.Get(19, 8) {
+		t.Error("line through the canvas was not drawn")
+	}
+}
+
+func TestSceneInvertedY(t *testing.T) {
+	c := canvas.New(20, 16, canvas.WithInvertedY())
+	scene := New(c)
+	node := scene.Add(Rectangle{X: 2, Y: 2, Width: 5, Height: 5, Filled: true})
+	scene.Render()
+	node.SetDrawable(Rectangle{X: 10, Y: 8, Width: 5, Height: 5, Filled: true})
+	scene.Render()
+
+	expected := canvas.New(20, 16, canvas.WithInvertedY())
+	Rectangle{X: 10, Y: 8, Width: 5, Height: 5, Filled: true}.Draw(expected)
+	stippletest.AssertEqual(t, expected, c)
+}
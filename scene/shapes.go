@@ -0,0 +1,82 @@
+package scene
+
+import (
+	"math"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+)
+
+// Line is a Drawable line segment from (StartX, StartY) to (EndX, EndY).
+type Line struct {
+	StartX float64
+	StartY float64
+	EndX   float64
+	EndY   float64
+}
+
+// Draw draws the line with draw.Line.
+func (line Line) Draw(c *canvas.Canvas) {
+	draw.Line(c, line.StartX, line.StartY, line.EndX, line.EndY)
+}
+
+// Bounds returns the pixels between the line's endpoints.
+func (line Line) Bounds() canvas.Rect {
+	return canvas.PixelBounds(line.StartX, line.StartY, line.EndX, line.EndY)
+}
+
+// Rectangle is a Drawable rectangle with its top-left corner at (X, Y).
+type Rectangle struct {
+	X      float64
+	Y      float64
+	Width  float64
+	Height float64
+	Filled bool // whether to fill the rectangle instead of drawing its outline
+}
+
+// Draw draws the rectangle with draw.Rectangle or draw.RectangleFilled.
+func (rectangle Rectangle) Draw(c *canvas.Canvas) {
+	if rectangle.Filled {
+		draw.RectangleFilled(c, rectangle.X, rectangle.Y, rectangle.Width, rectangle.Height)
+		return
+	}
+	draw.Rectangle(c, rectangle.X, rectangle.Y, rectangle.Width, rectangle.Height)
+}
+
+// Bounds returns the pixels covered by the rectangle, or an empty rectangle
+// when its width or height is not positive.
+func (rectangle Rectangle) Bounds() canvas.Rect {
+	if rectangle.Width <= 0 || rectangle.Height <= 0 {
+		return canvas.Rect{}
+	}
+	return canvas.PixelBounds(rectangle.X, rectangle.Y,
+		rectangle.X+rectangle.Width-1, rectangle.Y+rectangle.Height-1)
+}
+
+// Circle is a Drawable circle centered at (CenterX, CenterY).
+type Circle struct {
+	CenterX float64
+	CenterY float64
+	Radius  float64
+	Filled  bool // whether to fill the circle instead of drawing its outline
+}
+
+// Draw draws the circle with draw.Circle or draw.CircleFilled.
+func (circle Circle) Draw(c *canvas.Canvas) {
+	if circle.Filled {
+		draw.CircleFilled(c, circle.CenterX, circle.CenterY, circle.Radius)
+		return
+	}
+	draw.Circle(c, circle.CenterX, circle.CenterY, circle.Radius)
+}
+
+// Bounds returns the square of pixels around the circle, or an empty rectangle
+// when its radius is negative.
+func (circle Circle) Bounds() canvas.Rect {
+	if circle.Radius < 0 {
+		return canvas.Rect{}
+	}
+	centerX, centerY := math.Floor(circle.CenterX), math.Floor(circle.CenterY)
+	radius := math.Floor(circle.Radius)
+	return canvas.PixelBounds(centerX-radius, centerY-radius, centerX+radius, centerY+radius)
+}
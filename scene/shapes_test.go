@@ -0,0 +1,74 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+func TestShapesMatchDrawFunctions(t *testing.T) {
+	tests := []struct {
+		name     string
+		drawable Drawable
+		drawFunc func(c *canvas.Canvas)
+	}{
+		{"line", Line{StartX: 1, StartY: 2, EndX: 17, EndY: 9},
+			func(c *canvas.Canvas) { draw.Line(c, 1, 2, 17, 9) }},
+		{"rectangle", Rectangle{X: 2, Y: 1, Width: 10, Height: 6},
+			func(c *canvas.Canvas) { draw.Rectangle(c, 2, 1, 10, 6) }},
+		{"filled rectangle", Rectangle{X: 2, Y: 1, Width: 10, Height: 6, Filled: true},
+			func(c *canvas.Canvas) { draw.RectangleFilled(c, 2, 1, 10, 6) }},
+		{"circle", Circle{CenterX: 10, CenterY: 8, Radius: 6},
+			func(c *canvas.Canvas) { draw.Circle(c, 10, 8, 6) }},
+		{"filled circle", Circle{CenterX: 10, CenterY: 8, Radius: 6, Filled: true},
+			func(c *canvas.Canvas) { draw.CircleFilled(c, 10, 8, 6) }},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			expected := canvas.New(20, 16)
+			testCase.drawFunc(expected)
+			actual := canvas.New(20, 16)
+			testCase.drawable.Draw(actual)
+			stippletest.AssertEqual(t, expected, actual)
+		})
+	}
+}
+
+func TestShapeBounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		drawable Drawable
+		expected canvas.Rect
+	}{
+		{"line", Line{StartX: 17, StartY: 9, EndX: 1, EndY: 2}, canvas.Rect{X: 1, Y: 2, Width: 17, Height: 8}},
+		{"point line", Line{StartX: 3.5, StartY: 4.5, EndX: 3.5, EndY: 4.5}, canvas.Rect{X: 3, Y: 4, Width: 1, Height: 1}},
+		{"rectangle", Rectangle{X: 2, Y: 1, Width: 10, Height: 6}, canvas.Rect{X: 2, Y: 1, Width: 10, Height: 6}},
+		{"empty rectangle", Rectangle{X: 2, Y: 1, Width: 0, Height: 6}, canvas.Rect{}},
+		{"circle", Circle{CenterX: 10, CenterY: 8, Radius: 6}, canvas.Rect{X: 4, Y: 2, Width: 13, Height: 13}},
+		{"zero radius", Circle{CenterX: 10.5, CenterY: 8.5}, canvas.Rect{X: 10, Y: 8, Width: 1, Height: 1}},
+		{"negative radius", Circle{CenterX: 10, CenterY: 8, Radius: -1}, canvas.Rect{}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			bounds := testCase.drawable.Bounds()
+			if bounds != testCase.expected {
+				t.Errorf("Bounds() = %+v, want %+v", bounds, testCase.expected)
+			}
+
+			// Every pixel the shape draws lies inside its bounds
+			c := canvas.New(40, 40)
+			testCase.drawable.Draw(c)
+			for y := 0.0; y < 40; y++ {
+				for x := 0.0; x < 40; x++ {
+					if c.Get(x, y) && !bounds.Contains(x, y) {
+						t.Errorf("pixel (%.0f, %.0f) is outside Bounds() %+v", x, y, bounds)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package sprite
+
+import (
+	"math"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// Option is a functional option for drawing frames and sprites.
+type Option func(*drawing)
+
+// WithDepth returns an option that depth tests every pixel at depth z against
+// the canvas depth buffer, using SetDepth and SetColorDepth, so nearer drawings
+// hide the sprite. Off pixels only erase where the sprite is nearer than the
+// stored depth. The canvas needs canvas.WithDepth for the test to take effect.
+func WithDepth(z float64) Option {
+	return func(drawing *drawing) {
+		drawing.depth = &z
+	}
+}
+
+// WithOcclusion returns an option that skips every pixel column where visible
+// reports false for the given distance, such as raycast.Depth.Visible with the
+// distance from raycast.View.Project, so walls in front hide the sprite.
+func WithOcclusion(distance float64, visible func(column int, distance float64) bool) Option {
+	return func(drawing *drawing) {
+		drawing.distance = distance
+		drawing.visible = visible
+	}
+}
+
+// drawing holds the options for a single draw call.
+type drawing struct {
+	depth    *float64                                // depth for WithDepth, nil when not depth testing
+	distance float64                                 // distance passed to visible
+	visible  func(column int, distance float64) bool // column test for WithOcclusion, nil when unoccluded
+}
+
+// newDrawing applies options to a new drawing.
+func newDrawing(options []Option) *drawing {
+	drawing := &drawing{}
+	for _, option := range options {
+		option(drawing)
+	}
+	return drawing
+}
+
+// plot draws one sprite pixel at canvas coordinates (x, y).
+func (drawing *drawing) plot(c *canvas.Canvas, x, y float64, pixel Pixel) {
+	if pixel.Mode == Transparent {
+		return
+	}
+	if drawing.visible != nil && !drawing.visible(int(math.Floor(x)), drawing.distance) {
+		return
+	}
+
+	if drawing.depth != nil {
+		z := *drawing.depth
+		switch {
+		case pixel.Mode == Off:
+			if z < c.Depth(x, y) {
+				c.Unset(x, y)
+			}
+		case pixel.Color == canvas.ColorDefault:
+			c.SetDepth(x, y, z)
+		default:
+			c.SetColorDepth(x, y, z, pixel.Color)
+		}
+		return
+	}
+
+	switch {
+	case pixel.Mode == Off:
+		c.Unset(x, y)
+	case pixel.Color == canvas.ColorDefault:
+		c.Set(x, y)
+	default:
+		c.SetColor(x, y, pixel.Color)
+	}
+}
@@ -0,0 +1,315 @@
+package sprite
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// Reserved pixel-art characters, which cannot be used as color letters.
+const (
+	pixelLit         = '#'
+	pixelTransparent = '.'
+	pixelUnlit       = '-'
+)
+
+// colorNames maps the names accepted by color lines to colors.
+var colorNames = map[string]canvas.Color{
+	"black":   canvas.ColorBlack,
+	"blue":    canvas.ColorBlue,
+	"cyan":    canvas.ColorCyan,
+	"default": canvas.ColorDefault,
+	"green":   canvas.ColorGreen,
+	"magenta": canvas.ColorMagenta,
+	"red":     canvas.ColorRed,
+	"white":   canvas.ColorWhite,
+	"yellow":  canvas.ColorYellow,
+}
+
+// defaultPalette returns the built-in color letters.
+func defaultPalette() map[rune]canvas.Color {
+	return map[rune]canvas.Color{
+		'b': canvas.ColorBlue,
+		'c': canvas.ColorCyan,
+		'g': canvas.ColorGreen,
+		'k': canvas.ColorBlack,
+		'm': canvas.ColorMagenta,
+		'r': canvas.ColorRed,
+		'w': canvas.ColorWhite,
+		'y': canvas.ColorYellow,
+	}
+}
+
+// Parse decodes a single sprite from the text format described in the
+// package documentation. It returns an error if the text holds more than one
+// sprite.
+func Parse(text string) (*Sprite, error) {
+	sheet, err := ParseSheet(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(sheet) != 1 {
+		return nil, fmt.Errorf("found %d sprites, want 1", len(sheet))
+	}
+	for _, sprite := range sheet {
+		return sprite, nil
+	}
+	return nil, nil
+}
+
+// LoadSheet reads a sprite sheet from reader, as ParseSheet does.
+func LoadSheet(reader io.Reader) (Sheet, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSheet(string(data))
+}
+
+// ParseSheet decodes a sprite sheet from the text format described in the
+// package documentation. Frames of the same sprite are padded with
+// transparent pixels to the size of the largest one.
+func ParseSheet(text string) (Sheet, error) {
+	parser := &parser{palette: defaultPalette(), sheet: Sheet{}}
+	for index, line := range strings.Split(text, "\n") {
+		if err := parser.line(index+1, strings.TrimSpace(line)); err != nil {
+			return nil, err
+		}
+	}
+	if err := parser.finishSprite(); err != nil {
+		return nil, err
+	}
+	if len(parser.sheet) == 0 {
+		return nil, errors.New("no sprites")
+	}
+	return parser.sheet, nil
+}
+
+// parser holds the state of ParseSheet between lines.
+type parser struct {
+	braille    bool                  // whether the pending frame is braille text
+	duration   time.Duration         // duration of the pending frame
+	framing    bool                  // whether a frame is pending
+	palette    map[rune]canvas.Color // color letters
+	rowLines   []int                 // line numbers of the pending rows
+	rows       []string              // rows of the pending frame
+	sheet      Sheet                 // sprites parsed so far
+	sprite     *Sprite               // sprite receiving frames, nil before the first one
+	spriteLine int                   // line number where the current sprite started
+}
+
+// line handles one trimmed line of input.
+func (parser *parser) line(number int, line string) error {
+	if line == "" || strings.HasPrefix(line, "//") {
+		return nil
+	}
+
+	fields := strings.Fields(line)
+	switch fields[0] {
+	case "sprite":
+		name := strings.TrimSpace(strings.TrimPrefix(line, "sprite"))
+		if name == "" {
+			return fmt.Errorf("line %d: sprite needs a name", number)
+		}
+		if err := parser.finishSprite(); err != nil {
+			return err
+		}
+		return parser.startSprite(number, name)
+	case "frame":
+		if len(fields) > 2 {
+			return fmt.Errorf("line %d: frame takes at most one duration", number)
+		}
+		duration := DefaultFrameDuration
+		if len(fields) == 2 {
+			parsed, err := time.ParseDuration(fields[1])
+			if err != nil || parsed < 0 {
+				return fmt.Errorf("line %d: invalid duration %q", number, fields[1])
+			}
+			duration = parsed
+		}
+		if err := parser.finishFrame(); err != nil {
+			return err
+		}
+		return parser.startFrame(number, duration)
+	case "color":
+		return parser.color(number, fields)
+	}
+
+	if !parser.framing {
+		if err := parser.startFrame(number, DefaultFrameDuration); err != nil {
+			return err
+		}
+	}
+	braille := isBraille(line)
+	if len(parser.rows) == 0 {
+		parser.braille = braille
+	}
+	switch {
+	case braille && !parser.braille:
+		return fmt.Errorf("line %d: braille row in a pixel-art frame", number)
+	case !braille && parser.braille:
+		return fmt.Errorf("line %d: pixel-art row in a braille frame", number)
+	}
+	parser.rows = append(parser.rows, line)
+	parser.rowLines = append(parser.rowLines, number)
+	return nil
+}
+
+// color handles a color line, assigning a color to a letter.
+func (parser *parser) color(number int, fields []string) error {
+	if len(fields) != 3 {
+		return fmt.Errorf("line %d: color needs a letter and a value", number)
+	}
+	letters := []rune(fields[1])
+	if len(letters) != 1 {
+		return fmt.Errorf("line %d: color letter %q must be a single character", number, fields[1])
+	}
+	letter := letters[0]
+	if letter == pixelLit || letter == pixelTransparent || letter == pixelUnlit || isBraille(fields[1]) {
+		return fmt.Errorf("line %d: %q is reserved and cannot be a color letter", number, letter)
+	}
+	color, err := parseColor(fields[2])
+	if err != nil {
+		return fmt.Errorf("line %d: %w", number, err)
+	}
+	parser.palette[letter] = color
+	return nil
+}
+
+// startSprite begins a new sprite called name.
+func (parser *parser) startSprite(number int, name string) error {
+	if _, ok := parser.sheet[name]; ok {
+		return fmt.Errorf("line %d: duplicate sprite %q", number, name)
+	}
+	parser.sprite = &Sprite{Name: name}
+	parser.spriteLine = number
+	parser.sheet[name] = parser.sprite
+	return nil
+}
+
+// startFrame begins a new frame, starting an unnamed sprite if none was started.
+func (parser *parser) startFrame(number int, duration time.Duration) error {
+	if parser.sprite == nil {
+		if err := parser.startSprite(number, ""); err != nil {
+			return err
+		}
+	}
+	parser.framing = true
+	parser.duration = duration
+	parser.rows = nil
+	parser.rowLines = nil
+	return nil
+}
+
+// finishFrame adds the pending frame, if any, to the current sprite.
+func (parser *parser) finishFrame() error {
+	if !parser.framing {
+		return nil
+	}
+	parser.framing = false
+
+	var frame Frame
+	var err error
+	if parser.braille {
+		frame, err = brailleFrame(parser.rows, parser.rowLines)
+	} else {
+		frame, err = parser.pixelFrame()
+	}
+	if err != nil {
+		return err
+	}
+	frame.Duration = parser.duration
+	parser.sprite.Frames = append(parser.sprite.Frames, frame)
+	return nil
+}
+
+// finishSprite completes the current sprite, if any, padding its frames to a
+// common size.
+func (parser *parser) finishSprite() error {
+	if err := parser.finishFrame(); err != nil {
+		return err
+	}
+	sprite := parser.sprite
+	if sprite == nil {
+		return nil
+	}
+	if len(sprite.Frames) == 0 {
+		return fmt.Errorf("line %d: sprite %q has no frames", parser.spriteLine, sprite.Name)
+	}
+	width, height := sprite.Width(), sprite.Height()
+	for index, frame := range sprite.Frames {
+		sprite.Frames[index] = frame.resize(width, height)
+	}
+	return nil
+}
+
+// pixelFrame decodes the pending pixel-art rows.
+func (parser *parser) pixelFrame() (Frame, error) {
+	pixels := make([][]Pixel, len(parser.rows))
+	for row, text := range parser.rows {
+		number := parser.rowLines[row]
+		for column, character := range []rune(text) {
+			var pixel Pixel
+			switch character {
+			case pixelLit:
+				pixel.Mode = On
+			case pixelTransparent:
+			case pixelUnlit:
+				pixel.Mode = Off
+			default:
+				color, ok := parser.palette[character]
+				if !ok {
+					return Frame{}, fmt.Errorf("line %d, column %d: unknown pixel %q", number, column+1, character)
+				}
+				pixel = Pixel{Mode: On, Color: color}
+			}
+			pixels[row] = append(pixels[row], pixel)
+		}
+		if row > 0 && len(pixels[row]) != len(pixels[0]) {
+			return Frame{}, fmt.Errorf("line %d: row has %d pixels, want %d", number, len(pixels[row]), len(pixels[0]))
+		}
+	}
+	return Frame{Pixels: pixels}, nil
+}
+
+// brailleFrame decodes braille rows read from the given line numbers.
+func brailleFrame(rows []string, lines []int) (Frame, error) {
+	decoded, err := canvas.Parse(strings.Join(rows, "\n"))
+	if err != nil {
+		var parseError *canvas.ParseError
+		if errors.As(err, &parseError) {
+			return Frame{}, fmt.Errorf("line %d: %s", lines[parseError.Line-1], parseError.Message)
+		}
+		return Frame{}, err
+	}
+	return FromCanvas(decoded), nil
+}
+
+// isBraille reports whether a row is braille text rather than pixel art:
+// braille rows contain braille patterns or ANSI escape sequences.
+func isBraille(row string) bool {
+	for _, character := range row {
+		if character == '\x1b' || (character >= canvas.BrailleOffset && character <= canvas.BrailleOffset+0xFF) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseColor decodes a color name or a #rrggbb truecolor value.
+func parseColor(value string) (canvas.Color, error) {
+	if color, ok := colorNames[strings.ToLower(value)]; ok {
+		return color, nil
+	}
+	if len(value) == 7 && value[0] == '#' {
+		if rgb, err := strconv.ParseUint(value[1:], 16, 32); err == nil {
+			return canvas.RGB(uint8(rgb>>16), uint8(rgb>>8), uint8(rgb)), nil
+		}
+	}
+	return canvas.ColorDefault, fmt.Errorf("invalid color %q, want a color name or #rrggbb", value)
+}
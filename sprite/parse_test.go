@@ -0,0 +1,218 @@
+package sprite
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+func TestParsePixelArt(t *testing.T) {
+	sprite, err := Parse(`
+		// a small arrow
+		..#..
+		.###.
+		#-#-#
+	`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if sprite.Name != "" {
+		t.Errorf("Name = %q, want empty", sprite.Name)
+	}
+	if len(sprite.Frames) != 1 {
+		t.Fatalf("len(Frames) = %d, want 1", len(sprite.Frames))
+	}
+	frame := sprite.Frames[0]
+	if frame.Width() != 5 || frame.Height() != 3 {
+		t.Errorf("size = %dx%d, want 5x3", frame.Width(), frame.Height())
+	}
+	if frame.Duration != DefaultFrameDuration {
+		t.Errorf("Duration = %v, want %v", frame.Duration, DefaultFrameDuration)
+	}
+
+	tests := []struct {
+		x, y     int
+		expected Mode
+	}{
+		{0, 0, Transparent},
+		{2, 0, On},
+		{1, 2, Off},
+		{4, 2, On},
+	}
+	for _, testCase := range tests {
+		if pixel := frame.At(testCase.x, testCase.y); pixel.Mode != testCase.expected {
+			t.Errorf("At(%d, %d).Mode = %d, want %d", testCase.x, testCase.y, pixel.Mode, testCase.expected)
+		}
+	}
+}
+
+func TestParseColors(t *testing.T) {
+	sprite, err := Parse(`
+		color o #ff8000
+		color r blue
+		krgybmcw
+		o#......
+	`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	frame := sprite.Frames[0]
+
+	expected := []canvas.Color{
+		canvas.ColorBlack,
+		canvas.ColorBlue, // redefined
+		canvas.ColorGreen,
+		canvas.ColorYellow,
+		canvas.ColorBlue,
+		canvas.ColorMagenta,
+		canvas.ColorCyan,
+		canvas.ColorWhite,
+	}
+	for x, color := range expected {
+		if pixel := frame.At(x, 0); pixel != (Pixel{Mode: On, Color: color}) {
+			t.Errorf("At(%d, 0) = %v, want lit with %v", x, pixel, color)
+		}
+	}
+	if pixel := frame.At(0, 1); pixel.Color != canvas.RGB(0xff, 0x80, 0x00) {
+		t.Errorf("At(0, 1).Color = %v, want RGB(255, 128, 0)", pixel.Color)
+	}
+}
+
+func TestParseBraille(t *testing.T) {
+	source := canvas.New(8, 8, canvas.WithColor())
+	draw.CircleColor(source, 3, 3, 3, canvas.ColorRed)
+
+	sprite, err := Parse(source.Frame())
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	frame := sprite.Frames[0]
+	if frame.Width() != 8 || frame.Height() != 8 {
+		t.Errorf("size = %dx%d, want 8x8", frame.Width(), frame.Height())
+	}
+
+	actual := canvas.New(8, 8, canvas.WithColor())
+	frame.Draw(actual, 0, 0)
+	stippletest.AssertEqual(t, source, actual)
+}
+
+func TestParseFrames(t *testing.T) {
+	sprite, err := Parse(`
+		frame 150ms
+		#..
+		frame
+		.#
+		.#
+		frame 1s
+	`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	durations := []time.Duration{150 * time.Millisecond, DefaultFrameDuration, time.Second}
+	if len(sprite.Frames) != len(durations) {
+		t.Fatalf("len(Frames) = %d, want %d", len(sprite.Frames), len(durations))
+	}
+	for index, frame := range sprite.Frames {
+		if frame.Duration != durations[index] {
+			t.Errorf("frame %d: Duration = %v, want %v", index, frame.Duration, durations[index])
+		}
+
+		// Frames are padded to the sprite's size
+		if frame.Width() != 3 || frame.Height() != 2 {
+			t.Errorf("frame %d: size = %dx%d, want 3x2", index, frame.Width(), frame.Height())
+		}
+	}
+}
+
+func TestParseSheet(t *testing.T) {
+	sheet, err := ParseSheet(`
+		color o yellow
+		sprite coin
+		frame 80ms
+		.o.
+		frame 80ms
+		.#.
+
+		sprite eye
+		⢾⡷
+	`)
+	if err != nil {
+		t.Fatalf("ParseSheet() error = %v", err)
+	}
+
+	if len(sheet) != 2 {
+		t.Fatalf("len(sheet) = %d, want 2", len(sheet))
+	}
+	coin, eye := sheet["coin"], sheet["eye"]
+	if coin == nil || eye == nil {
+		t.Fatalf("sheet = %v, want sprites coin and eye", sheet)
+	}
+	if coin.Name != "coin" || len(coin.Frames) != 2 {
+		t.Errorf("coin = %q with %d frames, want coin with 2", coin.Name, len(coin.Frames))
+	}
+	if pixel := coin.Frames[0].At(1, 0); pixel.Color != canvas.ColorYellow {
+		t.Errorf("coin At(1, 0).Color = %v, want %v", pixel.Color, canvas.ColorYellow)
+	}
+	if eye.Width() != 4 || eye.Height() != 4 {
+		t.Errorf("eye size = %dx%d, want 4x4", eye.Width(), eye.Height())
+	}
+
+	// Parse rejects sheets with more than one sprite
+	if _, err := Parse("sprite a\n#\nsprite b\n#"); err == nil {
+		t.Error("Parse() of two sprites: error = nil, want error")
+	}
+}
+
+func TestLoadSheet(t *testing.T) {
+	sheet, err := LoadSheet(strings.NewReader("sprite dot\n#"))
+	if err != nil {
+		t.Fatalf("LoadSheet() error = %v", err)
+	}
+	if sprite := sheet["dot"]; sprite == nil || sprite.Width() != 1 {
+		t.Errorf("sheet[dot] = %v, want a 1-pixel sprite", sprite)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"empty", "  \n// nothing\n", "no sprites"},
+		{"unknown pixel", "#.\n#x", "line 2, column 2: unknown pixel 'x'"},
+		{"ragged rows", "##\n#", "line 2: row has 1 pixels, want 2"},
+		{"mixed rows", "##\n⣿", "line 2: braille row in a pixel-art frame"},
+		{"mixed braille", "⣿\n##", "line 2: pixel-art row in a braille frame"},
+		{"ragged braille", "⣿⣿\n⣿", "line 2: row has 1 cells, want 2"},
+		{"bad duration", "frame soon\n#", `line 1: invalid duration "soon"`},
+		{"negative duration", "frame -1s\n#", `line 1: invalid duration "-1s"`},
+		{"extra frame field", "frame 1s 2s", "line 1: frame takes at most one duration"},
+		{"unnamed sprite", "sprite\n#", "line 1: sprite needs a name"},
+		{"empty sprite", "sprite a\nsprite b\n#", `line 1: sprite "a" has no frames`},
+		{"duplicate sprite", "sprite a\n#\nsprite a\n#", `line 3: duplicate sprite "a"`},
+		{"color fields", "color x", "line 1: color needs a letter and a value"},
+		{"color letter", "color xy red", `line 1: color letter "xy" must be a single character`},
+		{"reserved letter", "color # red", "line 1: '#' is reserved and cannot be a color letter"},
+		{"bad color", "color x purple", `line 1: invalid color "purple", want a color name or #rrggbb`},
+		{"bad hex", "color x #12345g", `line 1: invalid color "#12345g", want a color name or #rrggbb`},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := ParseSheet(testCase.text)
+			if err == nil {
+				t.Fatalf("ParseSheet(%q) error = nil, want %q", testCase.text, testCase.expected)
+			}
+			if err.Error() != testCase.expected {
+				t.Errorf("ParseSheet(%q) error = %q, want %q", testCase.text, err.Error(), testCase.expected)
+			}
+		})
+	}
+}
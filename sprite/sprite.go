@@ -0,0 +1,267 @@
+// Package sprite loads small, optionally animated drawings from text and draws
+// them onto a canvas.
+//
+// Sprites are written either as pixel art, one character per pixel, or as
+// braille text such as the output of canvas.Frame, with 2x4 pixels per
+// character. In pixel art, '#' is a lit pixel, '.' is transparent, '-' is an
+// unlit pixel that erases whatever is below it, and a color letter is a lit
+// pixel in that color:
+//
+//	k black    r red      g green    y yellow
+//	b blue     m magenta  c cyan     w white
+//
+// Braille rows light their raised dots, leave the other dots transparent, and
+// take colors from ANSI color sequences.
+//
+// A sprite sheet holds one or more sprites, each with one or more frames.
+// Lines that start with a keyword structure the sheet:
+//
+//	sprite NAME       start a sprite called NAME
+//	frame [DURATION]  start a frame shown for DURATION (such as 150ms, default 100ms)
+//	color L VALUE     make letter L a lit pixel in VALUE, a color name or #rrggbb
+//
+// Rows before the first frame line form a frame with the default duration, and
+// frames before the first sprite line belong to a sprite with an empty name.
+// Leading and trailing whitespace is ignored, as are blank lines and lines
+// starting with "//".
+package sprite
+
+import (
+	"time"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// DefaultFrameDuration is how long a frame is shown when its frame line gives
+// no duration.
+const DefaultFrameDuration = 100 * time.Millisecond
+
+// Mode describes how a sprite pixel changes the canvas pixel under it.
+type Mode uint8
+
+// Pixel modes (grouped, with Transparent as the zero value).
+const (
+	Transparent Mode = iota // leaves the canvas pixel unchanged
+	On                      // lights the canvas pixel
+	Off                     // turns the canvas pixel off
+)
+
+// Pixel is one pixel of a frame.
+type Pixel struct {
+	Mode  Mode         // how the pixel is drawn
+	Color canvas.Color // color of an On pixel, ColorDefault for no color
+}
+
+// Frame is a single image of a sprite.
+type Frame struct {
+	Pixels   [][]Pixel     // pixel grid [row][column], top row first
+	Duration time.Duration // how long the frame is shown in an animation
+}
+
+// FromCanvas returns a frame with the lit pixels of c, colored with their cell
+// colors, and transparent pixels everywhere else. The top row of the frame is
+// the top row of the canvas as displayed, so it works with WithInvertedY too.
+func FromCanvas(c *canvas.Canvas) Frame {
+	pixels := make([][]Pixel, c.Height())
+	for row := range pixels {
+		pixels[row] = make([]Pixel, c.Width())
+		y := float64(row)
+		if c.InvertedY() {
+			y = float64(c.Height() - 1 - row)
+		}
+		for column := range pixels[row] {
+			x := float64(column)
+			if c.Get(x, y) {
+				pixels[row][column] = Pixel{Mode: On, Color: c.GetColor(x, y)}
+			}
+		}
+	}
+	return Frame{Pixels: pixels, Duration: DefaultFrameDuration}
+}
+
+// Width returns the width of the frame in pixels.
+func (frame Frame) Width() int {
+	width := 0
+	for _, row := range frame.Pixels {
+		width = max(width, len(row))
+	}
+	return width
+}
+
+// Height returns the height of the frame in pixels.
+func (frame Frame) Height() int {
+	return len(frame.Pixels)
+}
+
+// At returns the pixel at column x and row y, counted from the top-left corner.
+// Positions outside the frame are Transparent.
+func (frame Frame) At(x, y int) Pixel {
+	if y < 0 || y >= len(frame.Pixels) || x < 0 || x >= len(frame.Pixels[y]) {
+		return Pixel{}
+	}
+	return frame.Pixels[y][x]
+}
+
+// Draw draws the frame onto c with its top-left pixel at (x, y). Rows extend
+// down the screen, so on a WithInvertedY canvas they go toward smaller y.
+// Transparent pixels leave the canvas unchanged.
+func (frame Frame) Draw(c *canvas.Canvas, x, y float64, options ...Option) {
+	drawing := newDrawing(options)
+	rowStep := 1.0
+	if c.InvertedY() {
+		rowStep = -1
+	}
+	for row, pixels := range frame.Pixels {
+		pixelY := y + float64(row)*rowStep
+		for column, pixel := range pixels {
+			drawing.plot(c, x+float64(column), pixelY, pixel)
+		}
+	}
+}
+
+// FlipHorizontal returns a copy of the frame mirrored left to right.
+func (frame Frame) FlipHorizontal() Frame {
+	width := frame.Width()
+	flipped := frame.resize(width, frame.Height())
+	for _, row := range flipped.Pixels {
+		for left, right := 0, width-1; left < right; left, right = left+1, right-1 {
+			row[left], row[right] = row[right], row[left]
+		}
+	}
+	return flipped
+}
+
+// FlipVertical returns a copy of the frame mirrored top to bottom.
+func (frame Frame) FlipVertical() Frame {
+	flipped := frame.resize(frame.Width(), frame.Height())
+	rows := flipped.Pixels
+	for top, bottom := 0, len(rows)-1; top < bottom; top, bottom = top+1, bottom-1 {
+		rows[top], rows[bottom] = rows[bottom], rows[top]
+	}
+	return flipped
+}
+
+// resize returns a copy of the frame with the given size, cropping pixels
+// outside it and padding with transparent pixels.
+func (frame Frame) resize(width, height int) Frame {
+	pixels := make([][]Pixel, height)
+	for row := range pixels {
+		pixels[row] = make([]Pixel, width)
+		if row < len(frame.Pixels) {
+			copy(pixels[row], frame.Pixels[row])
+		}
+	}
+	return Frame{Pixels: pixels, Duration: frame.Duration}
+}
+
+// Sprite is a named sequence of frames that plays as a looping animation.
+type Sprite struct {
+	Name   string
+	Frames []Frame
+}
+
+// Width returns the width of the sprite's widest frame in pixels.
+func (sprite *Sprite) Width() int {
+	width := 0
+	for _, frame := range sprite.Frames {
+		width = max(width, frame.Width())
+	}
+	return width
+}
+
+// Height returns the height of the sprite's tallest frame in pixels.
+func (sprite *Sprite) Height() int {
+	height := 0
+	for _, frame := range sprite.Frames {
+		height = max(height, frame.Height())
+	}
+	return height
+}
+
+// Duration returns the total duration of one pass through the animation.
+func (sprite *Sprite) Duration() time.Duration {
+	var total time.Duration
+	for _, frame := range sprite.Frames {
+		total += max(frame.Duration, 0)
+	}
+	return total
+}
+
+// FrameAt returns the index of the frame shown after elapsed time, looping
+// the animation. Sprites with no total duration always show frame 0.
+func (sprite *Sprite) FrameAt(elapsed time.Duration) int {
+	total := sprite.Duration()
+	if total <= 0 {
+		return 0
+	}
+	elapsed %= total
+	if elapsed < 0 {
+		elapsed += total
+	}
+	for index, frame := range sprite.Frames {
+		if elapsed < frame.Duration {
+			return index
+		}
+		elapsed -= max(frame.Duration, 0)
+	}
+	return len(sprite.Frames) - 1
+}
+
+// Draw draws the frame shown after elapsed time with its top-left pixel at
+// (x, y), as Frame.Draw does. A sprite without frames draws nothing.
+func (sprite *Sprite) Draw(c *canvas.Canvas, x, y float64, elapsed time.Duration, options ...Option) {
+	if len(sprite.Frames) == 0 {
+		return
+	}
+	sprite.Frames[sprite.FrameAt(elapsed)].Draw(c, x, y, options...)
+}
+
+// DrawScaled draws the frame shown after elapsed time stretched to width by
+// height pixels, as the DrawScaled function does. Frames are padded to the
+// sprite's size first, so every frame is scaled by the same amount and the
+// animation stays aligned. A sprite without frames draws nothing.
+func (sprite *Sprite) DrawScaled(c *canvas.Canvas, x, y, width, height float64, elapsed time.Duration, options ...Option) {
+	if len(sprite.Frames) == 0 {
+		return
+	}
+	frame := sprite.Frames[sprite.FrameAt(elapsed)].resize(sprite.Width(), sprite.Height())
+	DrawScaled(c, frame, x, y, width, height, options...)
+}
+
+// DrawRotated draws the frame shown after elapsed time turned by angle radians
+// about (centerX, centerY), as the DrawRotated function does. Frames are
+// padded to the sprite's size first, so they all turn about the same center.
+// A sprite without frames draws nothing.
+func (sprite *Sprite) DrawRotated(c *canvas.Canvas, centerX, centerY, angle float64, elapsed time.Duration, options ...Option) {
+	if len(sprite.Frames) == 0 {
+		return
+	}
+	frame := sprite.Frames[sprite.FrameAt(elapsed)].resize(sprite.Width(), sprite.Height())
+	DrawRotated(c, frame, centerX, centerY, angle, options...)
+}
+
+// FlipHorizontal returns a copy of the sprite with every frame mirrored left
+// to right across the sprite's full width, so frames stay aligned.
+func (sprite *Sprite) FlipHorizontal() *Sprite {
+	return sprite.mapFrames(Frame.FlipHorizontal)
+}
+
+// FlipVertical returns a copy of the sprite with every frame mirrored top to
+// bottom across the sprite's full height, so frames stay aligned.
+func (sprite *Sprite) FlipVertical() *Sprite {
+	return sprite.mapFrames(Frame.FlipVertical)
+}
+
+// mapFrames returns a copy of the sprite with transform applied to each frame
+// after padding it to the sprite's size.
+func (sprite *Sprite) mapFrames(transform func(Frame) Frame) *Sprite {
+	width, height := sprite.Width(), sprite.Height()
+	frames := make([]Frame, len(sprite.Frames))
+	for index, frame := range sprite.Frames {
+		frames[index] = transform(frame.resize(width, height))
+	}
+	return &Sprite{Name: sprite.Name, Frames: frames}
+}
+
+// Sheet is a set of sprites keyed by name.
+type Sheet map[string]*Sprite
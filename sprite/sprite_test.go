@@ -0,0 +1,348 @@
+package sprite
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+// testFrame returns a frame decoded from pixel-art rows.
+func testFrame(t *testing.T, rows string) Frame {
+	t.Helper()
+	sprite, err := Parse(rows)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return sprite.Frames[0]
+}
+
+func TestFrameDraw(t *testing.T) {
+	frame := testFrame(t, `
+		.##.
+		#..#
+		.##.
+	`)
+
+	actual := canvas.New(12, 8)
+	frame.Draw(actual, 5, 2)
+
+	expected := canvas.New(12, 8)
+	draw.Line(expected, 6, 2, 7, 2)
+	expected.Set(5, 3)
+	expected.Set(8, 3)
+	draw.Line(expected, 6, 4, 7, 4)
+	stippletest.AssertEqual(t, expected, actual)
+
+	stippletest.PrintVisual(t, "TestFrameDraw", actual)
+}
+
+func TestFrameDrawTransparentAndUnlit(t *testing.T) {
+	frame := testFrame(t, `
+		.-.
+		-#-
+		.-.
+	`)
+
+	actual := canvas.New(4, 4)
+	draw.RectangleFilled(actual, 0, 0, 4, 4)
+	frame.Draw(actual, 0, 0)
+
+	// Unlit pixels erase the background; transparent corners keep it
+	expected := canvas.New(4, 4)
+	draw.RectangleFilled(expected, 0, 0, 4, 4)
+	expected.Unset(1, 0)
+	expected.Unset(0, 1)
+	expected.Unset(2, 1)
+	expected.Unset(1, 2)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestFrameDrawColors(t *testing.T) {
+	frame := testFrame(t, "r#b")
+
+	c := canvas.New(6, 2, canvas.WithHalfBlock(), canvas.WithColor())
+	frame.Draw(c, 1, 0)
+
+	tests := []struct {
+		x        float64
+		expected canvas.Color
+	}{
+		{1, canvas.ColorRed},
+		{2, canvas.ColorDefault},
+		{3, canvas.ColorBlue},
+	}
+	for _, testCase := range tests {
+		if !c.Get(testCase.x, 0) {
+			t.Errorf("pixel (%v, 0) not set", testCase.x)
+		}
+		if color := c.GetColor(testCase.x, 0); color != testCase.expected {
+			t.Errorf("GetColor(%v, 0) = %v, want %v", testCase.x, color, testCase.expected)
+		}
+	}
+}
+
+func TestFrameDrawInvertedY(t *testing.T) {
+	frame := testFrame(t, `
+		##.
+		#..
+		#..
+	`)
+
+	normal := canvas.New(8, 8)
+	inverted := canvas.New(8, 8, canvas.WithInvertedY())
+	frame.Draw(normal, 2, 1)
+	frame.Draw(inverted, 2, 6)
+
+	if inverted.Frame() != normal.Frame() {
+		t.Errorf("inverted frame:\n%s\nwant:\n%s", inverted.Frame(), normal.Frame())
+	}
+}
+
+func TestFrameDrawClipsToCanvas(t *testing.T) {
+	frame := testFrame(t, "####")
+
+	c := canvas.New(4, 4)
+	frame.Draw(c, -2, 0)
+
+	expected := canvas.New(4, 4)
+	draw.Line(expected, 0, 0, 1, 0)
+	stippletest.AssertEqual(t, expected, c)
+}
+
+func TestFrameFlip(t *testing.T) {
+	frame := testFrame(t, `
+		##.
+		#..
+	`)
+
+	tests := []struct {
+		name     string
+		flipped  Frame
+		expected Frame
+	}{
+		{"horizontal", frame.FlipHorizontal(), testFrame(t, ".##\n..#")},
+		{"vertical", frame.FlipVertical(), testFrame(t, "#..\n##.")},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			for y := range testCase.expected.Height() {
+				for x := range testCase.expected.Width() {
+					if pixel := testCase.flipped.At(x, y); pixel != testCase.expected.At(x, y) {
+						t.Errorf("At(%d, %d) = %v, want %v", x, y, pixel, testCase.expected.At(x, y))
+					}
+				}
+			}
+		})
+	}
+
+	// Flipping returns a copy
+	if frame.At(2, 0).Mode != Transparent {
+		t.Error("FlipHorizontal() changed the original frame")
+	}
+}
+
+func TestFrameAt(t *testing.T) {
+	frame := testFrame(t, "#g")
+
+	tests := []struct {
+		x, y     int
+		expected Pixel
+	}{
+		{0, 0, Pixel{Mode: On}},
+		{1, 0, Pixel{Mode: On, Color: canvas.ColorGreen}},
+		{2, 0, Pixel{}},
+		{0, 1, Pixel{}},
+		{-1, 0, Pixel{}},
+	}
+	for _, testCase := range tests {
+		if pixel := frame.At(testCase.x, testCase.y); pixel != testCase.expected {
+			t.Errorf("At(%d, %d) = %v, want %v", testCase.x, testCase.y, pixel, testCase.expected)
+		}
+	}
+}
+
+func TestFromCanvas(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []canvas.Option
+		centerY float64
+	}{
+		{"y down", nil, 3},
+		{"inverted y", []canvas.Option{canvas.WithInvertedY()}, 4}, // row 3 on screen
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			source := canvas.New(6, 8, append(testCase.options, canvas.WithColor())...)
+			draw.CircleColor(source, 3, testCase.centerY, 2, canvas.ColorCyan)
+			frame := FromCanvas(source)
+
+			if frame.Width() != 6 || frame.Height() != 8 {
+				t.Fatalf("FromCanvas() size = %dx%d, want 6x8", frame.Width(), frame.Height())
+			}
+
+			// Drawing the frame back reproduces the picture as displayed
+			actual := canvas.New(6, 8, canvas.WithColor())
+			frame.Draw(actual, 0, 0)
+			expected := canvas.New(6, 8, canvas.WithColor())
+			draw.CircleColor(expected, 3, 3, 2, canvas.ColorCyan)
+			stippletest.AssertEqual(t, expected, actual)
+		})
+	}
+}
+
+func TestSpriteFrameAt(t *testing.T) {
+	sprite := &Sprite{Frames: []Frame{
+		{Duration: 100 * time.Millisecond},
+		{Duration: 50 * time.Millisecond},
+		{Duration: 250 * time.Millisecond},
+	}}
+
+	tests := []struct {
+		elapsed  time.Duration
+		expected int
+	}{
+		{0, 0},
+		{99 * time.Millisecond, 0},
+		{100 * time.Millisecond, 1},
+		{149 * time.Millisecond, 1},
+		{150 * time.Millisecond, 2},
+		{399 * time.Millisecond, 2},
+		{400 * time.Millisecond, 0}, // loops
+		{1050 * time.Millisecond, 2},
+		{-50 * time.Millisecond, 2},
+	}
+
+	for _, testCase := range tests {
+		if index := sprite.FrameAt(testCase.elapsed); index != testCase.expected {
+			t.Errorf("FrameAt(%v) = %d, want %d", testCase.elapsed, index, testCase.expected)
+		}
+	}
+
+	if duration := sprite.Duration(); duration != 400*time.Millisecond {
+		t.Errorf("Duration() = %v, want 400ms", duration)
+	}
+}
+
+func TestSpriteFrameAtWithoutDuration(t *testing.T) {
+	sprite := &Sprite{Frames: []Frame{{}, {}}}
+	if index := sprite.FrameAt(time.Second); index != 0 {
+		t.Errorf("FrameAt(1s) = %d, want 0", index)
+	}
+}
+
+func TestSpriteDraw(t *testing.T) {
+	sprite, err := Parse(`
+		frame 100ms
+		#.
+		frame 100ms
+		.#
+	`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		elapsed  time.Duration
+		expected float64
+	}{
+		{0, 0},
+		{150 * time.Millisecond, 1},
+		{250 * time.Millisecond, 0},
+	}
+
+	for _, testCase := range tests {
+		c := canvas.New(4, 4)
+		sprite.Draw(c, 0, 0, testCase.elapsed)
+		expected := canvas.New(4, 4)
+		expected.Set(testCase.expected, 0)
+		stippletest.AssertEqual(t, expected, c)
+	}
+
+	// A sprite without frames draws nothing
+	c := canvas.New(4, 4)
+	(&Sprite{}).Draw(c, 0, 0, 0)
+	stippletest.AssertEqual(t, canvas.New(4, 4), c)
+}
+
+func TestSpriteDrawScaled(t *testing.T) {
+	// The narrow second frame is padded to the sprite's width before scaling,
+	// so its pixel keeps the same size as those of the first frame
+	sprite, err := Parse(`
+		frame 100ms
+		.#
+		frame 100ms
+		#
+	`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		elapsed  time.Duration
+		expected float64
+	}{
+		{0, 2},
+		{150 * time.Millisecond, 0},
+	}
+
+	for _, testCase := range tests {
+		c := canvas.New(8, 4)
+		sprite.DrawScaled(c, 0, 0, 4, 2, testCase.elapsed)
+		expected := canvas.New(8, 4)
+		draw.RectangleFilled(expected, testCase.expected, 0, 2, 2)
+		stippletest.AssertEqual(t, expected, c)
+	}
+
+	empty := &Sprite{}
+	c := canvas.New(4, 4)
+	empty.DrawScaled(c, 0, 0, 4, 4, 0)
+	empty.DrawRotated(c, 2, 2, 1, 0)
+	stippletest.AssertEqual(t, canvas.New(4, 4), c)
+}
+
+func TestSpriteDrawRotated(t *testing.T) {
+	sprite, err := Parse(`
+		frame 100ms
+		##.
+	`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	expected := canvas.New(8, 8)
+	DrawRotated(expected, sprite.Frames[0], 4.5, 4.5, math.Pi/2)
+	actual := canvas.New(8, 8)
+	sprite.DrawRotated(actual, 4.5, 4.5, math.Pi/2, 50*time.Millisecond)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestSpriteFlipKeepsFramesAligned(t *testing.T) {
+	// The second frame is narrower; flipping uses the sprite's full width
+	sprite := &Sprite{Name: "walker", Frames: []Frame{
+		testFrame(t, "#.."),
+		testFrame(t, "#"),
+	}}
+
+	flipped := sprite.FlipHorizontal()
+	if flipped.Name != "walker" {
+		t.Errorf("Name = %q, want %q", flipped.Name, "walker")
+	}
+	for index, frame := range flipped.Frames {
+		if pixel := frame.At(2, 0); pixel.Mode != On {
+			t.Errorf("frame %d: At(2, 0) = %v, want lit", index, pixel)
+		}
+	}
+
+	flipped = (&Sprite{Frames: []Frame{testFrame(t, "#\n."), testFrame(t, "#")}}).FlipVertical()
+	for index, frame := range flipped.Frames {
+		if pixel := frame.At(0, 1); pixel.Mode != On {
+			t.Errorf("frame %d: At(0, 1) = %v, want lit", index, pixel)
+		}
+	}
+}
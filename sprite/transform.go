@@ -0,0 +1,110 @@
+package sprite
+
+import (
+	"math"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// DrawScaled draws frame stretched to width by height pixels with its top-left
+// pixel at (x, y), as Frame.Draw places it. Each canvas pixel inside the
+// rectangle shows the frame pixel under its center (nearest-neighbour
+// sampling), so transparent pixels stay transparent at any size. Only the
+// part of the rectangle on the canvas is visited, so the cost is bounded by
+// the canvas size. A width or height that is not positive, or a position or
+// size that is not finite, draws nothing.
+func DrawScaled(c *canvas.Canvas, frame Frame, x, y, width, height float64, options ...Option) {
+	frameWidth, frameHeight := frame.Width(), frame.Height()
+	if width <= 0 || height <= 0 || frameWidth == 0 || frameHeight == 0 || !finite(x, y, width, height) {
+		return
+	}
+	drawing := newDrawing(options)
+	top := screenTop(c, y)
+
+	firstY, stopY := span(top, top+height, c.Height())
+	firstX, stopX := span(x, x+width, c.Width())
+	for screenY := firstY; screenY < stopY; screenY++ {
+		row := int(math.Floor((float64(screenY) + 0.5 - top) / height * float64(frameHeight)))
+		if row < 0 || row >= frameHeight {
+			continue
+		}
+		for screenX := firstX; screenX < stopX; screenX++ {
+			column := int(math.Floor((float64(screenX) + 0.5 - x) / width * float64(frameWidth)))
+			if column < 0 || column >= frameWidth {
+				continue
+			}
+			drawing.plot(c, float64(screenX), c.ScreenToCanvasY(screenY), frame.At(column, row))
+		}
+	}
+}
+
+// DrawRotated draws frame at its own size, turned by angle radians about its
+// center, with the center at (centerX, centerY). Positive angles turn the frame
+// counterclockwise as displayed. Each canvas pixel shows the frame pixel under
+// its center (nearest-neighbour sampling), and transparent pixels stay
+// transparent. A center or angle that is not finite draws nothing.
+func DrawRotated(c *canvas.Canvas, frame Frame, centerX, centerY, angle float64, options ...Option) {
+	frameWidth, frameHeight := float64(frame.Width()), float64(frame.Height())
+	if frameWidth == 0 || frameHeight == 0 || !finite(centerX, centerY, angle) {
+		return
+	}
+	drawing := newDrawing(options)
+
+	// Work in screen coordinates, with y growing down the display
+	screenCenterY := centerY
+	if c.InvertedY() {
+		screenCenterY = float64(c.Height()) - centerY
+	}
+	sin, cos := math.Sincos(angle)
+	radius := math.Hypot(frameWidth, frameHeight) / 2
+
+	firstY, stopY := span(screenCenterY-radius, math.Floor(screenCenterY+radius)+1, c.Height())
+	firstX, stopX := span(centerX-radius, math.Floor(centerX+radius)+1, c.Width())
+	for screenY := firstY; screenY < stopY; screenY++ {
+		for screenX := firstX; screenX < stopX; screenX++ {
+			// Rotate the pixel center back into the frame
+			offsetX := float64(screenX) + 0.5 - centerX
+			offsetY := float64(screenY) + 0.5 - screenCenterY
+			sourceX := snap(offsetX*cos - offsetY*sin + frameWidth/2)
+			sourceY := snap(offsetX*sin + offsetY*cos + frameHeight/2)
+			if sourceX < 0 || sourceX >= frameWidth || sourceY < 0 || sourceY >= frameHeight {
+				continue
+			}
+			pixel := frame.At(int(sourceX), int(sourceY))
+			drawing.plot(c, float64(screenX), c.ScreenToCanvasY(screenY), pixel)
+		}
+	}
+}
+
+// span returns the screen pixels from the one containing start up to, but not
+// including, end, limited to the limit pixels on the canvas.
+func span(start, end float64, limit int) (first, stop int) {
+	first = int(math.Min(math.Max(math.Floor(start), 0), float64(limit)))
+	stop = int(math.Min(math.Max(math.Ceil(end), 0), float64(limit)))
+	return first, stop
+}
+
+// finite reports whether every value is neither infinite nor NaN.
+func finite(values ...float64) bool {
+	for _, value := range values {
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// snap rounds away floating-point noise, so quarter turns land exactly on
+// pixel edges instead of sampling the neighbouring pixel.
+func snap(value float64) float64 {
+	return math.Round(value*1e9) / 1e9
+}
+
+// screenTop returns the screen row, counted down from the top of the display,
+// of the top edge of the pixel row at canvas coordinate y.
+func screenTop(c *canvas.Canvas, y float64) float64 {
+	if c.InvertedY() {
+		return float64(c.Height()-1) - y
+	}
+	return y
+}
@@ -0,0 +1,249 @@
+package sprite
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+func TestDrawScaledAtNativeSizeMatchesDraw(t *testing.T) {
+	frame := testFrame(t, `
+		.##.
+		#.-#
+		##..
+	`)
+
+	for _, options := range [][]canvas.Option{nil, {canvas.WithInvertedY()}} {
+		expected := canvas.New(12, 8, options...)
+		draw.RectangleFilled(expected, 0, 0, 12, 8)
+		frame.Draw(expected, 3, 5)
+
+		actual := canvas.New(12, 8, options...)
+		draw.RectangleFilled(actual, 0, 0, 12, 8)
+		DrawScaled(actual, frame, 3, 5, 4, 3)
+		stippletest.AssertEqual(t, expected, actual)
+	}
+}
+
+func TestDrawScaledEnlarges(t *testing.T) {
+	frame := testFrame(t, `
+		#.
+		.#
+	`)
+
+	actual := canvas.New(12, 8)
+	DrawScaled(actual, frame, 1, 1, 6, 4)
+
+	expected := canvas.New(12, 8)
+	draw.RectangleFilled(expected, 1, 1, 3, 2)
+	draw.RectangleFilled(expected, 4, 3, 3, 2)
+	stippletest.AssertEqual(t, expected, actual)
+
+	stippletest.PrintVisual(t, "TestDrawScaledEnlarges", actual)
+}
+
+func TestDrawScaledShrinks(t *testing.T) {
+	// Each output pixel samples the frame pixel under its center
+	frame := testFrame(t, `
+		....
+		.#.#
+		....
+		.#..
+	`)
+
+	actual := canvas.New(4, 4)
+	DrawScaled(actual, frame, 0, 0, 2, 2)
+
+	expected := canvas.New(4, 4)
+	expected.Set(0, 0)
+	expected.Set(1, 0)
+	expected.Set(0, 1)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestDrawScaledFractionalSize(t *testing.T) {
+	frame := testFrame(t, "###")
+
+	// 4.5 pixels wide starting at 0.5 covers the pixels whose centers are inside
+	actual := canvas.New(8, 4)
+	DrawScaled(actual, frame, 0.5, 0, 4.5, 1)
+
+	expected := canvas.New(8, 4)
+	draw.Line(expected, 0, 0, 4, 0)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestDrawScaledEmpty(t *testing.T) {
+	frame := testFrame(t, "#")
+	c := canvas.New(4, 4)
+	DrawScaled(c, frame, 0, 0, 0, 4)
+	DrawScaled(c, frame, 0, 0, 4, -1)
+	DrawScaled(c, Frame{}, 0, 0, 4, 4)
+	DrawScaled(c, frame, 0, 0, 4, math.Inf(1))
+	DrawScaled(c, frame, math.NaN(), 0, 4, 4)
+	stippletest.AssertEqual(t, canvas.New(4, 4), c)
+}
+
+func TestDrawScaledHugeSizeIsClipped(t *testing.T) {
+	// Only the pixels on the canvas are visited, so this returns at once
+	frame := testFrame(t, "#")
+	tests := []struct {
+		name    string
+		y       float64 // above the top edge of the canvas
+		options []canvas.Option
+	}{
+		{"default", -1e12, nil},
+		{"inverted", 1e12, []canvas.Option{canvas.WithInvertedY()}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := canvas.New(8, 4, testCase.options...)
+			DrawScaled(actual, frame, -1e12, testCase.y, 1e15, 1e15)
+
+			expected := canvas.New(8, 4, testCase.options...)
+			draw.RectangleFilled(expected, 0, 0, 8, 4)
+			stippletest.AssertEqual(t, expected, actual)
+		})
+	}
+}
+
+func TestDrawScaledOffCanvas(t *testing.T) {
+	frame := testFrame(t, "#")
+	c := canvas.New(4, 4)
+	DrawScaled(c, frame, 1e300, 0, 4, 4)
+	DrawScaled(c, frame, 0, -1e300, 4, 4)
+	DrawRotated(c, frame, -1e300, 2, 1)
+	stippletest.AssertEqual(t, canvas.New(4, 4), c)
+}
+
+func TestDrawScaledOcclusion(t *testing.T) {
+	frame := testFrame(t, "##\n##")
+
+	// A wall at distance 2 covers columns 2 and 3 only
+	depth := []float64{math.Inf(1), math.Inf(1), 2, 2, math.Inf(1), math.Inf(1)}
+	visible := func(column int, distance float64) bool {
+		return column >= 0 && column < len(depth) && distance < depth[column]
+	}
+
+	behind := canvas.New(6, 4)
+	DrawScaled(behind, frame, 0, 0, 6, 2, WithOcclusion(3, visible))
+	expected := canvas.New(6, 4)
+	draw.RectangleFilled(expected, 0, 0, 2, 2)
+	draw.RectangleFilled(expected, 4, 0, 2, 2)
+	stippletest.AssertEqual(t, expected, behind)
+
+	front := canvas.New(6, 4)
+	DrawScaled(front, frame, 0, 0, 6, 2, WithOcclusion(1, visible))
+	expected = canvas.New(6, 4)
+	draw.RectangleFilled(expected, 0, 0, 6, 2)
+	stippletest.AssertEqual(t, expected, front)
+}
+
+func TestDrawWithDepth(t *testing.T) {
+	frame := testFrame(t, "rr\nrr")
+
+	c := canvas.New(4, 4, canvas.WithHalfBlock(), canvas.WithColor(), canvas.WithDepth())
+	draw.RectangleFilledDepthColor(c, 0, 0, 4, 1, 1, canvas.ColorBlue)
+	frame.Draw(c, 1, 0, WithDepth(2))
+
+	tests := []struct {
+		x, y     float64
+		expected canvas.Color
+	}{
+		{1, 0, canvas.ColorBlue}, // the nearer bar hides the sprite
+		{1, 1, canvas.ColorRed},
+		{2, 1, canvas.ColorRed},
+	}
+	for _, testCase := range tests {
+		if color := c.GetColor(testCase.x, testCase.y); color != testCase.expected {
+			t.Errorf("GetColor(%v, %v) = %v, want %v", testCase.x, testCase.y, color, testCase.expected)
+		}
+	}
+	if depth := c.Depth(1, 1); depth != 2 {
+		t.Errorf("Depth(1, 1) = %v, want 2", depth)
+	}
+}
+
+func TestDrawWithDepthErasesOnlyWhenNearer(t *testing.T) {
+	frame := testFrame(t, "--")
+
+	c := canvas.New(4, 4, canvas.WithDepth())
+	c.SetDepth(0, 0, 1)
+	c.SetDepth(1, 0, 3)
+	frame.Draw(c, 0, 0, WithDepth(2))
+
+	if !c.Get(0, 0) {
+		t.Error("pixel (0, 0) erased behind a nearer pixel")
+	}
+	if c.Get(1, 0) {
+		t.Error("pixel (1, 0) not erased in front of a farther pixel")
+	}
+}
+
+func TestDrawRotated(t *testing.T) {
+	frame := testFrame(t, `
+		##.
+		#..
+		...
+	`)
+
+	tests := []struct {
+		name     string
+		angle    float64
+		expected Frame
+	}{
+		{"zero", 0, frame},
+		{"quarter turn", math.Pi / 2, testFrame(t, "...\n#..\n##.")},
+		{"half turn", math.Pi, testFrame(t, "...\n..#\n.##")},
+		{"clockwise quarter turn", -math.Pi / 2, testFrame(t, ".##\n..#\n...")},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			// The 3x3 frame centered at (3.5, 3.5) covers pixels 2-4
+			actual := canvas.New(8, 8)
+			DrawRotated(actual, frame, 3.5, 3.5, testCase.angle)
+
+			expected := canvas.New(8, 8)
+			testCase.expected.Draw(expected, 2, 2)
+			stippletest.AssertEqual(t, expected, actual)
+		})
+	}
+}
+
+func TestDrawRotatedInvertedY(t *testing.T) {
+	frame := testFrame(t, `
+		###.
+		#...
+		#...
+	`)
+
+	normal := canvas.New(16, 16)
+	inverted := canvas.New(16, 16, canvas.WithInvertedY())
+	DrawRotated(normal, frame, 7, 6.5, math.Pi/6)
+	DrawRotated(inverted, frame, 7, 16-6.5, math.Pi/6)
+
+	if inverted.Frame() != normal.Frame() {
+		t.Errorf("inverted frame:\n%s\nwant:\n%s", inverted.Frame(), normal.Frame())
+	}
+
+	stippletest.PrintVisual(t, "TestDrawRotatedInvertedY", normal)
+}
+
+func TestDrawRotatedKeepsTransparency(t *testing.T) {
+	actual := canvas.New(8, 8)
+	draw.RectangleFilled(actual, 0, 0, 8, 8)
+	DrawRotated(actual, testFrame(t, "-.-"), 4.5, 4.5, math.Pi/2)
+
+	// The unlit ends erase pixels (4, 3) and (4, 5); the transparent middle
+	// leaves the background at (4, 4) alone
+	expected := canvas.New(8, 8)
+	draw.RectangleFilled(expected, 0, 0, 8, 8)
+	expected.Unset(4, 3)
+	expected.Unset(4, 5)
+	stippletest.AssertEqual(t, expected, actual)
+}
@@ -0,0 +1,286 @@
+// Package stippletest provides test assertions for comparing canvases pixel by pixel.
+//
+// Failures report the coordinates of differing pixels and include an overlay
+// of both canvases. Golden files are read from the testdata directory and can be
+// rewritten by setting STIPPLE_UPDATE=1, or with -update when the test package
+// defines that flag. Setting STIPPLE_VISUAL=1 makes PrintVisual log drawings.
+package stippletest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// Environment variables that turn on golden file updates and visual output.
+const (
+	EnvUpdate = "STIPPLE_UPDATE"
+	EnvVisual = "STIPPLE_VISUAL"
+)
+
+// maxReported limits how many differences are listed in a failure message.
+const maxReported = 20
+
+// Overlay characters, one per pixel.
+const (
+	OverlayBoth     = '#' // set in both canvases
+	OverlayExpected = '-' // set only in the expected canvas
+	OverlayActual   = '+' // set only in the actual canvas
+	OverlayNeither  = '.' // set in neither canvas
+)
+
+// Point is a pixel position in screen coordinates (origin at the top-left),
+// regardless of whether a canvas uses WithInvertedY.
+type Point struct {
+	X int
+	Y int
+}
+
+// Diff returns the screen positions of pixels that differ between two canvases.
+// Pixels outside one canvas are treated as unset, so canvases of different sizes
+// can be compared.
+func Diff(expected, actual *canvas.Canvas) []Point {
+	var points []Point
+	width, height := unionSize(expected, actual)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if screenPixel(expected, x, y) != screenPixel(actual, x, y) {
+				points = append(points, Point{X: x, Y: y})
+			}
+		}
+	}
+	return points
+}
+
+// Overlay renders both canvases on top of each other, one character per pixel.
+// Pixels set in both are drawn with OverlayBoth, pixels only in expected with
+// OverlayExpected, pixels only in actual with OverlayActual, and the rest with
+// OverlayNeither. Rows are joined by newlines.
+func Overlay(expected, actual *canvas.Canvas) string {
+	width, height := unionSize(expected, actual)
+	rows := make([]string, height)
+	for y := 0; y < height; y++ {
+		var builder strings.Builder
+		for x := 0; x < width; x++ {
+			inExpected := screenPixel(expected, x, y)
+			inActual := screenPixel(actual, x, y)
+			switch {
+			case inExpected && inActual:
+				builder.WriteRune(OverlayBoth)
+			case inExpected:
+				builder.WriteRune(OverlayExpected)
+			case inActual:
+				builder.WriteRune(OverlayActual)
+			default:
+				builder.WriteRune(OverlayNeither)
+			}
+		}
+		rows[y] = builder.String()
+	}
+	return strings.Join(rows, "\n")
+}
+
+// AssertEqual reports a test error when the two canvases differ in size, pixels,
+// or cell colors. The message lists the differing coordinates and an overlay.
+func AssertEqual(t testing.TB, expected, actual *canvas.Canvas) {
+	t.Helper()
+
+	if message := compare(expected, actual); message != "" {
+		t.Errorf("canvases differ\n%s", message)
+	}
+}
+
+// AssertGolden compares a canvas against testdata/<name>.golden.
+// The golden file holds the canvas Frame, including ANSI colors when the canvas
+// was created with WithColor. The file is rewritten instead when Update is true.
+func AssertGolden(t testing.TB, name string, actual *canvas.Canvas) {
+	t.Helper()
+
+	path := GoldenPath(name)
+	frame := actual.Frame()
+
+	if Update() {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create testdata directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(frame), 0644); err != nil {
+			t.Fatalf("failed to write golden file %s: %v", path, err)
+		}
+		t.Logf("updated golden file: %s", path)
+		return
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file %s (set %s=1 to create): %v", path, EnvUpdate, err)
+	}
+	if string(contents) == frame {
+		return
+	}
+
+	expected, err := canvas.Parse(string(contents))
+	if err != nil {
+		t.Fatalf("failed to parse golden file %s: %v", path, err)
+	}
+
+	message := compare(expected, actual)
+	if message == "" {
+		// Same pixels and colors, but the text differs (for example in how
+		// escape sequences are grouped), so fall back to showing both frames.
+		message = fmt.Sprintf("--- expected ---\n%s\n--- actual ---\n%s", string(contents), frame)
+	}
+	t.Errorf("output does not match golden file %s\n%s", path, message)
+}
+
+// GoldenPath returns the path of the golden file for name.
+func GoldenPath(name string) string {
+	return filepath.Join("testdata", name+".golden")
+}
+
+// compare describes every difference between two canvases, or returns an
+// empty string when they match.
+func compare(expected, actual *canvas.Canvas) string {
+	var builder strings.Builder
+
+	if expected.Width() != actual.Width() || expected.Height() != actual.Height() {
+		fmt.Fprintf(&builder, "size: expected %dx%d, actual %dx%d\n",
+			expected.Width(), expected.Height(), actual.Width(), actual.Height())
+	}
+
+	points := Diff(expected, actual)
+	if len(points) > 0 {
+		fmt.Fprintf(&builder, "%d pixels differ:\n", len(points))
+		for index, point := range points {
+			if index == maxReported {
+				fmt.Fprintf(&builder, "  ... and %d more\n", len(points)-maxReported)
+				break
+			}
+			state := "missing"
+			if screenPixel(actual, point.X, point.Y) {
+				state = "unexpected"
+			}
+			fmt.Fprintf(&builder, "  (%d, %d) %s\n", point.X, point.Y, state)
+		}
+	}
+
+	colorDifferences := diffColors(expected, actual)
+	if len(colorDifferences) > 0 {
+		fmt.Fprintf(&builder, "%d cell colors differ:\n", len(colorDifferences))
+		for index, difference := range colorDifferences {
+			if index == maxReported {
+				fmt.Fprintf(&builder, "  ... and %d more\n", len(colorDifferences)-maxReported)
+				break
+			}
+			builder.WriteString("  " + difference + "\n")
+		}
+	}
+
+	if builder.Len() == 0 {
+		return ""
+	}
+
+	if len(points) > 0 {
+		fmt.Fprintf(&builder, "--- overlay (%c both, %c expected only, %c actual only) ---\n%s",
+			OverlayBoth, OverlayExpected, OverlayActual, Overlay(expected, actual))
+	}
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+// diffColors lists the cells, in screen coordinates, whose colors differ.
+// Every pixel of a cell is compared, since a half-block cell holds a separate
+// color for each of its two pixels.
+func diffColors(expected, actual *canvas.Canvas) []string {
+	var differences []string
+	cellWidth, cellHeight := expected.CellSize()
+	width, height := unionSize(expected, actual)
+	for top := 0; top < height; top += cellHeight {
+		for left := 0; left < width; left += cellWidth {
+		cell:
+			for y := top; y < min(top+cellHeight, height); y++ {
+				for x := left; x < min(left+cellWidth, width); x++ {
+					expectedColor, actualColor := screenColor(expected, x, y), screenColor(actual, x, y)
+					if expectedColor != actualColor {
+						differences = append(differences, fmt.Sprintf("cell (%d, %d): expected color %d, actual %d",
+							left/cellWidth, top/cellHeight, expectedColor, actualColor))
+						break cell
+					}
+				}
+			}
+		}
+	}
+	return differences
+}
+
+// screenPixel reports whether the pixel at screen position (x, y) is set.
+func screenPixel(c *canvas.Canvas, x, y int) bool {
+	canvasX, canvasY, ok := toCanvas(c, x, y)
+	return ok && c.Get(canvasX, canvasY)
+}
+
+// screenColor returns the color of the pixel at screen position (x, y).
+func screenColor(c *canvas.Canvas, x, y int) canvas.Color {
+	canvasX, canvasY, ok := toCanvas(c, x, y)
+	if !ok {
+		return canvas.ColorDefault
+	}
+	return c.GetColor(canvasX, canvasY)
+}
+
+// toCanvas converts screen coordinates to the canvas coordinate system.
+func toCanvas(c *canvas.Canvas, x, y int) (canvasX, canvasY float64, ok bool) {
+	if x < 0 || x >= c.Width() || y < 0 || y >= c.Height() {
+		return 0, 0, false
+	}
+	if c.InvertedY() {
+		y = c.Height() - 1 - y
+	}
+	return float64(x), float64(y), true
+}
+
+// unionSize returns the smallest size that covers both canvases.
+func unionSize(first, second *canvas.Canvas) (width, height int) {
+	return max(first.Width(), second.Width()), max(first.Height(), second.Height())
+}
+
+// Update reports whether AssertGolden should rewrite golden files: when
+// STIPPLE_UPDATE is true, or when the test binary defines an -update flag and
+// it is set. The package registers no flags of its own, so test packages are
+// free to define -update themselves.
+func Update() bool {
+	return enabled(EnvUpdate, "update")
+}
+
+// Visual reports whether PrintVisual should log drawings: when STIPPLE_VISUAL
+// is true, or when the test binary defines a -visual flag and it is set.
+func Visual() bool {
+	return enabled(EnvVisual, "visual")
+}
+
+// PrintVisual logs the frame of c under a heading when Visual is true, so
+// drawings can be inspected with go test -v.
+func PrintVisual(t testing.TB, name string, c *canvas.Canvas) {
+	t.Helper()
+	if Visual() {
+		t.Logf("\n=== %s ===\n%s", name, c.Frame())
+	}
+}
+
+// enabled reports whether the environment variable or the flag of the given
+// names is set to a true boolean value.
+func enabled(variable, name string) bool {
+	if value, err := strconv.ParseBool(os.Getenv(variable)); err == nil && value {
+		return true
+	}
+	defined := flag.Lookup(name)
+	if defined == nil {
+		return false
+	}
+	value, err := strconv.ParseBool(defined.Value.String())
+	return err == nil && value
+}
@@ -0,0 +1,292 @@
+package stippletest
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// The package must not register -update itself, or this would panic with
+// "flag redefined".
+var _ = flag.Bool("update", false, "update golden files")
+
+// recorder captures failures instead of failing the enclosing test.
+type recorder struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, arguments ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, arguments...))
+}
+
+func (r *recorder) Fatalf(format string, arguments ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, arguments...))
+	r.fatal = true
+}
+
+func (r *recorder) Logf(string, ...any) {}
+
+func TestDiff(t *testing.T) {
+	expected := canvas.New(4, 8)
+	actual := canvas.New(4, 8)
+	expected.Set(0, 0)
+	expected.Set(1, 1)
+	actual.Set(1, 1)
+	actual.Set(3, 7)
+
+	points := Diff(expected, actual)
+	want := []Point{{X: 0, Y: 0}, {X: 3, Y: 7}}
+	if len(points) != len(want) {
+		t.Fatalf("Diff() = %v, want %v", points, want)
+	}
+	for index := range want {
+		if points[index] != want[index] {
+			t.Errorf("Diff()[%d] = %v, want %v", index, points[index], want[index])
+		}
+	}
+}
+
+func TestDiffInvertedY(t *testing.T) {
+	// Both canvases light the same screen pixel using different coordinate systems
+	screen := canvas.New(4, 8)
+	inverted := canvas.New(4, 8, canvas.WithInvertedY())
+	screen.Set(2, 7)
+	inverted.Set(2, 0)
+
+	if points := Diff(screen, inverted); len(points) != 0 {
+		t.Errorf("Diff() = %v, want no differences", points)
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	expected := canvas.New(2, 4)
+	actual := canvas.New(2, 4)
+	expected.Set(0, 0)
+	actual.Set(0, 0)
+	expected.Set(1, 1)
+	actual.Set(0, 3)
+
+	want := strings.Join([]string{
+		"#.",
+		".-",
+		"..",
+		"+.",
+	}, "\n")
+	if overlay := Overlay(expected, actual); overlay != want {
+		t.Errorf("Overlay() =\n%s\nwant:\n%s", overlay, want)
+	}
+}
+
+func TestOverlayDifferentSizes(t *testing.T) {
+	expected := canvas.New(2, 4)
+	actual := canvas.New(4, 4)
+	actual.Set(3, 0)
+
+	overlay := Overlay(expected, actual)
+	lines := strings.Split(overlay, "\n")
+	if len(lines) != 4 || len(lines[0]) != 4 {
+		t.Fatalf("Overlay() =\n%s\nwant 4 rows of 4 pixels", overlay)
+	}
+	if lines[0][3] != OverlayActual {
+		t.Errorf("Overlay() pixel (3, 0) = %c, want %c", lines[0][3], OverlayActual)
+	}
+}
+
+func TestAssertEqualMatching(t *testing.T) {
+	expected := canvas.New(4, 8)
+	actual := canvas.New(4, 8)
+	expected.Set(1, 2)
+	actual.Set(1, 2)
+
+	fake := &recorder{TB: t}
+	AssertEqual(fake, expected, actual)
+	if len(fake.errors) != 0 {
+		t.Errorf("AssertEqual() reported %v, want no errors", fake.errors)
+	}
+}
+
+func TestAssertEqualReportsPixels(t *testing.T) {
+	expected := canvas.New(4, 8)
+	actual := canvas.New(4, 8)
+	expected.Set(1, 2)
+	actual.Set(3, 5)
+
+	fake := &recorder{TB: t}
+	AssertEqual(fake, expected, actual)
+	if len(fake.errors) != 1 {
+		t.Fatalf("AssertEqual() reported %d errors, want 1", len(fake.errors))
+	}
+
+	message := fake.errors[0]
+	for _, want := range []string{"2 pixels differ", "(1, 2) missing", "(3, 5) unexpected", "overlay"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("AssertEqual() message missing %q:\n%s", want, message)
+		}
+	}
+}
+
+func TestAssertEqualReportsColors(t *testing.T) {
+	expected := canvas.New(4, 8, canvas.WithColor())
+	actual := canvas.New(4, 8, canvas.WithColor())
+	expected.SetColor(0, 0, canvas.ColorRed)
+	actual.SetColor(0, 0, canvas.ColorBlue)
+
+	fake := &recorder{TB: t}
+	AssertEqual(fake, expected, actual)
+	if len(fake.errors) != 1 {
+		t.Fatalf("AssertEqual() reported %d errors, want 1", len(fake.errors))
+	}
+	if !strings.Contains(fake.errors[0], "cell (0, 0)") {
+		t.Errorf("AssertEqual() message missing cell color difference:\n%s", fake.errors[0])
+	}
+	if strings.Contains(fake.errors[0], "pixels differ") {
+		t.Errorf("AssertEqual() reported pixel differences for identical pixels:\n%s", fake.errors[0])
+	}
+}
+
+func TestAssertEqualReportsHalfBlockColors(t *testing.T) {
+	// The two pixels of a half-block cell have their own colors, so a
+	// difference in the lower pixel of the last cell is reported too
+	expected := canvas.New(2, 4, canvas.WithColor(), canvas.WithHalfBlock())
+	actual := canvas.New(2, 4, canvas.WithColor(), canvas.WithHalfBlock())
+	expected.SetColor(1, 3, canvas.ColorRed)
+	actual.SetColor(1, 3, canvas.ColorBlue)
+
+	fake := &recorder{TB: t}
+	AssertEqual(fake, expected, actual)
+	if len(fake.errors) != 1 || !strings.Contains(fake.errors[0], "cell (1, 1)") {
+		t.Errorf("AssertEqual() errors = %q, want one naming cell (1, 1)", fake.errors)
+	}
+}
+
+func TestAssertEqualReportsSize(t *testing.T) {
+	fake := &recorder{TB: t}
+	AssertEqual(fake, canvas.New(4, 8), canvas.New(6, 8))
+	if len(fake.errors) != 1 || !strings.Contains(fake.errors[0], "expected 4x8, actual 6x8") {
+		t.Errorf("AssertEqual() = %v, want size difference", fake.errors)
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	c := canvas.New(8, 8)
+	for index := 0; index < 8; index++ {
+		c.Set(float64(index), float64(index))
+	}
+
+	AssertGolden(t, "diagonal", c)
+}
+
+func TestAssertGoldenColor(t *testing.T) {
+	c := canvas.New(8, 4, canvas.WithColor())
+	c.SetColor(0, 0, canvas.ColorRed)
+	c.SetColor(3, 1, canvas.ColorGreen)
+	c.Set(6, 3)
+
+	AssertGolden(t, "colored", c)
+}
+
+func TestAssertGoldenMismatch(t *testing.T) {
+	if Update() {
+		t.Skip("golden files are being updated")
+	}
+
+	c := canvas.New(8, 8)
+	c.Set(0, 7)
+
+	fake := &recorder{TB: t}
+	AssertGolden(fake, "diagonal", c)
+	if len(fake.errors) != 1 {
+		t.Fatalf("AssertGolden() reported %d errors, want 1", len(fake.errors))
+	}
+	if !strings.Contains(fake.errors[0], "(0, 7) unexpected") {
+		t.Errorf("AssertGolden() message missing pixel coordinates:\n%s", fake.errors[0])
+	}
+}
+
+func TestAssertGoldenMissingFile(t *testing.T) {
+	if Update() {
+		t.Skip("golden files are being updated")
+	}
+
+	fake := &recorder{TB: t}
+	AssertGolden(fake, "does_not_exist", canvas.New(2, 4))
+	if !fake.fatal {
+		t.Error("AssertGolden() with missing file should fail fatally")
+	}
+}
+
+func TestEnabledFromEnvironment(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"", false},
+		{"1", true},
+		{"true", true},
+		{"0", false},
+		{"yes", false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.value, func(t *testing.T) {
+			t.Setenv(EnvVisual, testCase.value)
+			if actual := Visual(); actual != testCase.expected {
+				t.Errorf("Visual() with %s=%q = %v, want %v", EnvVisual, testCase.value, actual, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestUpdateFromFlag(t *testing.T) {
+	t.Setenv(EnvUpdate, "")
+	defined := flag.Lookup("update")
+	previous := defined.Value.String()
+	defer defined.Value.Set(previous)
+
+	defined.Value.Set("true")
+	if !Update() {
+		t.Error("Update() with -update set = false, want true")
+	}
+	defined.Value.Set("false")
+	if Update() {
+		t.Error("Update() with -update unset = true, want false")
+	}
+}
+
+func TestPrintVisual(t *testing.T) {
+	c := canvas.New(2, 4)
+	c.Set(0, 0)
+
+	t.Setenv(EnvVisual, "1")
+	fake := &logger{TB: t}
+	PrintVisual(fake, "dot", c)
+	if len(fake.logs) != 1 || !strings.Contains(fake.logs[0], "=== dot ===") {
+		t.Errorf("PrintVisual() logged %q, want one entry headed \"=== dot ===\"", fake.logs)
+	}
+
+	t.Setenv(EnvVisual, "0")
+	fake = &logger{TB: t}
+	PrintVisual(fake, "dot", c)
+	if len(fake.logs) != 0 {
+		t.Errorf("PrintVisual() with visual output off logged %q, want nothing", fake.logs)
+	}
+}
+
+// logger captures log output instead of writing it to the test log.
+type logger struct {
+	testing.TB
+	logs []string
+}
+
+func (l *logger) Helper() {}
+
+func (l *logger) Logf(format string, arguments ...any) {
+	l.logs = append(l.logs, fmt.Sprintf(format, arguments...))
+}
@@ -0,0 +1,338 @@
+// Package term manages a terminal session for full-screen canvas rendering.
+//
+// A Terminal can switch to the alternate screen, hide the cursor, enable raw
+// mode, and query and watch the terminal size, then restore everything on
+// exit, panic, or signal. Raw mode and size queries use system calls and are
+// currently only supported on Linux; other platforms return
+// errors.ErrUnsupported.
+package term
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// Escape sequences written by Terminal.
+const (
+	sequenceEnterAltScreen = "\x1b[?1049h"
+	sequenceExitAltScreen  = "\x1b[?1049l"
+	sequenceHideCursor     = "\x1b[?25l"
+	sequenceShowCursor     = "\x1b[?25h"
+	sequenceCursorHome     = "\x1b[H"
+	sequenceClearScreen    = "\x1b[2J"
+
+	// Button and drag tracking (1000, 1002) reported in SGR format (1006)
+	sequenceEnableMouse  = "\x1b[?1000h\x1b[?1002h\x1b[?1006h"
+	sequenceDisableMouse = "\x1b[?1006l\x1b[?1002l\x1b[?1000l"
+)
+
+// Terminal tracks the state of a terminal session so it can be restored.
+type Terminal struct {
+	altScreen    bool       // whether the alternate screen is active
+	cursorHidden bool       // whether the cursor is hidden
+	input        *os.File   // terminal input, used for raw mode and size queries
+	mouse        bool       // whether mouse reporting is enabled
+	mutex        sync.Mutex // guards state changes from signal handlers
+	output       io.Writer  // destination for escape sequences and frames
+	saved        *state     // terminal attributes before raw mode, nil when not raw
+}
+
+// New creates a Terminal that reads from input and writes to output.
+// No terminal state is changed until one of its methods is called.
+func New(input *os.File, output io.Writer) *Terminal {
+	return &Terminal{
+		input:  input,
+		output: output,
+	}
+}
+
+// Open creates a Terminal for the process's standard input and output.
+func Open() *Terminal {
+	return New(os.Stdin, os.Stdout)
+}
+
+// EnterAltScreen switches to the alternate screen buffer.
+func (terminal *Terminal) EnterAltScreen() error {
+	terminal.mutex.Lock()
+	defer terminal.mutex.Unlock()
+
+	if err := terminal.write(sequenceEnterAltScreen); err != nil {
+		return err
+	}
+	terminal.altScreen = true
+	return nil
+}
+
+// ExitAltScreen switches back to the main screen buffer.
+func (terminal *Terminal) ExitAltScreen() error {
+	terminal.mutex.Lock()
+	defer terminal.mutex.Unlock()
+
+	return terminal.exitAltScreen()
+}
+
+// HideCursor hides the text cursor.
+func (terminal *Terminal) HideCursor() error {
+	terminal.mutex.Lock()
+	defer terminal.mutex.Unlock()
+
+	if err := terminal.write(sequenceHideCursor); err != nil {
+		return err
+	}
+	terminal.cursorHidden = true
+	return nil
+}
+
+// ShowCursor shows the text cursor.
+func (terminal *Terminal) ShowCursor() error {
+	terminal.mutex.Lock()
+	defer terminal.mutex.Unlock()
+
+	return terminal.showCursor()
+}
+
+// EnableMouse turns on mouse reporting for button presses, releases, and drags,
+// encoded as SGR 1006 sequences that the input package decodes.
+func (terminal *Terminal) EnableMouse() error {
+	terminal.mutex.Lock()
+	defer terminal.mutex.Unlock()
+
+	if err := terminal.write(sequenceEnableMouse); err != nil {
+		return err
+	}
+	terminal.mouse = true
+	return nil
+}
+
+// DisableMouse turns off mouse reporting.
+func (terminal *Terminal) DisableMouse() error {
+	terminal.mutex.Lock()
+	defer terminal.mutex.Unlock()
+
+	return terminal.disableMouse()
+}
+
+// EnableRawMode disables line buffering, echo, and signal keys on the input,
+// so every key press is delivered immediately. The previous settings are saved
+// and restored by DisableRawMode or Restore.
+func (terminal *Terminal) EnableRawMode() error {
+	terminal.mutex.Lock()
+	defer terminal.mutex.Unlock()
+
+	if terminal.saved != nil {
+		return nil
+	}
+	saved, err := makeRaw(terminal.input)
+	if err != nil {
+		return err
+	}
+	terminal.saved = saved
+	return nil
+}
+
+// DisableRawMode restores the input settings saved by EnableRawMode.
+func (terminal *Terminal) DisableRawMode() error {
+	terminal.mutex.Lock()
+	defer terminal.mutex.Unlock()
+
+	return terminal.disableRawMode()
+}
+
+// Size returns the terminal dimensions in character cells.
+// A canvas that fills the terminal can be created with canvas.NewCells(columns, rows).
+func (terminal *Terminal) Size() (columns, rows int, err error) {
+	if file, ok := terminal.output.(*os.File); ok {
+		if columns, rows, err = getSize(file); err == nil {
+			return columns, rows, nil
+		}
+	}
+	return getSize(terminal.input)
+}
+
+// Clear erases the screen and moves the cursor to the top-left corner.
+func (terminal *Terminal) Clear() error {
+	return terminal.write(sequenceClearScreen + sequenceCursorHome)
+}
+
+// Draw writes a frame at the top-left corner of the screen, replacing the
+// previous frame in place. Newlines are written as CR LF so rows line up
+// in raw mode.
+func (terminal *Terminal) Draw(frame string) error {
+	return terminal.write(sequenceCursorHome + strings.ReplaceAll(frame, "\n", "\r\n"))
+}
+
+// Restore undoes every change made through this Terminal: it disables mouse
+// reporting and raw mode, shows the cursor, and leaves the alternate screen.
+// It is safe to call more than once. The first error encountered is returned.
+func (terminal *Terminal) Restore() error {
+	terminal.mutex.Lock()
+	defer terminal.mutex.Unlock()
+
+	var first error
+	for _, step := range []func() error{
+		terminal.disableMouse, terminal.disableRawMode, terminal.restoreCursor, terminal.exitAltScreen,
+	} {
+		if err := step(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Recover restores the terminal if the program is panicking, then re-panics
+// so the panic message is printed to a usable screen. Call it with defer:
+//
+//	defer terminal.Recover()
+func (terminal *Terminal) Recover() {
+	if recovered := recover(); recovered != nil {
+		_ = terminal.Restore()
+		panic(recovered)
+	}
+}
+
+// RestoreOnSignal restores the terminal when the process receives one of the
+// given signals (SIGINT and SIGTERM when none are given), then re-delivers the
+// signal so the process terminates as it normally would.
+// The returned function stops watching for signals.
+func (terminal *Terminal) RestoreOnSignal(signals ...os.Signal) (stop func()) {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	received := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(received, signals...)
+
+	go func() {
+		select {
+		case sig := <-received:
+			_ = terminal.Restore()
+			signal.Stop(received)
+			redeliver(sig)
+		case <-done:
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(received)
+			close(done)
+		})
+	}
+}
+
+// OnResize calls handle with the new terminal size, in character cells,
+// whenever the terminal is resized, so a layout or canvas can follow it.
+// Resizes whose size cannot be queried are skipped. Resize notifications
+// (SIGWINCH) are currently only supported on Linux; on other platforms handle
+// is never called. The returned function stops watching for resizes and
+// waits for a call to handle in progress to return, so it must not be called
+// from handle.
+func (terminal *Terminal) OnResize(handle func(columns, rows int)) (stop func()) {
+	received := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	if len(resizeSignals) > 0 {
+		signal.Notify(received, resizeSignals...)
+	}
+
+	go func() {
+		defer close(finished)
+		for {
+			select {
+			case <-received:
+				if columns, rows, err := terminal.Size(); err == nil {
+					handle(columns, rows)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(received)
+			close(done)
+			<-finished
+		})
+	}
+}
+
+// redeliver sends a signal to the current process again after its handler has
+// been removed, falling back to exiting when that is not possible.
+// It is a variable so tests can observe it without terminating.
+var redeliver = func(sig os.Signal) {
+	process, err := os.FindProcess(os.Getpid())
+	if err == nil {
+		err = process.Signal(sig)
+	}
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+// disableMouse turns off mouse reporting if it is enabled. The caller must hold the mutex.
+func (terminal *Terminal) disableMouse() error {
+	if !terminal.mouse {
+		return nil
+	}
+	if err := terminal.write(sequenceDisableMouse); err != nil {
+		return err
+	}
+	terminal.mouse = false
+	return nil
+}
+
+// disableRawMode restores saved input settings. The caller must hold the mutex.
+func (terminal *Terminal) disableRawMode() error {
+	if terminal.saved == nil {
+		return nil
+	}
+	if err := restoreState(terminal.input, terminal.saved); err != nil {
+		return err
+	}
+	terminal.saved = nil
+	return nil
+}
+
+// showCursor shows the cursor. The caller must hold the mutex.
+func (terminal *Terminal) showCursor() error {
+	if err := terminal.write(sequenceShowCursor); err != nil {
+		return err
+	}
+	terminal.cursorHidden = false
+	return nil
+}
+
+// restoreCursor shows the cursor if it was hidden. The caller must hold the mutex.
+func (terminal *Terminal) restoreCursor() error {
+	if !terminal.cursorHidden {
+		return nil
+	}
+	return terminal.showCursor()
+}
+
+// exitAltScreen leaves the alternate screen if it is active. The caller must hold the mutex.
+func (terminal *Terminal) exitAltScreen() error {
+	if !terminal.altScreen {
+		return nil
+	}
+	if err := terminal.write(sequenceExitAltScreen); err != nil {
+		return err
+	}
+	terminal.altScreen = false
+	return nil
+}
+
+// write sends an escape sequence or frame to the output.
+func (terminal *Terminal) write(text string) error {
+	_, err := io.WriteString(terminal.output, text)
+	return err
+}
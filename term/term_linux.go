@@ -0,0 +1,75 @@
+//go:build linux
+
+package term
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// resizeSignals are the signals that report a terminal resize.
+var resizeSignals = []os.Signal{syscall.SIGWINCH}
+
+// state holds the terminal attributes saved before entering raw mode.
+type state struct {
+	termios syscall.Termios
+}
+
+// winsize mirrors struct winsize from <sys/ioctl.h>.
+type winsize struct {
+	rows    uint16
+	columns uint16
+	xPixels uint16
+	yPixels uint16
+}
+
+// makeRaw puts the terminal into raw mode, as cfmakeraw(3) does, and returns
+// the previous attributes.
+func makeRaw(file *os.File) (*state, error) {
+	var termios syscall.Termios
+	if err := ioctl(file, syscall.TCGETS, unsafe.Pointer(&termios)); err != nil {
+		return nil, err
+	}
+	saved := &state{termios: termios}
+
+	termios.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	termios.Oflag &^= syscall.OPOST
+	termios.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	termios.Cflag &^= syscall.CSIZE | syscall.PARENB
+	termios.Cflag |= syscall.CS8
+	termios.Cc[syscall.VMIN] = 1
+	termios.Cc[syscall.VTIME] = 0
+
+	if err := ioctl(file, syscall.TCSETS, unsafe.Pointer(&termios)); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// restoreState applies previously saved terminal attributes.
+func restoreState(file *os.File, saved *state) error {
+	return ioctl(file, syscall.TCSETS, unsafe.Pointer(&saved.termios))
+}
+
+// getSize queries the terminal dimensions with TIOCGWINSZ.
+func getSize(file *os.File) (columns, rows int, err error) {
+	var size winsize
+	if err := ioctl(file, syscall.TIOCGWINSZ, unsafe.Pointer(&size)); err != nil {
+		return 0, 0, err
+	}
+	return int(size.columns), int(size.rows), nil
+}
+
+// ioctl performs an ioctl system call on the file's descriptor.
+func ioctl(file *os.File, request uintptr, argument unsafe.Pointer) error {
+	if file == nil {
+		return os.ErrInvalid
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), request, uintptr(argument))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
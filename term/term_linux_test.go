@@ -0,0 +1,131 @@
+//go:build linux
+
+package term
+
+import (
+	"bytes"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestRawModeRequiresTerminal(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	defer reader.Close()
+	defer writer.Close()
+
+	terminal := New(reader, writer)
+	if err := terminal.EnableRawMode(); err == nil {
+		t.Error("EnableRawMode() on a pipe error = nil, want error")
+	}
+	if err := terminal.DisableRawMode(); err != nil {
+		t.Errorf("DisableRawMode() without raw mode error = %v, want nil", err)
+	}
+	if _, _, err := terminal.Size(); err == nil {
+		t.Error("Size() on a pipe error = nil, want error")
+	}
+}
+
+func TestRestoreOnSignal(t *testing.T) {
+	delivered := make(chan os.Signal, 1)
+	original := redeliver
+	redeliver = func(sig os.Signal) { delivered <- sig }
+	defer func() { redeliver = original }()
+
+	var output bytes.Buffer
+	terminal := New(nil, &output)
+	_ = terminal.HideCursor()
+
+	stop := terminal.RestoreOnSignal(syscall.SIGUSR1)
+	defer stop()
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Kill() error = %v", err)
+	}
+
+	select {
+	case sig := <-delivered:
+		if sig != syscall.SIGUSR1 {
+			t.Errorf("redelivered %v, want %v", sig, syscall.SIGUSR1)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("signal was not handled")
+	}
+
+	terminal.mutex.Lock()
+	hidden := terminal.cursorHidden
+	terminal.mutex.Unlock()
+	if hidden {
+		t.Error("cursor still hidden after signal, want restored")
+	}
+}
+
+func TestRestoreOnSignalStop(t *testing.T) {
+	terminal := New(nil, &bytes.Buffer{})
+	stop := terminal.RestoreOnSignal(syscall.SIGUSR2)
+
+	// Stopping twice is safe
+	stop()
+	stop()
+}
+
+func TestOnResize(t *testing.T) {
+	// A pseudo-terminal master reports and accepts a window size
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skipf("no pseudo-terminal: %v", err)
+	}
+	defer master.Close()
+	size := winsize{rows: 24, columns: 80}
+	if err := ioctl(master, syscall.TIOCSWINSZ, unsafe.Pointer(&size)); err != nil {
+		t.Skipf("cannot set pseudo-terminal size: %v", err)
+	}
+
+	type resize struct{ columns, rows int }
+	resized := make(chan resize, 1)
+	terminal := New(master, master)
+	stop := terminal.OnResize(func(columns, rows int) { resized <- resize{columns, rows} })
+	defer stop()
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGWINCH); err != nil {
+		t.Fatalf("Kill() error = %v", err)
+	}
+	select {
+	case actual := <-resized:
+		if actual != (resize{80, 24}) {
+			t.Errorf("OnResize handled %dx%d, want 80x24", actual.columns, actual.rows)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("resize was not handled")
+	}
+}
+
+func TestOnResizeSkipsUnknownSize(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	defer reader.Close()
+	defer writer.Close()
+
+	called := make(chan struct{}, 1)
+	stop := New(reader, writer).OnResize(func(int, int) { called <- struct{}{} })
+	defer stop()
+	if err := syscall.Kill(os.Getpid(), syscall.SIGWINCH); err != nil {
+		t.Fatalf("Kill() error = %v", err)
+	}
+	select {
+	case <-called:
+		t.Error("OnResize called handle for a pipe, want skipped")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Stopping twice is safe
+	stop()
+	stop()
+}
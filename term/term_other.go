@@ -0,0 +1,30 @@
+//go:build !linux
+
+package term
+
+import (
+	"errors"
+	"os"
+)
+
+// resizeSignals is empty because resize notifications are not supported on
+// this platform.
+var resizeSignals []os.Signal
+
+// state holds the terminal attributes saved before entering raw mode.
+type state struct{}
+
+// makeRaw is not supported on this platform.
+func makeRaw(*os.File) (*state, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// restoreState is not supported on this platform.
+func restoreState(*os.File, *state) error {
+	return errors.ErrUnsupported
+}
+
+// getSize is not supported on this platform.
+func getSize(*os.File) (columns, rows int, err error) {
+	return 0, 0, errors.ErrUnsupported
+}
@@ -0,0 +1,196 @@
+package term
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// failingWriter rejects every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestEnterAndExitAltScreen(t *testing.T) {
+	var output bytes.Buffer
+	terminal := New(nil, &output)
+
+	if err := terminal.EnterAltScreen(); err != nil {
+		t.Fatalf("EnterAltScreen() error = %v", err)
+	}
+	if err := terminal.ExitAltScreen(); err != nil {
+		t.Fatalf("ExitAltScreen() error = %v", err)
+	}
+
+	expected := "\x1b[?1049h\x1b[?1049l"
+	if output.String() != expected {
+		t.Errorf("output = %q, want %q", output.String(), expected)
+	}
+}
+
+func TestHideAndShowCursor(t *testing.T) {
+	var output bytes.Buffer
+	terminal := New(nil, &output)
+
+	if err := terminal.HideCursor(); err != nil {
+		t.Fatalf("HideCursor() error = %v", err)
+	}
+	if err := terminal.ShowCursor(); err != nil {
+		t.Fatalf("ShowCursor() error = %v", err)
+	}
+
+	expected := "\x1b[?25l\x1b[?25h"
+	if output.String() != expected {
+		t.Errorf("output = %q, want %q", output.String(), expected)
+	}
+}
+
+func TestEnableAndDisableMouse(t *testing.T) {
+	var output bytes.Buffer
+	terminal := New(nil, &output)
+
+	if err := terminal.EnableMouse(); err != nil {
+		t.Fatalf("EnableMouse() error = %v", err)
+	}
+	if output.String() != "\x1b[?1000h\x1b[?1002h\x1b[?1006h" {
+		t.Errorf("EnableMouse() wrote %q", output.String())
+	}
+
+	output.Reset()
+	if err := terminal.Restore(); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if output.String() != "\x1b[?1006l\x1b[?1002l\x1b[?1000l" {
+		t.Errorf("Restore() wrote %q, want mouse reporting disabled", output.String())
+	}
+
+	output.Reset()
+	if err := terminal.DisableMouse(); err != nil {
+		t.Fatalf("DisableMouse() error = %v", err)
+	}
+	if output.Len() != 0 {
+		t.Errorf("DisableMouse() after Restore wrote %q, want nothing", output.String())
+	}
+}
+
+func TestDraw(t *testing.T) {
+	var output bytes.Buffer
+	terminal := New(nil, &output)
+
+	if err := terminal.Draw("ab\ncd"); err != nil {
+		t.Fatalf("Draw() error = %v", err)
+	}
+
+	expected := "\x1b[Hab\r\ncd"
+	if output.String() != expected {
+		t.Errorf("output = %q, want %q", output.String(), expected)
+	}
+}
+
+func TestClear(t *testing.T) {
+	var output bytes.Buffer
+	terminal := New(nil, &output)
+
+	if err := terminal.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if output.String() != "\x1b[2J\x1b[H" {
+		t.Errorf("output = %q, want %q", output.String(), "\x1b[2J\x1b[H")
+	}
+}
+
+func TestRestore(t *testing.T) {
+	var output bytes.Buffer
+	terminal := New(nil, &output)
+
+	_ = terminal.EnterAltScreen()
+	_ = terminal.HideCursor()
+	output.Reset()
+
+	if err := terminal.Restore(); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	expected := "\x1b[?25h\x1b[?1049l"
+	if output.String() != expected {
+		t.Errorf("output = %q, want %q", output.String(), expected)
+	}
+
+	// A second Restore has nothing left to undo
+	output.Reset()
+	if err := terminal.Restore(); err != nil {
+		t.Fatalf("second Restore() error = %v", err)
+	}
+	if output.Len() != 0 {
+		t.Errorf("second Restore() wrote %q, want nothing", output.String())
+	}
+}
+
+func TestRestoreWithoutChanges(t *testing.T) {
+	var output bytes.Buffer
+	terminal := New(nil, &output)
+
+	if err := terminal.Restore(); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if output.Len() != 0 {
+		t.Errorf("Restore() wrote %q, want nothing", output.String())
+	}
+}
+
+func TestWriteErrors(t *testing.T) {
+	terminal := New(nil, failingWriter{})
+
+	if err := terminal.EnterAltScreen(); err == nil {
+		t.Error("EnterAltScreen() error = nil, want write error")
+	}
+	if err := terminal.HideCursor(); err == nil {
+		t.Error("HideCursor() error = nil, want write error")
+	}
+	if err := terminal.Draw("frame"); err == nil {
+		t.Error("Draw() error = nil, want write error")
+	}
+
+	// Failed changes are not recorded, so there is nothing to restore
+	if err := terminal.Restore(); err != nil {
+		t.Errorf("Restore() error = %v, want nil", err)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	var output bytes.Buffer
+	terminal := New(nil, &output)
+	_ = terminal.EnterAltScreen()
+
+	defer func() {
+		recovered := recover()
+		if recovered != "boom" {
+			t.Errorf("recovered %v, want re-panic with %q", recovered, "boom")
+		}
+		if !strings.HasSuffix(output.String(), "\x1b[?1049l") {
+			t.Errorf("output = %q, want alternate screen exited before re-panic", output.String())
+		}
+	}()
+
+	func() {
+		defer terminal.Recover()
+		panic("boom")
+	}()
+}
+
+func TestRecoverWithoutPanic(t *testing.T) {
+	var output bytes.Buffer
+	terminal := New(nil, &output)
+	_ = terminal.EnterAltScreen()
+	output.Reset()
+
+	func() {
+		defer terminal.Recover()
+	}()
+
+	if output.Len() != 0 {
+		t.Errorf("Recover() without panic wrote %q, want nothing", output.String())
+	}
+}
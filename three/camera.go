@@ -0,0 +1,74 @@
+package three
+
+import "math"
+
+// Projection is how a Camera maps 3D space onto the canvas.
+type Projection int
+
+// Camera projections.
+const (
+	Perspective  Projection = iota // distant things look smaller
+	Orthographic                   // things look the same size at any distance
+)
+
+// Defaults for a Camera.
+const (
+	DefaultFieldOfView = math.Pi / 3 // 60 degrees
+	DefaultNear        = 0.1
+	DefaultSize        = 2
+)
+
+// Camera is a viewpoint and projection.
+type Camera struct {
+	Position    Vec3       // where the camera is
+	Target      Vec3       // the point at the center of the view
+	Up          Vec3       // which way is up, the zero vector for +y
+	Projection  Projection // Perspective or Orthographic
+	FieldOfView float64    // vertical field of view of a perspective camera in radians, 0 for DefaultFieldOfView
+	Size        float64    // height of the view of an orthographic camera in world units, 0 for DefaultSize
+	Near        float64    // distance of the near plane; closer geometry is clipped, 0 for DefaultNear
+}
+
+// View returns the matrix that maps the world into the camera's space, as
+// LookAt does.
+func (camera Camera) View() Mat4 {
+	up := camera.Up
+	if up == (Vec3{}) {
+		up = Vec3{0, 1, 0}
+	}
+	return LookAt(camera.Position, camera.Target, up)
+}
+
+// near returns the distance of the near plane, with the default applied.
+func (camera Camera) near() float64 {
+	if camera.Near <= 0 {
+		return DefaultNear
+	}
+	return camera.Near
+}
+
+// scale returns the pixels per world unit on a canvas height pixels tall: for
+// a perspective camera, of something one unit away.
+func (camera Camera) scale(height int) float64 {
+	if camera.Projection == Orthographic {
+		size := camera.Size
+		if size <= 0 {
+			size = DefaultSize
+		}
+		return float64(height) / size
+	}
+	fieldOfView := camera.FieldOfView
+	if fieldOfView <= 0 || fieldOfView >= math.Pi {
+		fieldOfView = DefaultFieldOfView
+	}
+	return float64(height) / 2 / math.Tan(fieldOfView/2)
+}
+
+// facing reports whether a face with the given normal, through a point, both
+// in camera space, is turned toward the camera.
+func (camera Camera) facing(normal, point Vec3) bool {
+	if camera.Projection == Orthographic {
+		return normal.Z > 0
+	}
+	return normal.Dot(point) < 0
+}
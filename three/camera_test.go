@@ -0,0 +1,56 @@
+package three
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCameraDefaults(t *testing.T) {
+	camera := Camera{Position: Vec3{0, 0, 5}}
+	if actual := camera.View().Apply(Vec3{0, 1, 0}); !closeVec(actual, Vec3{0, 1, -5}) {
+		t.Errorf("View() with no Up maps (0, 1, 0) to %v, want (0, 1, -5)", actual)
+	}
+	if near := camera.near(); near != DefaultNear {
+		t.Errorf("near() = %v, want %v", near, DefaultNear)
+	}
+}
+
+func TestCameraScale(t *testing.T) {
+	tests := []struct {
+		name     string
+		camera   Camera
+		expected float64
+	}{
+		{"perspective", Camera{FieldOfView: math.Pi / 2}, 20},
+		{"default field of view", Camera{}, 20 * math.Sqrt(3)},
+		{"orthographic", Camera{Projection: Orthographic, Size: 4}, 10},
+		{"default size", Camera{Projection: Orthographic}, 20},
+	}
+
+	for _, testCase := range tests {
+		if scale := testCase.camera.scale(40); math.Abs(scale-testCase.expected) > 1e-9 {
+			t.Errorf("%s: scale(40) = %v, want %v", testCase.name, scale, testCase.expected)
+		}
+	}
+}
+
+func TestCameraFacing(t *testing.T) {
+	tests := []struct {
+		name     string
+		camera   Camera
+		normal   Vec3
+		point    Vec3
+		expected bool
+	}{
+		{"perspective toward", Camera{}, Vec3{0, 0, 1}, Vec3{0, 0, -5}, true},
+		{"perspective away", Camera{}, Vec3{0, 0, -1}, Vec3{0, 0, -5}, false},
+		{"perspective side seen at an angle", Camera{}, Vec3{1, 0, 0}, Vec3{-2, 0, -5}, true},
+		{"orthographic side seen edge on", Camera{Projection: Orthographic}, Vec3{1, 0, 0}, Vec3{-2, 0, -5}, false},
+	}
+
+	for _, testCase := range tests {
+		if facing := testCase.camera.facing(testCase.normal, testCase.point); facing != testCase.expected {
+			t.Errorf("%s: facing() = %v, want %v", testCase.name, facing, testCase.expected)
+		}
+	}
+}
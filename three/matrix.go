@@ -0,0 +1,103 @@
+package three
+
+import "math"
+
+// Mat4 is a 4x4 transformation matrix, indexed [row][column], that acts on
+// points as column vectors with an implicit w of 1.
+type Mat4 [4][4]float64
+
+// Identity returns the identity matrix.
+func Identity() Mat4 {
+	return Mat4{
+		{1, 0, 0, 0},
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+	}
+}
+
+// Translation returns a matrix that moves points by (x, y, z).
+func Translation(x, y, z float64) Mat4 {
+	matrix := Identity()
+	matrix[0][3], matrix[1][3], matrix[2][3] = x, y, z
+	return matrix
+}
+
+// Scaling returns a matrix that scales points by x, y, and z along each axis.
+func Scaling(x, y, z float64) Mat4 {
+	matrix := Identity()
+	matrix[0][0], matrix[1][1], matrix[2][2] = x, y, z
+	return matrix
+}
+
+// RotationX returns a matrix that rotates points by angle radians about the
+// x axis, counterclockwise when looking from +x toward the origin.
+func RotationX(angle float64) Mat4 {
+	sin, cos := math.Sincos(angle)
+	matrix := Identity()
+	matrix[1][1], matrix[1][2] = cos, -sin
+	matrix[2][1], matrix[2][2] = sin, cos
+	return matrix
+}
+
+// RotationY returns a matrix that rotates points by angle radians about the
+// y axis, counterclockwise when looking from +y toward the origin.
+func RotationY(angle float64) Mat4 {
+	sin, cos := math.Sincos(angle)
+	matrix := Identity()
+	matrix[0][0], matrix[0][2] = cos, sin
+	matrix[2][0], matrix[2][2] = -sin, cos
+	return matrix
+}
+
+// RotationZ returns a matrix that rotates points by angle radians about the
+// z axis, counterclockwise when looking from +z toward the origin.
+func RotationZ(angle float64) Mat4 {
+	sin, cos := math.Sincos(angle)
+	matrix := Identity()
+	matrix[0][0], matrix[0][1] = cos, -sin
+	matrix[1][0], matrix[1][1] = sin, cos
+	return matrix
+}
+
+// LookAt returns a view matrix for an eye at eye looking toward target, with
+// up pointing roughly up. It maps the world into camera space, where the eye
+// is at the origin, x points right, y points up, and the camera looks along -z.
+func LookAt(eye, target, up Vec3) Mat4 {
+	forward := target.Sub(eye).Normalize()
+	right := forward.Cross(up).Normalize()
+	trueUp := right.Cross(forward)
+	return Mat4{
+		{right.X, right.Y, right.Z, -right.Dot(eye)},
+		{trueUp.X, trueUp.Y, trueUp.Z, -trueUp.Dot(eye)},
+		{-forward.X, -forward.Y, -forward.Z, forward.Dot(eye)},
+		{0, 0, 0, 1},
+	}
+}
+
+// Mul returns the matrix product m × other, which applies other first and
+// then m.
+func (m Mat4) Mul(other Mat4) Mat4 {
+	var product Mat4
+	for row := range 4 {
+		for column := range 4 {
+			for index := range 4 {
+				product[row][column] += m[row][index] * other[index][column]
+			}
+		}
+	}
+	return product
+}
+
+// Apply returns the point transformed by m, dividing by w when the matrix
+// produces one other than 1.
+func (m Mat4) Apply(point Vec3) Vec3 {
+	x := m[0][0]*point.X + m[0][1]*point.Y + m[0][2]*point.Z + m[0][3]
+	y := m[1][0]*point.X + m[1][1]*point.Y + m[1][2]*point.Z + m[1][3]
+	z := m[2][0]*point.X + m[2][1]*point.Y + m[2][2]*point.Z + m[2][3]
+	w := m[3][0]*point.X + m[3][1]*point.Y + m[3][2]*point.Z + m[3][3]
+	if w != 1 && w != 0 {
+		return Vec3{x / w, y / w, z / w}
+	}
+	return Vec3{x, y, z}
+}
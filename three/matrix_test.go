@@ -0,0 +1,62 @@
+package three
+
+import (
+	"math"
+	"testing"
+)
+
+// closeVec reports whether two vectors match, allowing for rounding error.
+func closeVec(a, b Vec3) bool {
+	return a.Sub(b).Length() < 1e-9
+}
+
+func TestTransforms(t *testing.T) {
+	point := Vec3{1, 2, 3}
+	tests := []struct {
+		name     string
+		matrix   Mat4
+		expected Vec3
+	}{
+		{"Identity", Identity(), Vec3{1, 2, 3}},
+		{"Translation", Translation(1, -2, 3), Vec3{2, 0, 6}},
+		{"Scaling", Scaling(2, 3, -1), Vec3{2, 6, -3}},
+		{"RotationX", RotationX(math.Pi / 2), Vec3{1, -3, 2}},
+		{"RotationY", RotationY(math.Pi / 2), Vec3{3, 2, -1}},
+		{"RotationZ", RotationZ(math.Pi / 2), Vec3{-2, 1, 3}},
+		{"Mul applies the right matrix first", Translation(1, 0, 0).Mul(Scaling(2, 2, 2)), Vec3{3, 4, 6}},
+		{"Mul the other way", Scaling(2, 2, 2).Mul(Translation(1, 0, 0)), Vec3{4, 4, 6}},
+	}
+
+	for _, testCase := range tests {
+		if actual := testCase.matrix.Apply(point); !closeVec(actual, testCase.expected) {
+			t.Errorf("%s: Apply(%v) = %v, want %v", testCase.name, point, actual, testCase.expected)
+		}
+	}
+}
+
+func TestApplyDividesByW(t *testing.T) {
+	matrix := Identity()
+	matrix[3][3] = 2
+	if actual := matrix.Apply(Vec3{2, 4, 6}); actual != (Vec3{1, 2, 3}) {
+		t.Errorf("Apply() = %v, want (1, 2, 3)", actual)
+	}
+}
+
+func TestLookAt(t *testing.T) {
+	view := LookAt(Vec3{5, 0, 0}, Vec3{}, Vec3{0, 1, 0})
+	tests := []struct {
+		world    Vec3
+		expected Vec3
+	}{
+		{Vec3{5, 0, 0}, Vec3{}},          // the eye is the origin
+		{Vec3{}, Vec3{0, 0, -5}},         // the target is straight ahead, along -z
+		{Vec3{0, 1, 0}, Vec3{0, 1, -5}},  // up stays up
+		{Vec3{0, 0, -1}, Vec3{1, 0, -5}}, // looking along -x, -z is to the right
+	}
+
+	for _, testCase := range tests {
+		if actual := view.Apply(testCase.world); !closeVec(actual, testCase.expected) {
+			t.Errorf("Apply(%v) = %v, want %v", testCase.world, actual, testCase.expected)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package three
+
+// Mesh is a wireframe model: vertices joined by the edges of its faces and by
+// any extra edges.
+type Mesh struct {
+	Vertices []Vec3   // vertex positions
+	Faces    [][]int  // polygons as vertex indices, counterclockwise seen from the front
+	Edges    [][2]int // edges between vertex indices that are not sides of a face
+}
+
+// Transform returns a copy of the mesh with its vertices transformed by m.
+// Faces and edges are shared with the original.
+func (mesh Mesh) Transform(m Mat4) Mesh {
+	vertices := make([]Vec3, len(mesh.Vertices))
+	for index, vertex := range mesh.Vertices {
+		vertices[index] = m.Apply(vertex)
+	}
+	return Mesh{Vertices: vertices, Faces: mesh.Faces, Edges: mesh.Edges}
+}
+
+// Cube returns a cube with sides of the given length, centered on the origin.
+func Cube(size float64) Mesh {
+	half := size / 2
+	vertices := make([]Vec3, 8)
+	for index := range vertices {
+		vertices[index] = Vec3{-half, -half, -half}
+		if index&1 != 0 {
+			vertices[index].X = half
+		}
+		if index&2 != 0 {
+			vertices[index].Y = half
+		}
+		if index&4 != 0 {
+			vertices[index].Z = half
+		}
+	}
+	return Mesh{
+		Vertices: vertices,
+		Faces: [][]int{
+			{0, 2, 3, 1}, // back, -z
+			{4, 5, 7, 6}, // front, +z
+			{0, 1, 5, 4}, // bottom, -y
+			{2, 6, 7, 3}, // top, +y
+			{0, 4, 6, 2}, // left, -x
+			{1, 3, 7, 5}, // right, +x
+		},
+	}
+}
+
+// edge is a side shared by faces, or an extra edge with no faces.
+type edge struct {
+	faces []int // indices of the faces the edge borders
+	start int   // first vertex index
+	end   int   // second vertex index
+}
+
+// edges returns every edge of the mesh once, with the faces it borders, in
+// the order they first appear. Edges with an index out of range are skipped.
+func (mesh Mesh) edges() []edge {
+	var edges []edge
+	seen := make(map[[2]int]int)
+	add := func(start, end, face int) {
+		if start < 0 || start >= len(mesh.Vertices) || end < 0 || end >= len(mesh.Vertices) || start == end {
+			return
+		}
+		key := [2]int{min(start, end), max(start, end)}
+		index, ok := seen[key]
+		if !ok {
+			index = len(edges)
+			seen[key] = index
+			edges = append(edges, edge{start: start, end: end})
+		}
+		if face >= 0 {
+			edges[index].faces = append(edges[index].faces, face)
+		}
+	}
+
+	for face, vertices := range mesh.Faces {
+		for index, start := range vertices {
+			add(start, vertices[(index+1)%len(vertices)], face)
+		}
+	}
+	for _, extra := range mesh.Edges {
+		add(extra[0], extra[1], -1)
+	}
+	return edges
+}
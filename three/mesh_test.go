@@ -0,0 +1,63 @@
+package three
+
+import "testing"
+
+func TestCube(t *testing.T) {
+	cube := Cube(2)
+	if len(cube.Vertices) != 8 || len(cube.Faces) != 6 {
+		t.Fatalf("Cube() has %d vertices and %d faces, want 8 and 6", len(cube.Vertices), len(cube.Faces))
+	}
+
+	// Every face points outward, away from the center
+	for face, vertices := range cube.Faces {
+		polygon := make([]Vec3, len(vertices))
+		for index, vertex := range vertices {
+			polygon[index] = cube.Vertices[vertex]
+		}
+		if normal(polygon).Dot(polygon[0]) <= 0 {
+			t.Errorf("face %d %v points inward", face, vertices)
+		}
+	}
+
+	edges := cube.edges()
+	if len(edges) != 12 {
+		t.Fatalf("edges() = %d edges, want 12", len(edges))
+	}
+	for _, edge := range edges {
+		if len(edge.faces) != 2 {
+			t.Errorf("edge %d-%d borders %d faces, want 2", edge.start, edge.end, len(edge.faces))
+		}
+	}
+}
+
+func TestEdgesIncludeExtraEdges(t *testing.T) {
+	mesh := Mesh{
+		Vertices: []Vec3{{}, {1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+		Faces:    [][]int{{0, 1, 2}},
+		Edges:    [][2]int{{2, 3}, {1, 0}, {3, 9}, {3, 3}},
+	}
+	edges := mesh.edges()
+
+	// Three sides of the face, then the one new extra edge; the repeated,
+	// out-of-range, and degenerate edges are skipped
+	if len(edges) != 4 {
+		t.Fatalf("edges() = %d edges, want 4", len(edges))
+	}
+	if last := edges[3]; last.start != 2 || last.end != 3 || len(last.faces) != 0 {
+		t.Errorf("edges()[3] = %+v, want 2-3 with no faces", last)
+	}
+	if first := edges[0]; len(first.faces) != 1 {
+		t.Errorf("edges()[0] borders %d faces, want 1", len(first.faces))
+	}
+}
+
+func TestTransform(t *testing.T) {
+	cube := Cube(2)
+	moved := cube.Transform(Translation(10, 0, 0))
+	if moved.Vertices[0] != (Vec3{9, -1, -1}) {
+		t.Errorf("Transform() vertex 0 = %v, want (9, -1, -1)", moved.Vertices[0])
+	}
+	if cube.Vertices[0] != (Vec3{-1, -1, -1}) {
+		t.Error("Transform() changed the original mesh")
+	}
+}
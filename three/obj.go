@@ -0,0 +1,88 @@
+package three
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// LoadOBJ reads a mesh from a Wavefront OBJ file. It reads vertices (v),
+// faces (f), and polylines (l), which become edges; indices may be negative
+// to count back from the latest vertex, and texture and normal indices
+// (f 1/1/1) are ignored. Other statements, such as normals, texture
+// coordinates, groups, and materials, are skipped.
+func LoadOBJ(reader io.Reader) (Mesh, error) {
+	var mesh Mesh
+	scanner := bufio.NewScanner(reader)
+	for number := 1; scanner.Scan(); number++ {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "v":
+			if len(fields) < 4 {
+				return Mesh{}, fmt.Errorf("line %d: vertex needs 3 coordinates", number)
+			}
+			var coordinates [3]float64
+			for index := range coordinates {
+				value, err := strconv.ParseFloat(fields[index+1], 64)
+				if err != nil {
+					return Mesh{}, fmt.Errorf("line %d: invalid coordinate %q", number, fields[index+1])
+				}
+				coordinates[index] = value
+			}
+			mesh.Vertices = append(mesh.Vertices, Vec3{coordinates[0], coordinates[1], coordinates[2]})
+
+		case "f", "l":
+			minimum := 3
+			if fields[0] == "l" {
+				minimum = 2
+			}
+			if len(fields)-1 < minimum {
+				return Mesh{}, fmt.Errorf("line %d: %q needs at least %d vertices", number, fields[0], minimum)
+			}
+			indices := make([]int, len(fields)-1)
+			for index, field := range fields[1:] {
+				vertex, err := objIndex(field, len(mesh.Vertices))
+				if err != nil {
+					return Mesh{}, fmt.Errorf("line %d: %w", number, err)
+				}
+				indices[index] = vertex
+			}
+			if fields[0] == "f" {
+				mesh.Faces = append(mesh.Faces, indices)
+				continue
+			}
+			for index := 1; index < len(indices); index++ {
+				mesh.Edges = append(mesh.Edges, [2]int{indices[index-1], indices[index]})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Mesh{}, err
+	}
+	return mesh, nil
+}
+
+// objIndex converts an OBJ vertex reference, 1-based or negative and
+// optionally followed by texture and normal indices, to a 0-based index
+// among count vertices read so far.
+func objIndex(field string, count int) (int, error) {
+	reference, _, _ := strings.Cut(field, "/")
+	index, err := strconv.Atoi(reference)
+	if err != nil {
+		return 0, fmt.Errorf("invalid vertex index %q", field)
+	}
+	if index < 0 {
+		index += count + 1
+	}
+	if index < 1 || index > count {
+		return 0, fmt.Errorf("vertex index %q out of range 1-%d", field, count)
+	}
+	return index - 1, nil
+}
@@ -0,0 +1,60 @@
+package three
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadOBJ(t *testing.T) {
+	source := `# a square with a stalk
+o square
+v 0 0 0
+v 1 0 0
+v 1 1 0
+v 0 1 0 1.0
+vn 0 0 1
+vt 0 0
+v 0.5 0.5 1
+usemtl green
+f 1/1/1 2/2/1 3//1 4
+l -1 1 3
+`
+	mesh, err := LoadOBJ(strings.NewReader(source))
+	if err != nil {
+		t.Fatalf("LoadOBJ() error = %v", err)
+	}
+
+	expected := Mesh{
+		Vertices: []Vec3{{0, 0, 0}, {1, 0, 0}, {1, 1, 0}, {0, 1, 0}, {0.5, 0.5, 1}},
+		Faces:    [][]int{{0, 1, 2, 3}},
+		Edges:    [][2]int{{4, 0}, {0, 2}},
+	}
+	if !reflect.DeepEqual(mesh, expected) {
+		t.Errorf("LoadOBJ() = %+v, want %+v", mesh, expected)
+	}
+}
+
+func TestLoadOBJErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{"short vertex", "v 1 2", "line 1: vertex needs 3 coordinates"},
+		{"bad coordinate", "v 1 x 3", `line 1: invalid coordinate "x"`},
+		{"short face", "v 0 0 0\nv 1 0 0\nf 1 2", `line 3: "f" needs at least 3 vertices`},
+		{"bad index", "v 0 0 0\nl 1 a", `line 2: invalid vertex index "a"`},
+		{"index out of range", "v 0 0 0\nv 1 0 0\nv 0 1 0\nf 1 2 4", `line 4: vertex index "4" out of range 1-3`},
+		{"index zero", "v 0 0 0\nl 0 1", `line 2: vertex index "0" out of range 1-1`},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := LoadOBJ(strings.NewReader(testCase.source))
+			if err == nil || err.Error() != testCase.expected {
+				t.Errorf("LoadOBJ() error = %v, want %q", err, testCase.expected)
+			}
+		})
+	}
+}
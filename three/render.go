@@ -0,0 +1,338 @@
+package three
+
+import (
+	"math"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+)
+
+// depthTolerance is how far, as a fraction of the depth, a pixel of an edge
+// may lie behind the depth buffer and still be drawn, so edges are not hidden
+// by the faces they border.
+const depthTolerance = 0.01
+
+// depthSlopePixels is how many pixels' worth of a face's depth gradient it is
+// pushed back in the depth buffer. Faces seen at a grazing angle change depth
+// quickly from pixel to pixel, so without this they would hide their own edges.
+const depthSlopePixels = 1.5
+
+// Option configures a Renderer.
+type Option func(*Renderer)
+
+// WithBackfaceCulling skips edges whose faces all point away from the
+// camera, such as the back of a closed mesh. Extra edges with no faces are
+// always drawn.
+func WithBackfaceCulling() Option {
+	return func(renderer *Renderer) {
+		renderer.cull = true
+	}
+}
+
+// WithHiddenLineRemoval hides the parts of edges behind faces, using the
+// canvas depth buffer, which is kept until Clear. The canvas must be created
+// with canvas.WithDepth(); without it no edges are hidden. Faces only hide
+// edges drawn after them, so draw nearer meshes first when several overlap.
+func WithHiddenLineRemoval() Option {
+	return func(renderer *Renderer) {
+		renderer.hidden = true
+	}
+}
+
+// Renderer draws meshes on a canvas through a camera.
+type Renderer struct {
+	camera Camera         // viewpoint and projection
+	canvas *canvas.Canvas // canvas to draw on
+	cull   bool           // whether to skip edges of faces turned away
+	hidden bool           // whether to remove hidden lines
+}
+
+// New creates a Renderer that draws on c through camera. The picture is the
+// same on canvases created with canvas.WithInvertedY().
+func New(c *canvas.Canvas, camera Camera, options ...Option) *Renderer {
+	renderer := &Renderer{camera: camera, canvas: c}
+	for _, option := range options {
+		option(renderer)
+	}
+	return renderer
+}
+
+// SetCamera changes the camera, such as between frames of an animation.
+func (renderer *Renderer) SetCamera(camera Camera) {
+	renderer.camera = camera
+}
+
+// Camera returns the camera.
+func (renderer *Renderer) Camera() Camera {
+	return renderer.camera
+}
+
+// Clear clears the canvas and its depth buffer for a new frame.
+func (renderer *Renderer) Clear() {
+	renderer.canvas.Clear()
+}
+
+// Draw draws the mesh's edges in the default color.
+func (renderer *Renderer) Draw(mesh Mesh) {
+	renderer.DrawColor(mesh, canvas.ColorDefault)
+}
+
+// DrawColor draws the mesh's edges in the given color. Colors only show on
+// canvases created with canvas.WithColor().
+func (renderer *Renderer) DrawColor(mesh Mesh, color canvas.Color) {
+	view := renderer.camera.View()
+	points := make([]Vec3, len(mesh.Vertices))
+	for index, vertex := range mesh.Vertices {
+		points[index] = view.Apply(vertex)
+	}
+
+	facing := make([]bool, len(mesh.Faces))
+	for face, vertices := range mesh.Faces {
+		polygon := renderer.polygon(points, vertices)
+		if len(polygon) < 3 {
+			continue
+		}
+		facing[face] = renderer.camera.facing(normal(polygon), polygon[0])
+		if renderer.hidden {
+			renderer.fillDepth(polygon)
+		}
+	}
+
+	for _, edge := range mesh.edges() {
+		if renderer.cull && len(edge.faces) > 0 && !anyFacing(facing, edge.faces) {
+			continue
+		}
+		renderer.edge(points[edge.start], points[edge.end], color)
+	}
+}
+
+// Project returns where a point in the world appears on the canvas, in
+// canvas coordinates, and its depth along the camera's view. It reports false
+// for points closer than the near plane.
+func (renderer *Renderer) Project(point Vec3) (x, y, depth float64, ok bool) {
+	camera := renderer.camera.View().Apply(point)
+	if -camera.Z < renderer.camera.near() {
+		return 0, 0, 0, false
+	}
+	screenX, screenY := renderer.screen(camera)
+	if renderer.canvas.InvertedY() {
+		screenY = float64(renderer.canvas.Height()) - screenY
+	}
+	return screenX, screenY, -camera.Z, true
+}
+
+// screen projects a point in camera space in front of the near plane to
+// screen coordinates, with y growing down.
+func (renderer *Renderer) screen(point Vec3) (x, y float64) {
+	scale := renderer.camera.scale(renderer.canvas.Height())
+	if renderer.camera.Projection == Perspective {
+		scale /= -point.Z
+	}
+	return float64(renderer.canvas.Width())/2 + point.X*scale, float64(renderer.canvas.Height())/2 - point.Y*scale
+}
+
+// polygon returns a face's vertices in camera space, clipped to the near plane.
+func (renderer *Renderer) polygon(points []Vec3, vertices []int) []Vec3 {
+	near := renderer.camera.near()
+	var clipped []Vec3
+	for index, vertex := range vertices {
+		next := vertices[(index+1)%len(vertices)]
+		if vertex < 0 || vertex >= len(points) || next < 0 || next >= len(points) {
+			return nil
+		}
+		current, following := points[vertex], points[next]
+		currentIn, followingIn := -current.Z >= near, -following.Z >= near
+		if currentIn {
+			clipped = append(clipped, current)
+		}
+		if currentIn != followingIn {
+			clipped = append(clipped, current.Lerp(following, (-near-current.Z)/(following.Z-current.Z)))
+		}
+	}
+	return clipped
+}
+
+// edge draws the part of a segment, in camera space, in front of the near
+// plane and on the canvas.
+func (renderer *Renderer) edge(start, end Vec3, color canvas.Color) {
+	near := renderer.camera.near()
+	startIn, endIn := -start.Z >= near, -end.Z >= near
+	switch {
+	case !startIn && !endIn:
+		return
+	case !startIn:
+		start = start.Lerp(end, (-near-start.Z)/(end.Z-start.Z))
+	case !endIn:
+		end = end.Lerp(start, (-near-end.Z)/(start.Z-end.Z))
+	}
+
+	x0, y0 := renderer.screen(start)
+	x1, y1 := renderer.screen(end)
+	low, high, ok := clipParameters(x0, y0, x1, y1, float64(renderer.canvas.Width()), float64(renderer.canvas.Height()))
+	if !ok {
+		return
+	}
+	if !renderer.hidden {
+		renderer.line(x0+(x1-x0)*low, y0+(y1-y0)*low, x0+(x1-x0)*high, y0+(y1-y0)*high, color)
+		return
+	}
+
+	// Step a pixel at a time, drawing each run of visible samples as a line
+	steps := max(1, int(math.Ceil(math.Max(math.Abs(x1-x0), math.Abs(y1-y0))*(high-low))))
+	var runX, runY, lastX, lastY float64
+	running := false
+	for step := 0; step <= steps; step++ {
+		t := low + (high-low)*float64(step)/float64(steps)
+		x, y := x0+(x1-x0)*t, y0+(y1-y0)*t
+		if renderer.visible(x, y, renderer.interpolate(-start.Z, -end.Z, t)) {
+			if !running {
+				runX, runY, running = x, y, true
+			}
+			lastX, lastY = x, y
+			continue
+		}
+		if running {
+			renderer.line(runX, runY, lastX, lastY, color)
+			running = false
+		}
+	}
+	if running {
+		renderer.line(runX, runY, lastX, lastY, color)
+	}
+}
+
+// line draws a segment in screen coordinates with draw.Line.
+func (renderer *Renderer) line(startX, startY, endX, endY float64, color canvas.Color) {
+	if renderer.canvas.InvertedY() {
+		height := float64(renderer.canvas.Height())
+		startY, endY = height-startY, height-endY
+	}
+	if color == canvas.ColorDefault {
+		draw.Line(renderer.canvas, startX, startY, endX, endY)
+	} else {
+		draw.LineColor(renderer.canvas, startX, startY, endX, endY, color)
+	}
+}
+
+// interpolate returns the depth a fraction t of the way across the screen
+// from a point at depth start to one at depth end. Under perspective, depth
+// is not linear on screen but its reciprocal is.
+func (renderer *Renderer) interpolate(start, end, t float64) float64 {
+	if renderer.camera.Projection == Perspective {
+		return 1 / (1/start + (1/end-1/start)*t)
+	}
+	return start + (end-start)*t
+}
+
+// visible reports whether something at a depth at screen point (x, y) is not
+// behind a face in the depth buffer.
+func (renderer *Renderer) visible(x, y, depth float64) bool {
+	column, row := int(math.Floor(x)), int(math.Floor(y))
+	width, height := renderer.canvas.Width(), renderer.canvas.Height()
+	if column < 0 || column >= width || row < 0 || row >= height {
+		return false
+	}
+	return depth <= renderer.canvas.Depth(float64(column), renderer.canvas.ScreenToCanvasY(row))*(1+depthTolerance)
+}
+
+// fillDepth rasterizes a convex polygon in camera space, in front of the near
+// plane, into the canvas depth buffer without turning on any pixels.
+func (renderer *Renderer) fillDepth(polygon []Vec3) {
+	type vertex struct{ x, y, depth float64 }
+	projected := make([]vertex, len(polygon))
+	for index, point := range polygon {
+		x, y := renderer.screen(point)
+		projected[index] = vertex{x, y, -point.Z}
+	}
+
+	width, height := renderer.canvas.Width(), renderer.canvas.Height()
+	for index := 1; index+1 < len(projected); index++ {
+		a, b, c := projected[0], projected[index], projected[index+1]
+		area := (b.x-a.x)*(c.y-a.y) - (b.y-a.y)*(c.x-a.x)
+		if area == 0 {
+			continue
+		}
+		// Push the face back by its depth gradient, per pixel
+		slopeX := ((b.depth-a.depth)*(c.y-a.y) - (c.depth-a.depth)*(b.y-a.y)) / area
+		slopeY := ((c.depth-a.depth)*(b.x-a.x) - (b.depth-a.depth)*(c.x-a.x)) / area
+		bias := (math.Abs(slopeX) + math.Abs(slopeY)) * depthSlopePixels
+
+		left := max(0, int(math.Floor(min(a.x, b.x, c.x))))
+		right := min(width-1, int(math.Ceil(max(a.x, b.x, c.x))))
+		top := max(0, int(math.Floor(min(a.y, b.y, c.y))))
+		bottom := min(height-1, int(math.Ceil(max(a.y, b.y, c.y))))
+
+		for row := top; row <= bottom; row++ {
+			for column := left; column <= right; column++ {
+				x, y := float64(column)+0.5, float64(row)+0.5
+				// Barycentric weights of b and c; inside when all are in 0..1
+				weightB := ((x-a.x)*(c.y-a.y) - (y-a.y)*(c.x-a.x)) / area
+				weightC := ((b.x-a.x)*(y-a.y) - (b.y-a.y)*(x-a.x)) / area
+				weightA := 1 - weightB - weightC
+				if weightA < 0 || weightB < 0 || weightC < 0 {
+					continue
+				}
+
+				var depth float64
+				if renderer.camera.Projection == Perspective {
+					depth = 1 / (weightA/a.depth + weightB/b.depth + weightC/c.depth)
+				} else {
+					depth = weightA*a.depth + weightB*b.depth + weightC*c.depth
+				}
+				renderer.canvas.WriteDepth(float64(column), renderer.canvas.ScreenToCanvasY(row), depth+bias)
+			}
+		}
+	}
+}
+
+// normal returns the normal of a polygon by Newell's method, which also works
+// for polygons that are not quite flat.
+func normal(polygon []Vec3) Vec3 {
+	var sum Vec3
+	for index, current := range polygon {
+		next := polygon[(index+1)%len(polygon)]
+		sum.X += (current.Y - next.Y) * (current.Z + next.Z)
+		sum.Y += (current.Z - next.Z) * (current.X + next.X)
+		sum.Z += (current.X - next.X) * (current.Y + next.Y)
+	}
+	return sum
+}
+
+// anyFacing reports whether any of the given faces is turned toward the camera.
+func anyFacing(facing []bool, faces []int) bool {
+	for _, face := range faces {
+		if facing[face] {
+			return true
+		}
+	}
+	return false
+}
+
+// clipParameters clips the segment from (x0, y0) to (x1, y1) to the
+// rectangle from the origin to (width, height) with the Liang-Barsky
+// algorithm, returning the parameters of the visible part.
+func clipParameters(x0, y0, x1, y1, width, height float64) (low, high float64, ok bool) {
+	low, high = 0, 1
+	deltaX, deltaY := x1-x0, y1-y0
+	for _, boundary := range [4][2]float64{
+		{-deltaX, x0},
+		{deltaX, width - x0},
+		{-deltaY, y0},
+		{deltaY, height - y0},
+	} {
+		p, q := boundary[0], boundary[1]
+		if p == 0 {
+			if q < 0 {
+				return 0, 0, false
+			}
+			continue
+		}
+		t := q / p
+		if p < 0 {
+			low = math.Max(low, t)
+		} else {
+			high = math.Min(high, t)
+		}
+	}
+	return low, high, low <= high
+}
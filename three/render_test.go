@@ -0,0 +1,160 @@
+package three
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+// flatCamera looks at the origin from +z with an orthographic projection of
+// 10 pixels per unit on a 40 pixel tall canvas.
+var flatCamera = Camera{Position: Vec3{0, 0, 5}, Projection: Orthographic, Size: 4}
+
+// square returns a square facing +z with sides of length 2, centered at depth z.
+func square(z float64) Mesh {
+	return Mesh{
+		Vertices: []Vec3{{-1, -1, z}, {1, -1, z}, {1, 1, z}, {-1, 1, z}},
+		Faces:    [][]int{{0, 1, 2, 3}},
+	}
+}
+
+func TestDrawOrthographicSquare(t *testing.T) {
+	actual := canvas.New(40, 40)
+	New(actual, flatCamera).Draw(square(0))
+
+	expected := canvas.New(40, 40)
+	draw.Rectangle(expected, 10, 10, 21, 21)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestDrawPerspective(t *testing.T) {
+	c := canvas.New(40, 40)
+	camera := Camera{Position: Vec3{0, 0, 5}, FieldOfView: math.Pi / 2}
+	renderer := New(c, camera)
+	renderer.Draw(Cube(2))
+	stippletest.PrintVisual(t, "perspective cube", c)
+
+	// The front face, 4 units away, is 20 pixels per unit / 4 across; the
+	// back face, 6 units away, is smaller
+	tests := []struct {
+		point    Vec3
+		expectX  float64
+		expectY  float64
+		expected float64
+	}{
+		{Vec3{1, 1, 1}, 25, 15, 4},
+		{Vec3{1, 1, -1}, 20 + 20.0/6, 20 - 20.0/6, 6},
+		{Vec3{}, 20, 20, 5},
+	}
+	for _, testCase := range tests {
+		x, y, depth, ok := renderer.Project(testCase.point)
+		if !ok || math.Abs(x-testCase.expectX) > 1e-9 || math.Abs(y-testCase.expectY) > 1e-9 || math.Abs(depth-testCase.expected) > 1e-9 {
+			t.Errorf("Project(%v) = (%v, %v, %v, %v), want (%v, %v, %v, true)",
+				testCase.point, x, y, depth, ok, testCase.expectX, testCase.expectY, testCase.expected)
+		}
+	}
+	if !c.Get(25, 15) || !c.Get(15, 25) {
+		t.Error("front face corners are not drawn")
+	}
+	if !c.Get(23, 16) {
+		t.Error("edge from front to back corner is not drawn")
+	}
+}
+
+func TestBackfaceCulling(t *testing.T) {
+	camera := Camera{Position: Vec3{0, 0, 5}, FieldOfView: math.Pi / 2}
+
+	// Seen face-on, a culled cube shows only its front face
+	actual := canvas.New(40, 40)
+	New(actual, camera, WithBackfaceCulling()).Draw(Cube(2))
+	expected := canvas.New(40, 40)
+	New(expected, camera).Draw(square(1))
+	stippletest.AssertEqual(t, expected, actual)
+
+	// A face turned away is culled entirely
+	away := canvas.New(40, 40)
+	New(away, camera, WithBackfaceCulling()).Draw(square(0).Transform(RotationY(math.Pi)))
+	stippletest.AssertEqual(t, canvas.New(40, 40), away)
+}
+
+func TestHiddenLineRemoval(t *testing.T) {
+	c := canvas.New(40, 40, canvas.WithDepth())
+	renderer := New(c, flatCamera, WithHiddenLineRemoval())
+	renderer.Draw(square(1))
+	// A line behind the square, from one side of the canvas to the other
+	behind := Mesh{Vertices: []Vec3{{-2, 0, -1}, {2, 0, -1}}, Edges: [][2]int{{0, 1}}}
+	renderer.Draw(behind)
+	stippletest.PrintVisual(t, "hidden line", c)
+
+	expected := canvas.New(40, 40)
+	draw.Rectangle(expected, 10, 10, 21, 21)
+	draw.Line(expected, 0, 20, 9, 20)
+	draw.Line(expected, 31, 20, 39, 20)
+	stippletest.AssertEqual(t, expected, c)
+
+	// After Clear, the square no longer hides anything
+	renderer.Clear()
+	renderer.Draw(behind)
+	expected.Clear()
+	draw.Line(expected, 0, 20, 39, 20)
+	stippletest.AssertEqual(t, expected, c)
+}
+
+func TestHiddenLineRemovalKeepsVisibleEdges(t *testing.T) {
+	// Every edge of a cube seen face-on is in front of or on the nearest
+	// face, except the back face's, which the front face hides
+	camera := Camera{Position: Vec3{0, 0, 5}, FieldOfView: math.Pi / 2}
+	actual := canvas.New(40, 40, canvas.WithDepth())
+	New(actual, camera, WithHiddenLineRemoval()).Draw(Cube(2))
+	stippletest.PrintVisual(t, "hidden-line cube", actual)
+
+	expected := canvas.New(40, 40)
+	New(expected, camera).Draw(square(1))
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestNearPlaneClipping(t *testing.T) {
+	c := canvas.New(40, 40)
+	camera := Camera{Position: Vec3{0, 0, 5}, FieldOfView: math.Pi / 2, Near: 1}
+	renderer := New(c, camera)
+
+	// Entirely behind the near plane: nothing is drawn
+	renderer.Draw(Mesh{Vertices: []Vec3{{-1, 0, 4.5}, {1, 0, 6}}, Edges: [][2]int{{0, 1}}})
+	stippletest.AssertEqual(t, canvas.New(40, 40), c)
+
+	// Crossing it: only the part in front is drawn, from where it crosses
+	renderer.Draw(Mesh{Vertices: []Vec3{{0, -1, 8}, {0, -1, 0}}, Edges: [][2]int{{0, 1}}})
+	expected := canvas.New(40, 40)
+	draw.Line(expected, 20, 40, 20, 24)
+	stippletest.AssertEqual(t, expected, c)
+
+	if _, _, _, ok := renderer.Project(Vec3{0, 0, 4.5}); ok {
+		t.Error("Project() of a point behind the near plane reported true")
+	}
+}
+
+func TestDrawColor(t *testing.T) {
+	c := canvas.New(40, 40, canvas.WithColor())
+	New(c, flatCamera).DrawColor(square(0), canvas.ColorGreen)
+	if color := c.GetColor(20, 10); color != canvas.ColorGreen {
+		t.Errorf("GetColor(20, 10) = %d, want %d", color, canvas.ColorGreen)
+	}
+}
+
+func TestDrawInvertedY(t *testing.T) {
+	camera := Camera{Position: Vec3{3, 2, 5}, FieldOfView: math.Pi / 2}
+	mesh := Cube(2).Transform(RotationY(0.3))
+
+	normal := canvas.New(40, 40, canvas.WithDepth())
+	New(normal, camera, WithHiddenLineRemoval()).Draw(mesh)
+	inverted := canvas.New(40, 40, canvas.WithInvertedY(), canvas.WithDepth())
+	New(inverted, camera, WithHiddenLineRemoval()).Draw(mesh)
+	stippletest.PrintVisual(t, "rotated cube", normal)
+
+	if normal.Frame() != inverted.Frame() {
+		t.Errorf("inverted frame:\n%s\nwant:\n%s", inverted.Frame(), normal.Frame())
+	}
+}
@@ -0,0 +1,70 @@
+// Package three draws 3D wireframes on a canvas.
+//
+// Meshes are lists of vertices with faces and edges, transformed with 4x4
+// matrices and viewed through a perspective or orthographic Camera. A
+// Renderer projects each edge onto the canvas and draws it with draw.Line,
+// clipping edges that cross the camera's near plane. It can cull edges that
+// only border faces turned away from the camera, and remove hidden lines
+// with a per-pixel depth buffer. LoadOBJ reads meshes from Wavefront OBJ
+// files.
+//
+// Coordinates are right-handed: with the default camera up vector, x points
+// right, y points up, and the camera looks along the direction from its
+// Position to its Target. The faces of a mesh list their vertices
+// counterclockwise as seen from the front.
+package three
+
+import "math"
+
+// Vec3 is a point or direction in 3D space.
+type Vec3 struct {
+	X, Y, Z float64
+}
+
+// Add returns v + other.
+func (v Vec3) Add(other Vec3) Vec3 {
+	return Vec3{v.X + other.X, v.Y + other.Y, v.Z + other.Z}
+}
+
+// Sub returns v - other.
+func (v Vec3) Sub(other Vec3) Vec3 {
+	return Vec3{v.X - other.X, v.Y - other.Y, v.Z - other.Z}
+}
+
+// Scale returns v multiplied by factor.
+func (v Vec3) Scale(factor float64) Vec3 {
+	return Vec3{v.X * factor, v.Y * factor, v.Z * factor}
+}
+
+// Dot returns the dot product of v and other.
+func (v Vec3) Dot(other Vec3) float64 {
+	return v.X*other.X + v.Y*other.Y + v.Z*other.Z
+}
+
+// Cross returns the cross product of v and other.
+func (v Vec3) Cross(other Vec3) Vec3 {
+	return Vec3{
+		v.Y*other.Z - v.Z*other.Y,
+		v.Z*other.X - v.X*other.Z,
+		v.X*other.Y - v.Y*other.X,
+	}
+}
+
+// Length returns the length of v.
+func (v Vec3) Length() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+// Normalize returns v scaled to length 1, or the zero vector if v is zero.
+func (v Vec3) Normalize() Vec3 {
+	length := v.Length()
+	if length == 0 {
+		return Vec3{}
+	}
+	return v.Scale(1 / length)
+}
+
+// Lerp returns the point a fraction t of the way from v to other.
+func (v Vec3) Lerp(other Vec3, t float64) Vec3 {
+	return v.Add(other.Sub(v).Scale(t))
+}
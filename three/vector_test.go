@@ -0,0 +1,39 @@
+package three
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVec3Arithmetic(t *testing.T) {
+	a, b := Vec3{1, 2, 3}, Vec3{4, -5, 6}
+	tests := []struct {
+		name     string
+		actual   Vec3
+		expected Vec3
+	}{
+		{"Add", a.Add(b), Vec3{5, -3, 9}},
+		{"Sub", a.Sub(b), Vec3{-3, 7, -3}},
+		{"Scale", a.Scale(2), Vec3{2, 4, 6}},
+		{"Cross", Vec3{1, 0, 0}.Cross(Vec3{0, 1, 0}), Vec3{0, 0, 1}},
+		{"Cross anticommutes", Vec3{0, 1, 0}.Cross(Vec3{1, 0, 0}), Vec3{0, 0, -1}},
+		{"Normalize", Vec3{3, 0, 4}.Normalize(), Vec3{0.6, 0, 0.8}},
+		{"Normalize zero", Vec3{}.Normalize(), Vec3{}},
+		{"Lerp", a.Lerp(b, 0.5), Vec3{2.5, -1.5, 4.5}},
+	}
+
+	for _, testCase := range tests {
+		if !closeVec(testCase.actual, testCase.expected) {
+			t.Errorf("%s = %v, want %v", testCase.name, testCase.actual, testCase.expected)
+		}
+	}
+}
+
+func TestVec3DotAndLength(t *testing.T) {
+	if dot := (Vec3{1, 2, 3}).Dot(Vec3{4, -5, 6}); dot != 12 {
+		t.Errorf("Dot() = %v, want 12", dot)
+	}
+	if length := (Vec3{2, 3, 6}).Length(); math.Abs(length-7) > 1e-12 {
+		t.Errorf("Length() = %v, want 7", length)
+	}
+}
@@ -0,0 +1,75 @@
+// Package tilemap draws 2D tile maps, such as maze minimaps, into a viewport
+// of a canvas.
+//
+// A map is a grid of tile values, indexed [row][column] with row 0 at the top,
+// the same layout as raycast.Map, so a raycast map can be drawn directly. Each
+// tile value is drawn with a Tile: a small pixel pattern scaled to the zoom
+// level with nearest-neighbour sampling. Map positions are measured in tiles,
+// so (1.5, 2.5) is the center of the tile in column 1, row 2.
+package tilemap
+
+import (
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/sprite"
+)
+
+// Zoom is the size of a tile on the canvas: the number of pixels per tile
+// along each axis, usually 1, 2, or 4, or ZoomCell.
+type Zoom int
+
+// ZoomCell draws each tile as one terminal cell: 2x4 pixels, or 1x2 pixels on
+// a WithHalfBlock canvas.
+const ZoomCell Zoom = -1
+
+// size returns the pixel dimensions of a tile on c. Zoom levels below 1,
+// other than ZoomCell, draw one pixel per tile.
+func (zoom Zoom) size(c *canvas.Canvas) (width, height int) {
+	switch {
+	case zoom == ZoomCell && c.HalfBlock():
+		return 1, 2
+	case zoom == ZoomCell:
+		return 2, 4
+	case zoom < 1:
+		return 1, 1
+	}
+	return int(zoom), int(zoom)
+}
+
+// Tile describes how one kind of tile is drawn.
+type Tile struct {
+	Pattern sprite.Frame // pixels of the tile, stretched to the tile size
+	Color   canvas.Color // color of lit pattern pixels that have no color of their own
+}
+
+// Solid returns a tile with every pixel lit in color.
+func Solid(color canvas.Color) Tile {
+	return Tile{
+		Pattern: sprite.Frame{Pixels: [][]sprite.Pixel{{{Mode: sprite.On}}}},
+		Color:   color,
+	}
+}
+
+// ParseTile returns a tile whose pattern is pixel art in the sprite text
+// format, such as "#.\n.#" for a checkerboard. Lit pixels without a color
+// letter are drawn in color.
+func ParseTile(pattern string, color canvas.Color) (Tile, error) {
+	parsed, err := sprite.Parse(pattern)
+	if err != nil {
+		return Tile{}, err
+	}
+	return Tile{Pattern: parsed.Frames[0], Color: color}, nil
+}
+
+// sample returns whether the pattern pixel at the fractional position
+// (offsetX, offsetY) within the tile, each in [0, 1), is lit, and its color.
+func (tile Tile) sample(offsetX, offsetY float64) (bool, canvas.Color) {
+	width, height := tile.Pattern.Width(), tile.Pattern.Height()
+	pixel := tile.Pattern.At(int(offsetX*float64(width)), int(offsetY*float64(height)))
+	if pixel.Mode != sprite.On {
+		return false, canvas.ColorDefault
+	}
+	if pixel.Color != canvas.ColorDefault {
+		return true, pixel.Color
+	}
+	return true, tile.Color
+}
@@ -0,0 +1,70 @@
+package tilemap
+
+import (
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+func TestZoomSize(t *testing.T) {
+	braille := canvas.New(8, 8)
+	halfBlock := canvas.New(8, 8, canvas.WithHalfBlock())
+
+	tests := []struct {
+		name          string
+		zoom          Zoom
+		c             *canvas.Canvas
+		width, height int
+	}{
+		{"default", 0, braille, 1, 1},
+		{"one", 1, braille, 1, 1},
+		{"two", 2, braille, 2, 2},
+		{"four", 4, braille, 4, 4},
+		{"cell", ZoomCell, braille, 2, 4},
+		{"half-block cell", ZoomCell, halfBlock, 1, 2},
+	}
+
+	for _, testCase := range tests {
+		width, height := testCase.zoom.size(testCase.c)
+		if width != testCase.width || height != testCase.height {
+			t.Errorf("%s: size() = %d, %d, want %d, %d", testCase.name, width, height, testCase.width, testCase.height)
+		}
+	}
+}
+
+func TestSolid(t *testing.T) {
+	tile := Solid(canvas.ColorRed)
+	for _, offset := range []float64{0, 0.3, 0.99} {
+		if lit, color := tile.sample(offset, offset); !lit || color != canvas.ColorRed {
+			t.Errorf("sample(%v, %v) = %v, %v, want true, %v", offset, offset, lit, color, canvas.ColorRed)
+		}
+	}
+}
+
+func TestParseTile(t *testing.T) {
+	tile, err := ParseTile("#.\n.g", canvas.ColorBlue)
+	if err != nil {
+		t.Fatalf("ParseTile() error = %v", err)
+	}
+
+	tests := []struct {
+		x, y  float64
+		lit   bool
+		color canvas.Color
+	}{
+		{0.2, 0.2, true, canvas.ColorBlue},
+		{0.7, 0.2, false, canvas.ColorDefault},
+		{0.2, 0.7, false, canvas.ColorDefault},
+		{0.7, 0.7, true, canvas.ColorGreen}, // the pattern's own color wins
+	}
+	for _, testCase := range tests {
+		lit, color := tile.sample(testCase.x, testCase.y)
+		if lit != testCase.lit || color != testCase.color {
+			t.Errorf("sample(%v, %v) = %v, %v, want %v, %v", testCase.x, testCase.y, lit, color, testCase.lit, testCase.color)
+		}
+	}
+
+	if _, err := ParseTile("#x", canvas.ColorDefault); err == nil {
+		t.Error("ParseTile(\"#x\") error = nil, want error")
+	}
+}
@@ -0,0 +1,189 @@
+package tilemap
+
+import (
+	"math"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+)
+
+// Marker is a position and heading drawn over the map, such as the player.
+type Marker struct {
+	X     float64      // map position in tiles
+	Y     float64      // map position in tiles
+	Angle float64      // heading in radians: 0 points along +x, positive turns toward +y, as in raycast
+	Color canvas.Color // color of the marker
+}
+
+// View is a scrollable, zoomable window onto a tile map.
+type View struct {
+	Map      [][]int      // tile values [row][column], row 0 at the top
+	Tiles    map[int]Tile // how each tile value is drawn; other values are left blank
+	Viewport canvas.Rect  // canvas area to draw into, the whole canvas when empty
+	Zoom     Zoom         // tile size, 1 pixel per tile when 0
+	X        float64      // map position shown at the center of the viewport
+	Y        float64      // map position shown at the center of the viewport
+	Clamp    bool         // keep the viewport inside the map instead of centering on (X, Y) near edges
+	Markers  []Marker     // markers drawn over the tiles, clipped to the viewport
+}
+
+// Render draws the map into the viewport, replacing every pixel there: pixels
+// of blank tiles, unlit pattern pixels, and positions outside the map are
+// turned off. Markers are drawn last as a dot with a heading arrow.
+// With ZoomCell the view scrolls by whole tiles, so each tile fills one
+// terminal cell when the viewport is aligned to cells.
+func (view View) Render(c *canvas.Canvas) {
+	viewport := view.viewport(c)
+	if viewport.Empty() {
+		return
+	}
+	left, top, width, height := view.screenRect(c, viewport)
+	tileWidth, tileHeight := view.Zoom.size(c)
+	originX, originY := view.origin(width, height, tileWidth, tileHeight)
+
+	for row := range height {
+		mapY := (float64(originY+row) + 0.5) / float64(tileHeight)
+		y := c.ScreenToCanvasY(top + row)
+		for column := range width {
+			mapX := (float64(originX+column) + 0.5) / float64(tileWidth)
+			x := float64(left + column)
+			lit, color := view.sample(mapX, mapY)
+			switch {
+			case !lit:
+				c.Unset(x, y)
+			case color == canvas.ColorDefault:
+				c.Set(x, y)
+			default:
+				c.SetColor(x, y, color)
+			}
+		}
+	}
+
+	if len(view.Markers) == 0 {
+		return
+	}
+	saved, clipped := c.Clip()
+	clip := viewport
+	if clipped {
+		clip = clip.Intersect(saved)
+	}
+	c.SetClip(clip)
+	for _, marker := range view.Markers {
+		view.drawMarker(c, marker, left, top, originX, originY, tileWidth, tileHeight)
+	}
+	if clipped {
+		c.SetClip(saved)
+	} else {
+		c.ClearClip()
+	}
+}
+
+// ToCanvas returns the canvas coordinates where the map position (x, y)
+// appears when the view is rendered onto c, for drawing overlays.
+func (view View) ToCanvas(c *canvas.Canvas, x, y float64) (float64, float64) {
+	left, top, width, height := view.screenRect(c, view.viewport(c))
+	tileWidth, tileHeight := view.Zoom.size(c)
+	originX, originY := view.origin(width, height, tileWidth, tileHeight)
+	screenX := float64(left) + x*float64(tileWidth) - float64(originX)
+	screenY := float64(top) + y*float64(tileHeight) - float64(originY)
+	if c.InvertedY() {
+		return screenX, float64(c.Height()) - screenY
+	}
+	return screenX, screenY
+}
+
+// drawMarker draws a marker as a dot with an arrow along its heading, sized to
+// the tiles.
+func (view View) drawMarker(c *canvas.Canvas, marker Marker, left, top, originX, originY, tileWidth, tileHeight int) {
+	centerX := float64(left) + marker.X*float64(tileWidth) - float64(originX)
+	centerY := float64(top) + marker.Y*float64(tileHeight) - float64(originY)
+	length := math.Max(3, 1.5*float64(max(tileWidth, tileHeight)))
+
+	// Screen rows grow downward like map rows, so the heading needs no flip
+	tipX := centerX + length*math.Cos(marker.Angle)
+	tipY := centerY + length*math.Sin(marker.Angle)
+	view.markerLine(c, marker.Color, centerX, centerY, tipX, tipY)
+	for _, turn := range []float64{5 * math.Pi / 6, -5 * math.Pi / 6} {
+		barbX := tipX + length/2*math.Cos(marker.Angle+turn)
+		barbY := tipY + length/2*math.Sin(marker.Angle+turn)
+		view.markerLine(c, marker.Color, tipX, tipY, barbX, barbY)
+	}
+}
+
+// markerLine draws a line between two screen positions.
+func (view View) markerLine(c *canvas.Canvas, color canvas.Color, startX, startY, endX, endY float64) {
+	x0, y0 := math.Floor(startX), c.ScreenToCanvasY(int(math.Floor(startY)))
+	x1, y1 := math.Floor(endX), c.ScreenToCanvasY(int(math.Floor(endY)))
+	if color == canvas.ColorDefault {
+		draw.Line(c, x0, y0, x1, y1)
+		return
+	}
+	draw.LineColor(c, x0, y0, x1, y1, color)
+}
+
+// sample returns whether the map pixel at the map position (x, y) is lit, and
+// its color.
+func (view View) sample(x, y float64) (bool, canvas.Color) {
+	row, column := int(math.Floor(y)), int(math.Floor(x))
+	if row < 0 || row >= len(view.Map) || column < 0 || column >= len(view.Map[row]) {
+		return false, canvas.ColorDefault
+	}
+	tile, ok := view.Tiles[view.Map[row][column]]
+	if !ok {
+		return false, canvas.ColorDefault
+	}
+	return tile.sample(x-float64(column), y-float64(row))
+}
+
+// viewport returns the viewport limited to the canvas, with the default applied.
+func (view View) viewport(c *canvas.Canvas) canvas.Rect {
+	bounds := canvas.Rect{Width: float64(c.Width()), Height: float64(c.Height())}
+	if view.Viewport.Empty() {
+		return bounds
+	}
+	return view.Viewport.Intersect(bounds)
+}
+
+// screenRect returns the pixels covered by a viewport in screen coordinates,
+// counted from the top-left corner of the display.
+func (view View) screenRect(c *canvas.Canvas, viewport canvas.Rect) (left, top, width, height int) {
+	left = int(math.Ceil(viewport.X))
+	width = int(math.Ceil(viewport.X+viewport.Width)) - left
+	bottom := int(math.Ceil(viewport.Y + viewport.Height))
+	height = bottom - int(math.Ceil(viewport.Y))
+	top = int(math.Ceil(viewport.Y))
+	if c.InvertedY() {
+		top = c.Height() - bottom
+	}
+	return left, top, width, height
+}
+
+// origin returns the map position at the top-left corner of the viewport, in
+// zoomed pixels from the map's top-left corner, so tile edges fall between
+// pixels.
+func (view View) origin(width, height, tileWidth, tileHeight int) (originX, originY int) {
+	columns := 0
+	for _, row := range view.Map {
+		columns = max(columns, len(row))
+	}
+	return view.originAxis(view.X, columns, width, tileWidth),
+		view.originAxis(view.Y, len(view.Map), height, tileHeight)
+}
+
+// originAxis returns the origin along one axis for a view centered on center,
+// showing pixels of a map with size tiles at tileSize pixels per tile.
+func (view View) originAxis(center float64, size, pixels, tileSize int) int {
+	visible := float64(pixels) / float64(tileSize)
+	origin := center - visible/2
+	if view.Clamp {
+		if visible >= float64(size) {
+			origin = (float64(size) - visible) / 2
+		} else {
+			origin = math.Max(0, math.Min(origin, float64(size)-visible))
+		}
+	}
+	if view.Zoom == ZoomCell {
+		return int(math.Round(origin)) * tileSize
+	}
+	return int(math.Round(origin * float64(tileSize)))
+}
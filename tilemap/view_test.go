@@ -0,0 +1,327 @@
+package tilemap
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+// testMap converts rows of '#' walls and '.' floors into tile values 1 and 0.
+func testMap(rows ...string) [][]int {
+	grid := make([][]int, len(rows))
+	for row, text := range rows {
+		for _, character := range text {
+			value := 0
+			if character == '#' {
+				value = 1
+			}
+			grid[row] = append(grid[row], value)
+		}
+	}
+	return grid
+}
+
+// expectedMap returns a canvas with a zoom x zoom block lit for every '#' in
+// rows, offset by (left, top) pixels.
+func expectedMap(width, height, zoom, left, top int, rows ...string) *canvas.Canvas {
+	c := canvas.New(width, height)
+	for row, text := range rows {
+		for column, character := range text {
+			if character == '#' {
+				draw.RectangleFilled(c, float64(left+column*zoom), float64(top+row*zoom), float64(zoom), float64(zoom))
+			}
+		}
+	}
+	return c
+}
+
+var (
+	wallTiles = map[int]Tile{1: Solid(canvas.ColorDefault)}
+	room      = []string{
+		"####",
+		"#..#",
+		"#.##",
+		"####",
+	}
+)
+
+func TestRenderZoomLevels(t *testing.T) {
+	for _, zoom := range []Zoom{1, 2, 4} {
+		t.Run(fmt.Sprintf("zoom %d", zoom), func(t *testing.T) {
+			size := 4 * int(zoom)
+			actual := canvas.New(size, size)
+			view := View{Map: testMap(room...), Tiles: wallTiles, Zoom: zoom, X: 2, Y: 2}
+			view.Render(actual)
+
+			stippletest.AssertEqual(t, expectedMap(size, size, int(zoom), 0, 0, room...), actual)
+		})
+	}
+}
+
+func TestRenderScrolls(t *testing.T) {
+	// Centering on (3, 2) shifts the map one tile left
+	actual := canvas.New(4, 4)
+	view := View{Map: testMap(room...), Tiles: wallTiles, X: 3, Y: 2}
+	view.Render(actual)
+
+	stippletest.AssertEqual(t, expectedMap(4, 4, 1, -1, 0, room...), actual)
+
+	// Half a tile at zoom 2 scrolls by one pixel
+	actual = canvas.New(8, 8)
+	view = View{Map: testMap(room...), Tiles: wallTiles, Zoom: 2, X: 2.5, Y: 2}
+	view.Render(actual)
+
+	stippletest.AssertEqual(t, expectedMap(8, 8, 2, -1, 0, room...), actual)
+}
+
+func TestRenderClamp(t *testing.T) {
+	grid := testMap(
+		"#.......",
+		"........",
+		"........",
+		".......#",
+	)
+
+	// A 4x4 viewport centered on the top-left corner would show blank space
+	actual := canvas.New(4, 4)
+	view := View{Map: grid, Tiles: wallTiles, X: 0, Y: 0, Clamp: true}
+	view.Render(actual)
+	expected := canvas.New(4, 4)
+	expected.Set(0, 0)
+	stippletest.AssertEqual(t, expected, actual)
+
+	view.X, view.Y = 8, 4
+	actual = canvas.New(4, 4)
+	view.Render(actual)
+	expected = canvas.New(4, 4)
+	expected.Set(3, 3)
+	stippletest.AssertEqual(t, expected, actual)
+
+	// A map smaller than the viewport is centered
+	actual = canvas.New(8, 8)
+	view = View{Map: testMap("#"), Tiles: wallTiles, X: 0, Y: 0, Clamp: true}
+	view.Render(actual)
+	expected = canvas.New(8, 8)
+	expected.Set(4, 4)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestRenderViewport(t *testing.T) {
+	actual := canvas.New(16, 12)
+	draw.RectangleFilled(actual, 0, 0, 16, 12)
+	view := View{
+		Map:      testMap(room...),
+		Tiles:    wallTiles,
+		Viewport: canvas.Rect{X: 8, Y: 4, Width: 4, Height: 4},
+		X:        2,
+		Y:        2,
+	}
+	view.Render(actual)
+
+	// The viewport is replaced; everything else is untouched
+	expected := canvas.New(16, 12)
+	draw.RectangleFilled(expected, 0, 0, 16, 12)
+	expected.Unset(9, 5)
+	expected.Unset(10, 5)
+	expected.Unset(9, 6)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestRenderZoomCell(t *testing.T) {
+	c := canvas.New(8, 8)
+	view := View{Map: testMap("#.#.", ".#.#"), Tiles: wallTiles, Zoom: ZoomCell, X: 2, Y: 1}
+	view.Render(c)
+
+	expected := "⣿⠀⣿⠀\n⠀⣿⠀⣿"
+	if frame := c.Frame(); frame != expected {
+		t.Errorf("Frame() = %q, want %q", frame, expected)
+	}
+
+	// With half blocks each tile is one character
+	halfBlock := canvas.New(4, 4, canvas.WithHalfBlock())
+	view.Render(halfBlock)
+	expected = "█ █ \n █ █"
+	if frame := halfBlock.Frame(); frame != expected {
+		t.Errorf("half-block Frame() = %q, want %q", frame, expected)
+	}
+}
+
+func TestRenderPatternsAndColors(t *testing.T) {
+	door, err := ParseTile(`
+		....
+		.##.
+		.##.
+		....
+	`, canvas.ColorYellow)
+	if err != nil {
+		t.Fatalf("ParseTile() error = %v", err)
+	}
+
+	c := canvas.New(8, 4, canvas.WithHalfBlock(), canvas.WithColor())
+	view := View{
+		Map:   [][]int{{1, 2}},
+		Tiles: map[int]Tile{1: Solid(canvas.ColorRed), 2: door},
+		Zoom:  4,
+		X:     1,
+		Y:     0.5,
+	}
+	view.Render(c)
+
+	expected := canvas.New(8, 4, canvas.WithHalfBlock())
+	draw.RectangleFilled(expected, 0, 0, 4, 4)
+	draw.RectangleFilled(expected, 5, 1, 2, 2)
+	if points := stippletest.Diff(expected, c); len(points) > 0 {
+		t.Errorf("pixels differ at %v\n%s", points, stippletest.Overlay(expected, c))
+	}
+	if color := c.GetColor(0, 0); color != canvas.ColorRed {
+		t.Errorf("GetColor(0, 0) = %v, want %v", color, canvas.ColorRed)
+	}
+	if color := c.GetColor(5, 1); color != canvas.ColorYellow {
+		t.Errorf("GetColor(5, 1) = %v, want %v", color, canvas.ColorYellow)
+	}
+
+	// At zoom 1 the door samples the middle of its pattern
+	small := canvas.New(2, 4)
+	view.Zoom, view.Y = 1, 2
+	view.Render(small)
+	if !small.Get(1, 0) {
+		t.Error("door pixel (1, 0) not set at zoom 1")
+	}
+}
+
+func TestRenderMarker(t *testing.T) {
+	tests := []struct {
+		name  string
+		angle float64
+		tipX  float64
+		tipY  float64
+	}{
+		{"east", 0, 13, 8},
+		{"south", math.Pi / 2, 8, 13},
+		{"west", math.Pi, 3, 8},
+		{"north", -math.Pi / 2, 8, 3},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Zoom 4 makes the arrow 6 pixels long from the marker at pixel (8, 8)
+			c := canvas.New(16, 16)
+			view := View{
+				Map:     testMap(room...),
+				Zoom:    4,
+				X:       2,
+				Y:       2,
+				Markers: []Marker{{X: 2.1, Y: 2.1, Angle: testCase.angle}},
+			}
+			view.Render(c)
+
+			if !c.Get(8, 8) {
+				t.Error("marker center (8, 8) not set")
+			}
+			if !c.Get(testCase.tipX, testCase.tipY) {
+				t.Errorf("arrow tip (%v, %v) not set", testCase.tipX, testCase.tipY)
+			}
+			stippletest.PrintVisual(t, "TestRenderMarker "+testCase.name, c)
+		})
+	}
+}
+
+func TestRenderMarkerClippedToViewport(t *testing.T) {
+	c := canvas.New(16, 8)
+	c.SetClip(canvas.Rect{Width: 12, Height: 8})
+	view := View{
+		Map:      testMap("...", "...", "..."),
+		Viewport: canvas.Rect{Width: 6, Height: 6},
+		Zoom:     2,
+		X:        1.5,
+		Y:        1.5,
+		Markers:  []Marker{{X: 2.9, Y: 1.5, Angle: 0}},
+	}
+	view.Render(c)
+
+	for x := 6.0; x < 16; x++ {
+		if c.Get(x, 3) {
+			t.Errorf("pixel (%v, 3) outside the viewport is set", x)
+		}
+	}
+	if !c.Get(5, 3) {
+		t.Error("marker pixel (5, 3) inside the viewport not set")
+	}
+
+	// The canvas clip is restored afterwards
+	if rect, ok := c.Clip(); !ok || rect != (canvas.Rect{Width: 12, Height: 8}) {
+		t.Errorf("Clip() = %v, %v, want the original clip", rect, ok)
+	}
+}
+
+func TestRenderInvertedY(t *testing.T) {
+	grid := testMap(
+		"#####",
+		"#...#",
+		"#.#.#",
+		"##..#",
+		"#####",
+	)
+
+	normal := canvas.New(24, 24)
+	inverted := canvas.New(24, 24, canvas.WithInvertedY())
+	viewports := []canvas.Rect{
+		{X: 2, Y: 4, Width: 20, Height: 16},
+		{X: 2, Y: 24 - 4 - 16, Width: 20, Height: 16}, // the same pixels on screen
+	}
+	for index, c := range []*canvas.Canvas{normal, inverted} {
+		view := View{
+			Map:      grid,
+			Tiles:    wallTiles,
+			Viewport: viewports[index],
+			Zoom:     4,
+			X:        2.2,
+			Y:        2.7,
+			Markers:  []Marker{{X: 1.5, Y: 1.5, Angle: math.Pi / 4}},
+		}
+		view.Render(c)
+	}
+
+	if inverted.Frame() != normal.Frame() {
+		t.Errorf("inverted frame:\n%s\nwant:\n%s", inverted.Frame(), normal.Frame())
+	}
+
+	stippletest.PrintVisual(t, "TestRenderInvertedY", normal)
+}
+
+func TestToCanvas(t *testing.T) {
+	view := View{Map: testMap(room...), Zoom: 2, X: 2, Y: 2, Viewport: canvas.Rect{X: 4, Y: 0, Width: 8, Height: 8}}
+
+	tests := []struct {
+		name     string
+		c        *canvas.Canvas
+		x, y     float64
+		expected [2]float64
+	}{
+		{"origin", canvas.New(16, 8), 0, 0, [2]float64{4, 0}},
+		{"tile center", canvas.New(16, 8), 1.5, 2.5, [2]float64{7, 5}},
+		{"inverted", canvas.New(16, 8, canvas.WithInvertedY()), 1.5, 2.5, [2]float64{7, 3}},
+	}
+
+	for _, testCase := range tests {
+		x, y := view.ToCanvas(testCase.c, testCase.x, testCase.y)
+		if x != testCase.expected[0] || y != testCase.expected[1] {
+			t.Errorf("%s: ToCanvas(%v, %v) = %v, %v, want %v", testCase.name, testCase.x, testCase.y, x, y, testCase.expected)
+		}
+	}
+}
+
+func TestRenderEmptyViewport(t *testing.T) {
+	c := canvas.New(8, 8)
+	draw.RectangleFilled(c, 0, 0, 8, 8)
+	view := View{Map: testMap(room...), Viewport: canvas.Rect{X: 20, Y: 20, Width: 4, Height: 4}}
+	view.Render(c)
+
+	expected := canvas.New(8, 8)
+	draw.RectangleFilled(expected, 0, 0, 8, 8)
+	stippletest.AssertEqual(t, expected, c)
+}
@@ -0,0 +1,167 @@
+// Package turtle provides turtle graphics on a canvas.
+//
+// A Turtle has a position, a heading, and a pen. Moving it with the pen down
+// draws a line through draw.Line. Headings are in degrees: 0 points right,
+// 90 points up on screen, and Left turns counterclockwise as seen on screen.
+// This holds on both Y-down canvases and canvases created with
+// canvas.WithInvertedY(), so the same commands draw the same picture.
+package turtle
+
+import (
+	"math"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+)
+
+// state is everything PushState saves.
+type state struct {
+	color   canvas.Color // pen color
+	heading float64      // direction in degrees, counterclockwise from right
+	penDown bool         // whether moving draws
+	x       float64      // position in canvas coordinates
+	y       float64      // position in canvas coordinates
+}
+
+// Turtle draws on a canvas by moving and turning.
+type Turtle struct {
+	canvas  *canvas.Canvas // canvas to draw on
+	current state          // position, heading, and pen
+	saved   []state        // states saved by PushState, most recent last
+}
+
+// New creates a Turtle at the center of the canvas, heading right, with the
+// pen down in the default color.
+func New(c *canvas.Canvas) *Turtle {
+	return &Turtle{
+		canvas: c,
+		current: state{
+			penDown: true,
+			x:       float64(c.Width()) / 2,
+			y:       float64(c.Height()) / 2,
+		},
+	}
+}
+
+// Forward moves the turtle distance pixels along its heading, drawing a line
+// when the pen is down. A negative distance moves it backward.
+func (turtle *Turtle) Forward(distance float64) {
+	directionX, directionY := direction(turtle.current.heading)
+	if !turtle.canvas.InvertedY() {
+		// Up on screen is toward smaller y on a Y-down canvas
+		directionY = -directionY
+	}
+	turtle.Goto(settle(turtle.current.x+directionX*distance), settle(turtle.current.y+directionY*distance))
+}
+
+// Back moves the turtle distance pixels away from its heading, without
+// turning, drawing a line when the pen is down.
+func (turtle *Turtle) Back(distance float64) {
+	turtle.Forward(-distance)
+}
+
+// Left turns the turtle counterclockwise by angle degrees.
+func (turtle *Turtle) Left(angle float64) {
+	turtle.SetHeading(turtle.current.heading + angle)
+}
+
+// Right turns the turtle clockwise by angle degrees.
+func (turtle *Turtle) Right(angle float64) {
+	turtle.SetHeading(turtle.current.heading - angle)
+}
+
+// SetHeading points the turtle in a direction in degrees: 0 is right, 90 is
+// up, 180 is left, and 270 is down on screen. The heading is kept in [0, 360).
+func (turtle *Turtle) SetHeading(heading float64) {
+	heading = math.Mod(heading, 360)
+	if heading < 0 {
+		heading += 360
+	}
+	turtle.current.heading = heading
+}
+
+// Heading returns the turtle's direction in degrees, in [0, 360).
+func (turtle *Turtle) Heading() float64 {
+	return turtle.current.heading
+}
+
+// Goto moves the turtle to a position in canvas coordinates without turning,
+// drawing a line when the pen is down.
+func (turtle *Turtle) Goto(x, y float64) {
+	if turtle.current.penDown {
+		if turtle.current.color == canvas.ColorDefault {
+			draw.Line(turtle.canvas, turtle.current.x, turtle.current.y, x, y)
+		} else {
+			draw.LineColor(turtle.canvas, turtle.current.x, turtle.current.y, x, y, turtle.current.color)
+		}
+	}
+	turtle.current.x, turtle.current.y = x, y
+}
+
+// Position returns the turtle's position in canvas coordinates.
+func (turtle *Turtle) Position() (x, y float64) {
+	return turtle.current.x, turtle.current.y
+}
+
+// PenUp lifts the pen, so moving no longer draws.
+func (turtle *Turtle) PenUp() {
+	turtle.current.penDown = false
+}
+
+// PenDown lowers the pen, so moving draws.
+func (turtle *Turtle) PenDown() {
+	turtle.current.penDown = true
+}
+
+// IsDown reports whether the pen is down.
+func (turtle *Turtle) IsDown() bool {
+	return turtle.current.penDown
+}
+
+// SetPenColor sets the color of lines drawn from now on. Colors only show on
+// canvases created with canvas.WithColor().
+func (turtle *Turtle) SetPenColor(color canvas.Color) {
+	turtle.current.color = color
+}
+
+// PenColor returns the pen color.
+func (turtle *Turtle) PenColor() canvas.Color {
+	return turtle.current.color
+}
+
+// PushState saves the turtle's position, heading, pen state, and pen color.
+func (turtle *Turtle) PushState() {
+	turtle.saved = append(turtle.saved, turtle.current)
+}
+
+// PopState restores the state saved by the most recent PushState, without
+// drawing, and reports whether there was one to restore.
+func (turtle *Turtle) PopState() bool {
+	if len(turtle.saved) == 0 {
+		return false
+	}
+	turtle.current = turtle.saved[len(turtle.saved)-1]
+	turtle.saved = turtle.saved[:len(turtle.saved)-1]
+	return true
+}
+
+// direction returns the unit vector of a heading, with y up. Components that
+// should be zero are snapped to it, so moves along the axes stay on whole
+// pixels instead of drifting by rounding error.
+func direction(heading float64) (x, y float64) {
+	radians := heading * math.Pi / 180
+	x, y = math.Cos(radians), math.Sin(radians)
+	if math.Abs(x) < 1e-12 {
+		x = 0
+	}
+	if math.Abs(y) < 1e-12 {
+		y = 0
+	}
+	return x, y
+}
+
+// settle rounds away floating-point error accumulated over many moves, so a
+// closed path such as a hexagon ends exactly where it started.
+func settle(value float64) float64 {
+	return math.Round(value*1e9) / 1e9
+}
@@ -0,0 +1,182 @@
+package turtle
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+func TestNew(t *testing.T) {
+	turtle := New(canvas.New(20, 12))
+	if x, y := turtle.Position(); x != 10 || y != 6 {
+		t.Errorf("Position() = (%v, %v), want (10, 6)", x, y)
+	}
+	if heading := turtle.Heading(); heading != 0 {
+		t.Errorf("Heading() = %v, want 0", heading)
+	}
+	if !turtle.IsDown() {
+		t.Error("IsDown() = false, want true")
+	}
+}
+
+func TestForwardAndTurns(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands func(turtle *Turtle)
+		expectX  float64
+		expectY  float64
+	}{
+		{"forward", func(turtle *Turtle) { turtle.Forward(5) }, 15, 10},
+		{"back", func(turtle *Turtle) { turtle.Back(5) }, 5, 10},
+		{"left is up", func(turtle *Turtle) { turtle.Left(90); turtle.Forward(5) }, 10, 5},
+		{"right is down", func(turtle *Turtle) { turtle.Right(90); turtle.Forward(5) }, 10, 15},
+		{"heading", func(turtle *Turtle) { turtle.SetHeading(180); turtle.Forward(4) }, 6, 10},
+		{"goto", func(turtle *Turtle) { turtle.Goto(3, 4) }, 3, 4},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			turtle := New(canvas.New(20, 20))
+			testCase.commands(turtle)
+			if x, y := turtle.Position(); x != testCase.expectX || y != testCase.expectY {
+				t.Errorf("Position() = (%v, %v), want (%v, %v)", x, y, testCase.expectX, testCase.expectY)
+			}
+		})
+	}
+}
+
+func TestSetHeadingNormalizes(t *testing.T) {
+	tests := []struct {
+		heading  float64
+		expected float64
+	}{
+		{0, 0},
+		{360, 0},
+		{450, 90},
+		{-90, 270},
+		{-720, 0},
+	}
+
+	turtle := New(canvas.New(4, 4))
+	for _, testCase := range tests {
+		turtle.SetHeading(testCase.heading)
+		if heading := turtle.Heading(); heading != testCase.expected {
+			t.Errorf("SetHeading(%v): Heading() = %v, want %v", testCase.heading, heading, testCase.expected)
+		}
+	}
+}
+
+func TestSquare(t *testing.T) {
+	actual := canvas.New(20, 20)
+	turtle := New(actual)
+	turtle.Goto(2, 2)
+	turtle.Goto(2, 2)
+	for range 4 {
+		turtle.Forward(10)
+		turtle.Right(90)
+	}
+
+	expected := canvas.New(20, 20)
+	draw.Line(expected, 10, 10, 2, 2)
+	draw.Rectangle(expected, 2, 2, 11, 11)
+	stippletest.AssertEqual(t, expected, actual)
+
+	if x, y := turtle.Position(); x != 2 || y != 2 {
+		t.Errorf("Position() after a square = (%v, %v), want (2, 2)", x, y)
+	}
+}
+
+func TestClosedPolygonReturnsHome(t *testing.T) {
+	turtle := New(canvas.New(40, 40))
+	for range 6 {
+		turtle.Forward(7)
+		turtle.Left(60)
+	}
+	if x, y := turtle.Position(); x != 20 || y != 20 {
+		t.Errorf("Position() after a hexagon = (%v, %v), want (20, 20)", x, y)
+	}
+}
+
+func TestPenUp(t *testing.T) {
+	c := canvas.New(20, 20)
+	turtle := New(c)
+	turtle.PenUp()
+	turtle.Forward(5)
+	if turtle.IsDown() {
+		t.Error("IsDown() = true after PenUp(), want false")
+	}
+	turtle.PenDown()
+	turtle.Forward(3)
+
+	expected := canvas.New(20, 20)
+	draw.Line(expected, 15, 10, 18, 10)
+	stippletest.AssertEqual(t, expected, c)
+}
+
+func TestPushPopState(t *testing.T) {
+	turtle := New(canvas.New(20, 20))
+	turtle.PushState()
+	turtle.Left(45)
+	turtle.Forward(5)
+	turtle.PenUp()
+	turtle.SetPenColor(canvas.ColorRed)
+
+	if !turtle.PopState() {
+		t.Fatal("PopState() = false, want true")
+	}
+	if x, y := turtle.Position(); x != 10 || y != 10 {
+		t.Errorf("Position() = (%v, %v), want (10, 10)", x, y)
+	}
+	if turtle.Heading() != 0 || !turtle.IsDown() || turtle.PenColor() != canvas.ColorDefault {
+		t.Errorf("state = heading %v, down %v, color %v, want 0, true, default",
+			turtle.Heading(), turtle.IsDown(), turtle.PenColor())
+	}
+	if turtle.PopState() {
+		t.Error("PopState() with nothing saved = true, want false")
+	}
+}
+
+func TestPenColor(t *testing.T) {
+	c := canvas.New(20, 20, canvas.WithColor())
+	turtle := New(c)
+	turtle.SetPenColor(canvas.ColorGreen)
+	turtle.Forward(4)
+
+	if color := c.GetColor(12, 10); color != canvas.ColorGreen {
+		t.Errorf("GetColor(12, 10) = %d, want %d", color, canvas.ColorGreen)
+	}
+}
+
+func TestInvertedY(t *testing.T) {
+	// From the same screen pixel, the same commands draw the same picture on
+	// either kind of canvas
+	commands := func(turtle *Turtle) {
+		turtle.Left(90)
+		turtle.Forward(6)
+		turtle.Right(90)
+		turtle.Forward(4)
+		turtle.Right(135)
+		turtle.Forward(4 * math.Sqrt2)
+	}
+
+	normal := canvas.New(20, 20)
+	normalTurtle := New(normal)
+	normalTurtle.PenUp()
+	normalTurtle.Goto(6, 12)
+	normalTurtle.PenDown()
+	commands(normalTurtle)
+
+	inverted := canvas.New(20, 20, canvas.WithInvertedY())
+	invertedTurtle := New(inverted)
+	invertedTurtle.PenUp()
+	invertedTurtle.Goto(6, 7)
+	invertedTurtle.PenDown()
+	commands(invertedTurtle)
+
+	if normal.Frame() != inverted.Frame() {
+		t.Errorf("inverted frame:\n%s\nwant:\n%s", inverted.Frame(), normal.Frame())
+	}
+}
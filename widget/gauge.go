@@ -0,0 +1,44 @@
+package widget
+
+import (
+	"math"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// Gauge is a bar filled in proportion to a value, such as health or speed.
+//
+// The fill ends at pixel resolution, so with braille it moves in half-cell
+// steps horizontally and quarter-cell steps vertically. A horizontal gauge
+// fills from the left and a vertical gauge from the bottom, across every
+// pixel of its cells.
+type Gauge struct {
+	Value       float64      // current value, clamped to the range
+	Min         float64      // value of an empty gauge
+	Max         float64      // value of a full gauge; the range is 0 to 1 when Max <= Min
+	Orientation Orientation  // fill direction
+	Color       canvas.Color // fill color
+	Label       string       // text centered over the gauge, empty for none
+	LabelColor  canvas.Color // label color
+}
+
+// Draw draws the gauge into bounds, turning off the pixels past the fill.
+func (gauge Gauge) Draw(c *canvas.Canvas, bounds canvas.Rect) {
+	area := cellRegion(c, bounds)
+	if area.empty() {
+		return
+	}
+	left, top, width, height := area.pixels(c)
+	along, across := gauge.Orientation.lengths(width, height)
+	filled := int(math.Round(fraction(gauge.Value, gauge.Min, gauge.Max) * float64(along)))
+
+	for position := range along {
+		for offset := range across {
+			x, y := gauge.Orientation.pixel(left, top, height, position, offset)
+			setPixel(c, x, y, position < filled, gauge.Color)
+		}
+	}
+	if gauge.Label != "" {
+		area.centerText(c, area.row+area.rows/2, gauge.Label, gauge.LabelColor)
+	}
+}
@@ -0,0 +1,93 @@
+package widget
+
+import (
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+func TestGaugeFill(t *testing.T) {
+	tests := []struct {
+		name   string
+		gauge  Gauge
+		filled float64
+	}{
+		{"empty", Gauge{Value: 0}, 0},
+		{"half", Gauge{Value: 0.5}, 8},
+		{"sub-cell", Gauge{Value: 3, Max: 16}, 3},
+		{"range", Gauge{Value: 75, Min: 50, Max: 150}, 4},
+		{"clamped low", Gauge{Value: -1}, 0},
+		{"clamped high", Gauge{Value: 2}, 16},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := canvas.New(16, 8)
+			draw.RectangleFilled(actual, 0, 0, 16, 8)
+			testCase.gauge.Draw(actual, canvas.Rect{Y: 4, Width: 16, Height: 4})
+
+			// The gauge replaces only its own row
+			expected := canvas.New(16, 8)
+			draw.RectangleFilled(expected, 0, 0, 16, 4)
+			draw.RectangleFilled(expected, 0, 4, testCase.filled, 4)
+			stippletest.AssertEqual(t, expected, actual)
+		})
+	}
+}
+
+func TestGaugeVertical(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []canvas.Option
+		expected func(c *canvas.Canvas)
+	}{
+		{"fills from bottom", nil, func(c *canvas.Canvas) { draw.RectangleFilled(c, 0, 13, 4, 3) }},
+		{"inverted", []canvas.Option{canvas.WithInvertedY()}, func(c *canvas.Canvas) { draw.RectangleFilled(c, 0, 0, 4, 3) }},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := canvas.New(4, 16, testCase.options...)
+			Gauge{Value: 0.2, Orientation: Vertical}.Draw(actual, canvas.Rect{})
+
+			expected := canvas.New(4, 16, testCase.options...)
+			testCase.expected(expected)
+			stippletest.AssertEqual(t, expected, actual)
+			stippletest.PrintVisual(t, "TestGaugeVertical "+testCase.name, actual)
+		})
+	}
+}
+
+func TestGaugeColor(t *testing.T) {
+	actual := canvas.New(8, 2, canvas.WithHalfBlock(), canvas.WithColor())
+	Gauge{Value: 0.5, Color: canvas.ColorGreen}.Draw(actual, canvas.Rect{})
+
+	expected := canvas.New(8, 2, canvas.WithHalfBlock(), canvas.WithColor())
+	draw.RectangleFilledColor(expected, 0, 0, 4, 2, canvas.ColorGreen)
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestGaugeLabel(t *testing.T) {
+	tests := []struct {
+		name     string
+		columns  int
+		label    string
+		expected string
+	}{
+		{"centered", 10, "HP 80", "  HP 80   "},
+		{"shortened", 4, "HP 80", "HP …"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := canvas.NewCells(testCase.columns, 3)
+			Gauge{Value: 0.8, Label: testCase.label}.Draw(c, canvas.Rect{})
+			if actual := rowText(c, 1); actual != testCase.expected {
+				t.Errorf("middle row = %q, want %q", actual, testCase.expected)
+			}
+			stippletest.PrintVisual(t, "TestGaugeLabel "+testCase.name, c)
+		})
+	}
+}
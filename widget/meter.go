@@ -0,0 +1,79 @@
+package widget
+
+import (
+	"math"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// Threshold colors the segments of a meter from a point in its range upward.
+type Threshold struct {
+	At    float64      // fraction of the range, from 0 to 1, where the color starts
+	Color canvas.Color // color of segments whose centers are at or past At
+}
+
+// Meter is a level indicator made of separate segments, such as a signal or
+// volume meter, that lights one segment per step and can change color toward
+// the top of its range.
+//
+// Segments are separated by a one-pixel gap. Lit segments fill every pixel
+// across the meter; unlit segments show a one-pixel track through their
+// middle so the scale stays visible.
+type Meter struct {
+	Value       float64      // current value, clamped to the range
+	Min         float64      // value with no segments lit
+	Max         float64      // value with every segment lit; the range is 0 to 1 when Max <= Min
+	Segments    int          // number of segments, 0 for one per cell along the meter
+	Orientation Orientation  // fill direction
+	Color       canvas.Color // color of segments below every threshold
+	Thresholds  []Threshold  // segment colors, in increasing order of At
+}
+
+// Draw draws the meter into bounds. Segments are limited to at least two
+// pixels each, so a meter given too little room draws fewer of them.
+func (meter Meter) Draw(c *canvas.Canvas, bounds canvas.Rect) {
+	area := cellRegion(c, bounds)
+	if area.empty() {
+		return
+	}
+	area.clear(c)
+	left, top, width, height := area.pixels(c)
+	along, across := meter.Orientation.lengths(width, height)
+
+	segments := meter.Segments
+	if segments <= 0 {
+		segments, _ = meter.Orientation.lengths(area.columns, area.rows)
+	}
+	segments = min(segments, max(along/2, 1))
+	lit := int(math.Round(fraction(meter.Value, meter.Min, meter.Max) * float64(segments)))
+
+	for index := range segments {
+		start, end := index*along/segments, (index+1)*along/segments
+		if end-start > 1 {
+			end--
+		}
+		color := meter.segmentColor(index, segments)
+		for position := start; position < end; position++ {
+			for offset := range across {
+				if index >= lit && offset != across/2 {
+					continue
+				}
+				x, y := meter.Orientation.pixel(left, top, height, position, offset)
+				setPixel(c, x, y, true, color)
+			}
+		}
+	}
+}
+
+// segmentColor returns the color of the index-th of count segments: the color
+// of the last threshold at or below the segment's center.
+func (meter Meter) segmentColor(index, count int) canvas.Color {
+	center := (float64(index) + 0.5) / float64(count)
+	color := meter.Color
+	for _, threshold := range meter.Thresholds {
+		if center >= threshold.At {
+			color = threshold.Color
+		}
+	}
+	return color
+}
@@ -0,0 +1,93 @@
+package widget
+
+import (
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+func TestMeterSegments(t *testing.T) {
+	tests := []struct {
+		name     string
+		meter    Meter
+		expected func(c *canvas.Canvas)
+	}{
+		{"one per cell", Meter{Value: 0.5}, func(c *canvas.Canvas) {
+			// Four 2-pixel cells: two lit segments, two tracks, 1-pixel gaps
+			draw.RectangleFilled(c, 0, 0, 1, 4)
+			draw.RectangleFilled(c, 2, 0, 1, 4)
+			c.Set(4, 2)
+			c.Set(6, 2)
+		}},
+		{"explicit count", Meter{Value: 1, Segments: 2}, func(c *canvas.Canvas) {
+			draw.RectangleFilled(c, 0, 0, 3, 4)
+			draw.RectangleFilled(c, 4, 0, 3, 4)
+		}},
+		{"limited to room", Meter{Value: 1, Segments: 10}, func(c *canvas.Canvas) {
+			for x := 0.0; x < 8; x += 2 {
+				draw.RectangleFilled(c, x, 0, 1, 4)
+			}
+		}},
+		{"range", Meter{Value: 5, Min: 0, Max: 20}, func(c *canvas.Canvas) {
+			draw.RectangleFilled(c, 0, 0, 1, 4)
+			c.Set(2, 2)
+			c.Set(4, 2)
+			c.Set(6, 2)
+		}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := canvas.New(8, 4)
+			draw.RectangleFilled(actual, 0, 0, 8, 4)
+			testCase.meter.Draw(actual, canvas.Rect{})
+
+			expected := canvas.New(8, 4)
+			testCase.expected(expected)
+			stippletest.AssertEqual(t, expected, actual)
+			stippletest.PrintVisual(t, "TestMeterSegments "+testCase.name, actual)
+		})
+	}
+}
+
+func TestMeterVertical(t *testing.T) {
+	actual := canvas.New(2, 8, canvas.WithHalfBlock())
+	Meter{Value: 0.25, Orientation: Vertical}.Draw(actual, canvas.Rect{})
+
+	// Four 2-pixel cells from the bottom: the lowest is lit
+	expected := canvas.New(2, 8, canvas.WithHalfBlock())
+	draw.RectangleFilled(expected, 0, 7, 2, 1)
+	for y := 1.0; y < 7; y += 2 {
+		expected.Set(1, y)
+	}
+	stippletest.AssertEqual(t, expected, actual)
+}
+
+func TestMeterThresholds(t *testing.T) {
+	meter := Meter{
+		Segments:   10,
+		Color:      canvas.ColorGreen,
+		Thresholds: []Threshold{{At: 0.6, Color: canvas.ColorYellow}, {At: 0.85, Color: canvas.ColorRed}},
+	}
+	expected := []canvas.Color{
+		canvas.ColorGreen, canvas.ColorGreen, canvas.ColorGreen, canvas.ColorGreen, canvas.ColorGreen, canvas.ColorGreen,
+		canvas.ColorYellow, canvas.ColorYellow, canvas.ColorRed, canvas.ColorRed,
+	}
+	for index, color := range expected {
+		if actual := meter.segmentColor(index, 10); actual != color {
+			t.Errorf("segmentColor(%d, 10) = %v, want %v", index, actual, color)
+		}
+	}
+
+	c := canvas.New(20, 2, canvas.WithHalfBlock(), canvas.WithColor())
+	meter.Value = 1
+	meter.Draw(c, canvas.Rect{})
+	for index, color := range expected {
+		if actual := c.GetColor(float64(index*2), 0); actual != color {
+			t.Errorf("segment %d color = %v, want %v", index, actual, color)
+		}
+	}
+	stippletest.PrintVisual(t, "TestMeterThresholds", c)
+}
@@ -0,0 +1,149 @@
+package widget
+
+import (
+	"strings"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+)
+
+// Border is the style of a panel's frame.
+type Border uint8
+
+// Available borders.
+const (
+	BorderLine    Border = iota // single box-drawing lines: ┌─┐
+	BorderRounded               // single lines with rounded corners: ╭─╮
+	BorderDouble                // double box-drawing lines: ╔═╗
+	BorderHeavy                 // heavy box-drawing lines: ┏━┓
+	BorderBraille               // a one-pixel line around the panel's outermost pixels
+	BorderNone                  // no border
+)
+
+// box holds the characters of a box-drawing border.
+type box struct {
+	horizontal  rune
+	vertical    rune
+	topLeft     rune
+	topRight    rune
+	bottomLeft  rune
+	bottomRight rune
+}
+
+// boxes maps text borders to their characters.
+var boxes = map[Border]box{
+	BorderLine:    {'─', '│', '┌', '┐', '└', '┘'},
+	BorderRounded: {'─', '│', '╭', '╮', '╰', '╯'},
+	BorderDouble:  {'═', '║', '╔', '╗', '╚', '╝'},
+	BorderHeavy:   {'━', '┃', '┏', '┓', '┗', '┛'},
+}
+
+// Panel is a cleared region with an optional border and title, holding
+// another widget.
+//
+// The border takes the outermost cells of the panel, and the title is written
+// into the top border. A panel without a border gives its title the top row.
+// Panels smaller than 2x2 cells draw no border.
+type Panel struct {
+	Title      string       // text in the top border, empty for none
+	Border     Border       // frame style
+	Color      canvas.Color // border color
+	TitleColor canvas.Color // title color, the border color when ColorDefault
+	Content    Widget       // widget drawn inside the border, nil for none
+}
+
+// Draw clears the panel's cells, draws its border and title, then draws the
+// content into the inner region.
+func (panel Panel) Draw(c *canvas.Canvas, bounds canvas.Rect) {
+	area := cellRegion(c, bounds)
+	if area.empty() {
+		return
+	}
+	area.clear(c)
+	if panel.bordered(area) {
+		panel.drawBorder(c, area)
+	}
+	panel.drawTitle(c, area)
+
+	inner := panel.inner(area)
+	if panel.Content != nil && !inner.empty() {
+		panel.Content.Draw(c, inner.rect(c))
+	}
+}
+
+// Inner returns the region inside the panel's border and title when it is
+// drawn into bounds, for drawing content without a Widget. The region is
+// empty when no cells are left.
+func (panel Panel) Inner(c *canvas.Canvas, bounds canvas.Rect) canvas.Rect {
+	inner := panel.inner(cellRegion(c, bounds))
+	if inner.empty() {
+		return canvas.Rect{}
+	}
+	return inner.rect(c)
+}
+
+// bordered reports whether the panel draws a border in area.
+func (panel Panel) bordered(area region) bool {
+	return panel.Border != BorderNone && area.columns >= 2 && area.rows >= 2
+}
+
+// inner returns the cells left for content in area.
+func (panel Panel) inner(area region) region {
+	switch {
+	case panel.bordered(area):
+		return area.inset(1)
+	case panel.Title != "" && area.rows > 1:
+		area.row++
+		area.rows--
+	}
+	return area
+}
+
+// drawBorder draws the border around the edge of area.
+func (panel Panel) drawBorder(c *canvas.Canvas, area region) {
+	if panel.Border == BorderBraille {
+		rect := area.rect(c)
+		if panel.Color == canvas.ColorDefault {
+			draw.Rectangle(c, rect.X, rect.Y, rect.Width, rect.Height)
+		} else {
+			draw.RectangleColor(c, rect.X, rect.Y, rect.Width, rect.Height, panel.Color)
+		}
+		return
+	}
+
+	glyphs, ok := boxes[panel.Border]
+	if !ok {
+		return
+	}
+	span := strings.Repeat(string(glyphs.horizontal), area.columns-2)
+	bottom := area.row + area.rows - 1
+	c.SetTextColor(area.column, area.row, string(glyphs.topLeft)+span+string(glyphs.topRight), panel.Color)
+	c.SetTextColor(area.column, bottom, string(glyphs.bottomLeft)+span+string(glyphs.bottomRight), panel.Color)
+	for row := area.row + 1; row < bottom; row++ {
+		c.SetTextColor(area.column, row, string(glyphs.vertical), panel.Color)
+		c.SetTextColor(area.column+area.columns-1, row, string(glyphs.vertical), panel.Color)
+	}
+}
+
+// drawTitle writes the title into the top border, padded with a space on each
+// side when there is room, or onto the top row when there is no border.
+func (panel Panel) drawTitle(c *canvas.Canvas, area region) {
+	if panel.Title == "" {
+		return
+	}
+	color := panel.TitleColor
+	if color == canvas.ColorDefault {
+		color = panel.Color
+	}
+	if !panel.bordered(area) {
+		c.SetTextColor(area.column, area.row, truncate(panel.Title, area.columns), color)
+		return
+	}
+
+	width := area.columns - 2
+	title := truncate(panel.Title, width)
+	if width >= 3 {
+		title = " " + truncate(panel.Title, width-2) + " "
+	}
+	c.SetTextColor(area.column+1, area.row, title, color)
+}
@@ -0,0 +1,134 @@
+package widget
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+func TestPanelBorders(t *testing.T) {
+	tests := []struct {
+		name     string
+		border   Border
+		expected []string
+	}{
+		{"line", BorderLine, []string{"┌──────┐", "│      │", "└──────┘"}},
+		{"rounded", BorderRounded, []string{"╭──────╮", "│      │", "╰──────╯"}},
+		{"double", BorderDouble, []string{"╔══════╗", "║      ║", "╚══════╝"}},
+		{"heavy", BorderHeavy, []string{"┏━━━━━━┓", "┃      ┃", "┗━━━━━━┛"}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := canvas.New(16, 12)
+			Panel{Border: testCase.border}.Draw(c, canvas.Rect{})
+			for row, expected := range testCase.expected {
+				if actual := rowText(c, row); actual != expected {
+					t.Errorf("row %d = %q, want %q", row, actual, expected)
+				}
+			}
+			stippletest.PrintVisual(t, "TestPanelBorders "+testCase.name, c)
+		})
+	}
+}
+
+func TestPanelTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		border   Border
+		columns  int
+		title    string
+		expected string
+	}{
+		{"padded", BorderLine, 10, "Map", "┌ Map ───┐"},
+		{"shortened", BorderLine, 8, "Status", "┌ Sta… ┐"},
+		{"no room for padding", BorderLine, 4, "Status", "┌S…┐"},
+		{"no border", BorderNone, 6, "Status", "Status"},
+		{"no border shortened", BorderNone, 4, "Status", "Sta…"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := canvas.NewCells(testCase.columns, 3)
+			Panel{Title: testCase.title, Border: testCase.border}.Draw(c, canvas.Rect{})
+			if actual := rowText(c, 0); actual != testCase.expected {
+				t.Errorf("top row = %q, want %q", actual, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestPanelTitleColor(t *testing.T) {
+	c := canvas.NewCells(10, 3, canvas.WithColor())
+	Panel{Title: "Map", Color: canvas.ColorBlue, TitleColor: canvas.ColorYellow}.Draw(c, canvas.Rect{})
+	frame := c.Frame()
+	for _, sequence := range []string{canvas.ColorBlue.ANSI() + "┌", canvas.ColorYellow.ANSI() + "M"} {
+		if !strings.Contains(frame, sequence) {
+			t.Errorf("Frame() = %q, want it to contain %q", frame, sequence)
+		}
+	}
+}
+
+func TestPanelBrailleBorder(t *testing.T) {
+	actual := canvas.New(16, 12)
+	Panel{Border: BorderBraille}.Draw(actual, canvas.Rect{X: 2, Y: 0, Width: 12, Height: 12})
+
+	expected := canvas.New(16, 12)
+	draw.Rectangle(expected, 2, 0, 12, 12)
+	stippletest.AssertEqual(t, expected, actual)
+	stippletest.PrintVisual(t, "TestPanelBrailleBorder", actual)
+}
+
+func TestPanelClearsAndDrawsContent(t *testing.T) {
+	c := canvas.New(16, 12)
+	draw.RectangleFilled(c, 0, 0, 16, 12)
+	panel := Panel{Border: BorderLine, Content: Gauge{Value: 1}}
+	panel.Draw(c, canvas.Rect{X: 0, Y: 0, Width: 12, Height: 12})
+
+	expected := canvas.New(16, 12)
+	draw.RectangleFilled(expected, 12, 0, 4, 12)
+	draw.RectangleFilled(expected, 2, 4, 8, 4)
+	stippletest.AssertEqual(t, expected, c)
+	stippletest.PrintVisual(t, "TestPanelClearsAndDrawsContent", c)
+}
+
+func TestPanelInner(t *testing.T) {
+	tests := []struct {
+		name     string
+		panel    Panel
+		options  []canvas.Option
+		bounds   canvas.Rect
+		expected canvas.Rect
+	}{
+		{"border", Panel{}, nil, canvas.Rect{}, canvas.Rect{X: 2, Y: 4, Width: 12, Height: 8}},
+		{"braille border", Panel{Border: BorderBraille}, nil, canvas.Rect{}, canvas.Rect{X: 2, Y: 4, Width: 12, Height: 8}},
+		{"no border", Panel{Border: BorderNone}, nil, canvas.Rect{}, canvas.Rect{X: 0, Y: 0, Width: 16, Height: 16}},
+		{"title without border", Panel{Border: BorderNone, Title: "HUD"}, nil, canvas.Rect{}, canvas.Rect{X: 0, Y: 4, Width: 16, Height: 12}},
+		{"inverted", Panel{}, []canvas.Option{canvas.WithInvertedY()}, canvas.Rect{X: 0, Y: 0, Width: 16, Height: 12}, canvas.Rect{X: 2, Y: 4, Width: 12, Height: 4}},
+		{"too small for border", Panel{}, nil, canvas.Rect{Width: 2, Height: 16}, canvas.Rect{X: 0, Y: 0, Width: 2, Height: 16}},
+		{"nothing left", Panel{}, nil, canvas.Rect{Width: 4, Height: 16}, canvas.Rect{}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := canvas.New(16, 16, testCase.options...)
+			if actual := testCase.panel.Inner(c, testCase.bounds); actual != testCase.expected {
+				t.Errorf("Inner(%v) = %v, want %v", testCase.bounds, actual, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestPanelTooSmallForBorder(t *testing.T) {
+	c := canvas.NewCells(1, 3)
+	Panel{Title: "Status"}.Draw(c, canvas.Rect{})
+	if actual := rowText(c, 0); actual != "S" {
+		t.Errorf("top row = %q, want %q", actual, "S")
+	}
+	if actual := rowText(c, 1); actual != " " {
+		t.Errorf("second row = %q, want no border", actual)
+	}
+}
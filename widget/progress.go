@@ -0,0 +1,66 @@
+package widget
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// minimumBarColumns is the fewest cells a progress bar keeps for the bar
+// itself before dropping its percentage and label.
+const minimumBarColumns = 2
+
+// ProgressBar shows how much of a task is complete: an optional label on the
+// left, a bar in the middle, and the percentage on the right.
+//
+// The completed part of the bar is filled and the rest is a one-pixel track
+// through its middle. When the cells are too narrow, the label is shortened
+// and then dropped, and then the percentage is dropped, so at least two cells
+// stay for the bar.
+type ProgressBar struct {
+	Progress    float64      // completed fraction, from 0 to 1
+	Label       string       // text before the bar, empty for none
+	HidePercent bool         // whether to leave out the percentage
+	Color       canvas.Color // bar color
+	TextColor   canvas.Color // label and percentage color
+}
+
+// Draw draws the progress bar into bounds. The label and percentage go on the
+// middle row of the cells, and the bar fills every row.
+func (bar ProgressBar) Draw(c *canvas.Canvas, bounds canvas.Rect) {
+	area := cellRegion(c, bounds)
+	if area.empty() {
+		return
+	}
+	area.clear(c)
+	progress := fraction(bar.Progress, 0, 1)
+	row := area.row + area.rows/2
+
+	track := area
+	if !bar.HidePercent {
+		// Floor so 100% only shows once the task is complete
+		percent := fmt.Sprintf("%3d%%", int(math.Floor(progress*100)))
+		if width := len(percent) + 1; track.columns-width >= minimumBarColumns {
+			c.SetTextColor(track.column+track.columns-len(percent), row, percent, bar.TextColor)
+			track.columns -= width
+		}
+	}
+	if bar.Label != "" {
+		if room := track.columns - minimumBarColumns - 1; room > 0 {
+			label := []rune(truncate(bar.Label, room))
+			c.SetTextColor(track.column, row, string(label), bar.TextColor)
+			track.column += len(label) + 1
+			track.columns -= len(label) + 1
+		}
+	}
+
+	left, top, width, height := track.pixels(c)
+	filled := int(math.Round(progress * float64(width)))
+	for y := range height {
+		for x := range width {
+			lit := x < filled || y == height/2
+			setPixel(c, left+x, top+y, lit, bar.Color)
+		}
+	}
+}
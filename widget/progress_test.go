@@ -0,0 +1,51 @@
+package widget
+
+import (
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+	"github.com/cboone/stipple/draw"
+	"github.com/cboone/stipple/stippletest"
+)
+
+func TestProgressBarLayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		bar      ProgressBar
+		columns  int
+		expected string
+	}{
+		{"label and percent", ProgressBar{Progress: 0.42, Label: "Load"}, 16, "Load         42%"},
+		{"complete", ProgressBar{Progress: 1}, 8, "    100%"},
+		{"nearly complete", ProgressBar{Progress: 0.999}, 8, "     99%"},
+		{"label shortened", ProgressBar{Progress: 0.5, Label: "Loading"}, 10, "L…     50%"},
+		{"label dropped", ProgressBar{Progress: 0.5, Label: "Loading"}, 7, "    50%"},
+		{"percent dropped", ProgressBar{Progress: 0.5, Label: "Loading"}, 6, "Lo…   "},
+		{"hidden percent", ProgressBar{Progress: 0.5, Label: "Load", HidePercent: true}, 8, "Load    "},
+		{"bar only", ProgressBar{Progress: 0.5, Label: "Load"}, 2, "  "},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := canvas.NewCells(testCase.columns, 1)
+			testCase.bar.Draw(c, canvas.Rect{})
+			if actual := rowText(c, 0); actual != testCase.expected {
+				t.Errorf("row = %q, want %q", actual, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestProgressBarFill(t *testing.T) {
+	actual := canvas.NewCells(10, 1)
+	draw.RectangleFilled(actual, 0, 0, 20, 4)
+	ProgressBar{Progress: 0.25, Label: "A"}.Draw(actual, canvas.Rect{})
+
+	// The label takes two cells and the percentage five, leaving 6 pixels of
+	// bar: 2 filled, and a track through the middle of the rest
+	expected := canvas.NewCells(10, 1)
+	draw.RectangleFilled(expected, 4, 0, 2, 4)
+	draw.Line(expected, 6, 2, 9, 2)
+	stippletest.AssertEqual(t, expected, actual)
+	stippletest.PrintVisual(t, "TestProgressBarFill", actual)
+}
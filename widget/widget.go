@@ -0,0 +1,176 @@
+// Package widget draws HUD pieces, such as bordered panels, gauges, progress
+// bars, and meters, into a region of a canvas.
+//
+// Each widget is a plain struct drawn with Draw(c, bounds), where bounds is a
+// rectangle in canvas coordinates, or the whole canvas when empty. Borders and
+// labels are drawn as text with Canvas.SetText, so a widget occupies the
+// terminal cells that lie entirely inside bounds and lays itself out to fit
+// them: labels are shortened or dropped when there is no room. Fills are drawn
+// at pixel resolution, so with braille a gauge can end on either dot column of
+// a cell.
+//
+// Widgets replace every pixel of the cells they occupy. Text from earlier
+// frames is left in place, so call Canvas.ClearText before redrawing widgets
+// whose labels change.
+package widget
+
+import (
+	"math"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// Widget is anything that can draw itself into a region of a canvas.
+type Widget interface {
+	// Draw draws the widget into bounds, or the whole canvas when bounds is empty.
+	Draw(c *canvas.Canvas, bounds canvas.Rect)
+}
+
+// Orientation is the direction in which a widget fills.
+type Orientation uint8
+
+// Available orientations.
+const (
+	Horizontal Orientation = iota // fills left to right
+	Vertical                      // fills bottom to top
+)
+
+// lengths returns the number of pixels along and across the fill direction of
+// a region width by height pixels.
+func (orientation Orientation) lengths(width, height int) (along, across int) {
+	if orientation == Vertical {
+		return height, width
+	}
+	return width, height
+}
+
+// pixel returns the screen pixel at the given distances along and across the
+// fill direction of the region with its top-left pixel at (left, top).
+func (orientation Orientation) pixel(left, top, height, along, across int) (x, y int) {
+	if orientation == Vertical {
+		return left + across, top + height - 1 - along
+	}
+	return left + along, top + across
+}
+
+// region is a rectangle of whole terminal cells, counted from the top-left cell
+// of the canvas.
+type region struct {
+	column  int // leftmost column
+	columns int // width in cells
+	row     int // top row
+	rows    int // height in cells
+}
+
+// cellRegion returns the terminal cells lying entirely inside bounds, or inside
+// the whole canvas when bounds is empty.
+func cellRegion(c *canvas.Canvas, bounds canvas.Rect) region {
+	full := canvas.Rect{Width: float64(c.Width()), Height: float64(c.Height())}
+	if bounds.Empty() {
+		bounds = full
+	} else {
+		bounds = bounds.Intersect(full)
+	}
+	if bounds.Empty() {
+		return region{}
+	}
+
+	top := bounds.Y
+	if c.InvertedY() {
+		top = float64(c.Height()) - bounds.Y - bounds.Height
+	}
+	cellWidth, cellHeight := c.CellSize()
+	column := int(math.Ceil(bounds.X / float64(cellWidth)))
+	row := int(math.Ceil(top / float64(cellHeight)))
+	right := int(math.Floor((bounds.X + bounds.Width) / float64(cellWidth)))
+	bottom := int(math.Floor((top + bounds.Height) / float64(cellHeight)))
+	return region{column: column, columns: max(right-column, 0), row: row, rows: max(bottom-row, 0)}
+}
+
+// empty reports whether the area covers no cells.
+func (area region) empty() bool {
+	return area.columns <= 0 || area.rows <= 0
+}
+
+// inset returns the area shrunk by cells on every side.
+func (area region) inset(cells int) region {
+	return region{
+		column:  area.column + cells,
+		columns: max(area.columns-2*cells, 0),
+		row:     area.row + cells,
+		rows:    max(area.rows-2*cells, 0),
+	}
+}
+
+// pixels returns the pixels covered by the area in screen coordinates,
+// counted from the top-left corner of the display.
+func (area region) pixels(c *canvas.Canvas) (left, top, width, height int) {
+	cellWidth, cellHeight := c.CellSize()
+	return area.column * cellWidth, area.row * cellHeight, area.columns * cellWidth, area.rows * cellHeight
+}
+
+// rect returns the area as a rectangle in canvas coordinates.
+func (area region) rect(c *canvas.Canvas) canvas.Rect {
+	left, top, width, height := area.pixels(c)
+	if c.InvertedY() {
+		top = c.Height() - top - height
+	}
+	return canvas.Rect{X: float64(left), Y: float64(top), Width: float64(width), Height: float64(height)}
+}
+
+// clear turns off every pixel of the area.
+func (area region) clear(c *canvas.Canvas) {
+	left, top, width, height := area.pixels(c)
+	for row := range height {
+		for column := range width {
+			setPixel(c, left+column, top+row, false, canvas.ColorDefault)
+		}
+	}
+}
+
+// centerText writes text centered on one row of the area, shortened to fit.
+func (area region) centerText(c *canvas.Canvas, row int, text string, color canvas.Color) {
+	runes := []rune(truncate(text, area.columns))
+	c.SetTextColor(area.column+(area.columns-len(runes))/2, row, string(runes), color)
+}
+
+// setPixel lights or turns off the pixel at a screen position.
+func setPixel(c *canvas.Canvas, screenX, screenY int, lit bool, color canvas.Color) {
+	x, y := float64(screenX), c.ScreenToCanvasY(screenY)
+	switch {
+	case !lit:
+		c.Unset(x, y)
+	case color == canvas.ColorDefault:
+		c.Set(x, y)
+	default:
+		c.SetColor(x, y, color)
+	}
+}
+
+// truncate shortens text to at most width characters, ending it with an
+// ellipsis when characters were dropped.
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	switch {
+	case len(runes) <= width:
+		return text
+	case width <= 0:
+		return ""
+	case width == 1:
+		return string(runes[:1])
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// fraction returns how far value lies from low to high, between 0 and 1.
+// A range with high <= low is treated as 0 to 1, and NaN as 0.
+func fraction(value, low, high float64) float64 {
+	if high <= low {
+		low, high = 0, 1
+	}
+	result := (value - low) / (high - low)
+	if math.IsNaN(result) {
+		return 0
+	}
+	return math.Max(0, math.Min(1, result))
+}
@@ -0,0 +1,119 @@
+package widget
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/cboone/stipple/canvas"
+)
+
+// rowText returns the text written over one row of terminal cells, with a
+// space for cells without text.
+func rowText(c *canvas.Canvas, row int) string {
+	var builder strings.Builder
+	for column := range c.Cols() {
+		character := c.Text(column, row)
+		if character == 0 {
+			character = ' '
+		}
+		builder.WriteRune(character)
+	}
+	return builder.String()
+}
+
+func TestCellRegion(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []canvas.Option
+		bounds   canvas.Rect
+		expected region
+	}{
+		{"whole canvas", nil, canvas.Rect{}, region{column: 0, columns: 8, row: 0, rows: 4}},
+		{"aligned", nil, canvas.Rect{X: 2, Y: 4, Width: 6, Height: 8}, region{column: 1, columns: 3, row: 1, rows: 2}},
+		{"partial cells dropped", nil, canvas.Rect{X: 1, Y: 2, Width: 6, Height: 8}, region{column: 1, columns: 2, row: 1, rows: 1}},
+		{"limited to canvas", nil, canvas.Rect{X: 12, Y: 8, Width: 20, Height: 20}, region{column: 6, columns: 2, row: 2, rows: 2}},
+		{"outside canvas", nil, canvas.Rect{X: 20, Y: 0, Width: 4, Height: 4}, region{}},
+		{"inverted", []canvas.Option{canvas.WithInvertedY()}, canvas.Rect{X: 0, Y: 0, Width: 4, Height: 4}, region{column: 0, columns: 2, row: 3, rows: 1}},
+		{"half block", []canvas.Option{canvas.WithHalfBlock()}, canvas.Rect{X: 3, Y: 2, Width: 2, Height: 4}, region{column: 3, columns: 2, row: 1, rows: 2}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := canvas.New(16, 16, testCase.options...)
+			if actual := cellRegion(c, testCase.bounds); actual != testCase.expected {
+				t.Errorf("cellRegion(%v) = %+v, want %+v", testCase.bounds, actual, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestRegionRect(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []canvas.Option
+		expected canvas.Rect
+	}{
+		{"braille", nil, canvas.Rect{X: 2, Y: 4, Width: 6, Height: 8}},
+		{"inverted", []canvas.Option{canvas.WithInvertedY()}, canvas.Rect{X: 2, Y: 4, Width: 6, Height: 8}},
+		{"half block", []canvas.Option{canvas.WithHalfBlock()}, canvas.Rect{X: 1, Y: 2, Width: 3, Height: 4}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := canvas.New(16, 16, testCase.options...)
+			area := region{column: 1, columns: 3, row: 1, rows: 2}
+			actual := area.rect(c)
+			if actual != testCase.expected {
+				t.Errorf("rect() = %v, want %v", actual, testCase.expected)
+			}
+			if roundTrip := cellRegion(c, actual); roundTrip != area {
+				t.Errorf("cellRegion(rect()) = %+v, want %+v", roundTrip, area)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		text     string
+		width    int
+		expected string
+	}{
+		{"speed", 10, "speed"},
+		{"speed", 5, "speed"},
+		{"speed", 4, "spe…"},
+		{"speed", 1, "s"},
+		{"speed", 0, ""},
+		{"élan", 3, "él…"},
+	}
+
+	for _, testCase := range tests {
+		if actual := truncate(testCase.text, testCase.width); actual != testCase.expected {
+			t.Errorf("truncate(%q, %d) = %q, want %q", testCase.text, testCase.width, actual, testCase.expected)
+		}
+	}
+}
+
+func TestFraction(t *testing.T) {
+	tests := []struct {
+		name            string
+		value, low, top float64
+		expected        float64
+	}{
+		{"inside", 25, 0, 100, 0.25},
+		{"offset range", 15, 10, 30, 0.25},
+		{"below", -5, 0, 100, 0},
+		{"above", 150, 0, 100, 1},
+		{"empty range is 0 to 1", 0.5, 0, 0, 0.5},
+		{"NaN", math.NaN(), 0, 1, 0},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			if actual := fraction(testCase.value, testCase.low, testCase.top); actual != testCase.expected {
+				t.Errorf("fraction(%v, %v, %v) = %v, want %v", testCase.value, testCase.low, testCase.top, actual, testCase.expected)
+			}
+		})
+	}
+}